@@ -0,0 +1,45 @@
+package ai
+
+import (
+	"tetris/application"
+	"tetris/domain/model"
+)
+
+type Bot struct {
+	weights Weights
+}
+
+func NewBot(weights Weights) *Bot {
+	return &Bot{weights: weights}
+}
+
+func (b *Bot) Weights() Weights {
+	return b.weights
+}
+
+func (b *Bot) Evaluate(placement Placement) float64 {
+	return b.weights.Score(ComputeFeatures(placement.Board, placement.CompletedLines))
+}
+
+// BestPlacement は現在のピースが到達できる全配置を評価し、最も評価値の高い配置を返す
+func (b *Bot) BestPlacement(board *model.Board, piece *model.Tetromino) (Placement, error) {
+	placements, err := ReachablePlacements(board, piece)
+	if err != nil {
+		return Placement{}, err
+	}
+
+	best := placements[0]
+	bestScore := b.Evaluate(best)
+	for _, placement := range placements[1:] {
+		if score := b.Evaluate(placement); score > bestScore {
+			best = placement
+			bestScore = score
+		}
+	}
+
+	return best, nil
+}
+
+func (b *Bot) Plan(state application.GameState) (Placement, error) {
+	return b.BestPlacement(state.Board.Clone(), state.CurrentPiece.Clone())
+}
@@ -0,0 +1,168 @@
+package ai
+
+import "tetris/domain/model"
+
+type Features struct {
+	AggregateHeight   int
+	Holes             int
+	Bumpiness         int
+	CompletedLines    int
+	Wells             int
+	RowTransitions    int
+	ColumnTransitions int
+}
+
+type Weights struct {
+	AggregateHeight   float64 `json:"aggregateHeight"`
+	Holes             float64 `json:"holes"`
+	Bumpiness         float64 `json:"bumpiness"`
+	CompletedLines    float64 `json:"completedLines"`
+	Wells             float64 `json:"wells"`
+	RowTransitions    float64 `json:"rowTransitions"`
+	ColumnTransitions float64 `json:"columnTransitions"`
+}
+
+func DefaultWeights() Weights {
+	return Weights{
+		AggregateHeight:   -0.45,
+		Holes:             -1.0,
+		Bumpiness:         -0.15,
+		CompletedLines:    0.2,
+		Wells:             -0.25,
+		RowTransitions:    -0.35,
+		ColumnTransitions: -0.8,
+	}
+}
+
+func (w Weights) Score(features Features) float64 {
+	return w.AggregateHeight*float64(features.AggregateHeight) +
+		w.Holes*float64(features.Holes) +
+		w.Bumpiness*float64(features.Bumpiness) +
+		w.CompletedLines*float64(features.CompletedLines) +
+		w.Wells*float64(features.Wells) +
+		w.RowTransitions*float64(features.RowTransitions) +
+		w.ColumnTransitions*float64(features.ColumnTransitions)
+}
+
+// ComputeFeatures はライン消去後の盤面から評価値の特徴量を計算する
+func ComputeFeatures(board *model.Board, completedLines int) Features {
+	heights := columnHeights(board)
+
+	features := Features{CompletedLines: completedLines}
+	for x, height := range heights {
+		features.AggregateHeight += height
+		if x > 0 {
+			features.Bumpiness += abs(height - heights[x-1])
+		}
+	}
+
+	features.Holes = countHoles(board, heights)
+	features.Wells = countWells(board)
+	features.RowTransitions = countRowTransitions(board)
+	features.ColumnTransitions = countColumnTransitions(board)
+
+	return features
+}
+
+func columnHeights(board *model.Board) []int {
+	heights := make([]int, board.Width)
+	for x := 0; x < board.Width; x++ {
+		for y := 0; y < board.Height; y++ {
+			if board.Grid[y][x] {
+				heights[x] = board.Height - y
+				break
+			}
+		}
+	}
+	return heights
+}
+
+func countHoles(board *model.Board, heights []int) int {
+	holes := 0
+	for x, height := range heights {
+		for y := board.Height - height; y < board.Height; y++ {
+			if !board.Grid[y][x] {
+				holes++
+			}
+		}
+	}
+	return holes
+}
+
+// countWells は両隣が埋まった空きセルを井戸とみなし、深さに応じて累積する
+func countWells(board *model.Board) int {
+	wells := 0
+	for x := 0; x < board.Width; x++ {
+		depth := 0
+		for y := 0; y < board.Height; y++ {
+			if board.Grid[y][x] {
+				depth = 0
+				continue
+			}
+
+			leftFilled := x == 0 || board.Grid[y][x-1]
+			rightFilled := x == board.Width-1 || board.Grid[y][x+1]
+			if leftFilled && rightFilled {
+				depth++
+				wells += depth
+			} else {
+				depth = 0
+			}
+		}
+	}
+	return wells
+}
+
+func countRowTransitions(board *model.Board) int {
+	transitions := 0
+	for y := 0; y < board.Height; y++ {
+		if isRowEmpty(board, y) {
+			continue
+		}
+
+		previous := true
+		for x := 0; x < board.Width; x++ {
+			if board.Grid[y][x] != previous {
+				transitions++
+			}
+			previous = board.Grid[y][x]
+		}
+		if !previous {
+			transitions++
+		}
+	}
+	return transitions
+}
+
+func countColumnTransitions(board *model.Board) int {
+	transitions := 0
+	for x := 0; x < board.Width; x++ {
+		previous := false
+		for y := 0; y < board.Height; y++ {
+			if board.Grid[y][x] != previous {
+				transitions++
+			}
+			previous = board.Grid[y][x]
+		}
+		if !previous {
+			transitions++
+		}
+	}
+	return transitions
+}
+
+func isRowEmpty(board *model.Board, y int) bool {
+	for x := 0; x < board.Width; x++ {
+		if board.Grid[y][x] {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
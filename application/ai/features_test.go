@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"testing"
+	"tetris/domain/model"
+)
+
+// boardFromRows は下詰めの行を標準の大きさの盤面にする
+func boardFromRows(t testing.TB, rows ...string) *model.Board {
+	t.Helper()
+	board, err := model.ParseBoardRows(model.BoardWidth, model.BoardHeight, rows...)
+	if err != nil {
+		t.Fatalf("ParseBoardRows() error = %v", err)
+	}
+	return board
+}
+
+func TestComputeFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []string
+		lines    int
+		expected Features
+	}{
+		{
+			name:     "空の盤面",
+			rows:     []string{"....", "....", "...."},
+			expected: Features{ColumnTransitions: 4},
+		},
+		{
+			name: "穴と凹凸",
+			rows: []string{
+				"....",
+				"X.X.",
+				"..XX",
+			},
+			lines: 1,
+			expected: Features{
+				AggregateHeight:   2 + 0 + 2 + 1,
+				Holes:             1,
+				Bumpiness:         2 + 2 + 1,
+				CompletedLines:    1,
+				Wells:             1 + 1,
+				RowTransitions:    4 + 2,
+				ColumnTransitions: 3 + 1 + 1 + 1,
+			},
+		},
+		{
+			name: "右端の井戸",
+			rows: []string{
+				"XXX.",
+				"XXX.",
+			},
+			expected: Features{
+				AggregateHeight:   6,
+				Bumpiness:         2,
+				Wells:             1 + 2,
+				RowTransitions:    2 + 2,
+				ColumnTransitions: 1 + 1 + 1 + 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := model.ParseBoardRows(len(tt.rows[0]), len(tt.rows), tt.rows...)
+			if err != nil {
+				t.Fatalf("ParseBoardRows() error = %v", err)
+			}
+			features := ComputeFeatures(board, tt.lines)
+			if features != tt.expected {
+				t.Errorf("ComputeFeatures() = %+v, want %+v", features, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWeights_Score(t *testing.T) {
+	weights := Weights{AggregateHeight: -1, Holes: -2, CompletedLines: 3}
+	features := Features{AggregateHeight: 4, Holes: 1, CompletedLines: 2, Bumpiness: 10}
+
+	if got := weights.Score(features); got != -4-2+6 {
+		t.Errorf("Score() = %v, want %v", got, 0)
+	}
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"tetris/domain/model"
+	"tetris/domain/service"
+)
+
+type pieceState struct {
+	x, y, rotation int
+}
+
+type searchNode struct {
+	piece *model.Tetromino
+	moves []string
+}
+
+// ReachablePlacements は (x, y, 回転) 状態の幅優先探索で、出現位置から到達できる全ての固定位置を
+// 最短の入力手順とともに返す。ソフトドロップ後の横移動（差し込み）やキックを伴う回転も含む
+func ReachablePlacements(board *model.Board, piece *model.Tetromino) ([]Placement, error) {
+	if !board.CanPlaceTetromino(piece) {
+		return nil, ErrNoPlacement
+	}
+
+	start := piece.Clone()
+	visited := map[pieceState]bool{stateOf(start): true}
+	queue := []searchNode{{piece: start}}
+
+	var placements []Placement
+	seen := make(map[string]bool)
+	landedStates := make(map[pieceState]bool)
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		// 同じ状態への着地は1度だけ調べ、盤面のコピーを伴う固定のシミュレーションは初めて見る形に対してだけ行う
+		landed := land(board, node.piece)
+		if landedState := stateOf(landed); !landedStates[landedState] {
+			landedStates[landedState] = true
+			if key := blocksKey(landed.GetBlocks()); !seen[key] {
+				seen[key] = true
+				placement, err := lockPlacement(board, landed, append(node.moves, MoveDrop))
+				if err != nil {
+					return nil, err
+				}
+				placements = append(placements, placement)
+			}
+		}
+
+		for _, move := range []string{MoveLeft, MoveRight, MoveDown, MoveRotate} {
+			next, ok, err := applyMove(board, node.piece, move)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			state := stateOf(next)
+			if visited[state] {
+				continue
+			}
+			visited[state] = true
+
+			moves := make([]string, len(node.moves), len(node.moves)+1)
+			copy(moves, node.moves)
+			queue = append(queue, searchNode{piece: next, moves: append(moves, move)})
+		}
+	}
+
+	return placements, nil
+}
+
+func stateOf(piece *model.Tetromino) pieceState {
+	return pieceState{x: piece.Position.X, y: piece.Position.Y, rotation: piece.Rotation()}
+}
+
+// applyMove はゲームと同じ規則で1入力を適用した新しいピースを返す
+func applyMove(board *model.Board, piece *model.Tetromino, move string) (*model.Tetromino, bool, error) {
+	next := piece.Clone()
+
+	switch move {
+	case MoveLeft, MoveRight:
+		return next, shift(board, next, move), nil
+	case MoveDown:
+		next.Position.Y++
+		return next, board.CanPlaceTetromino(next), nil
+	case MoveRotate:
+		ok, err := service.TryRotate(board, next)
+		return next, ok, err
+	default:
+		return nil, false, nil
+	}
+}
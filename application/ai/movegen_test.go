@@ -0,0 +1,230 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+	"tetris/domain/model"
+	"tetris/domain/service"
+)
+
+// replayMoves は実際のGameServiceで入力手順を再生し、一気に落下した直後のブロック位置を返す
+func replayMoves(t *testing.T, board *model.Board, piece *model.Tetromino, moves []string) string {
+	t.Helper()
+
+	gameService, err := service.NewGameServiceWithOptions(service.GameOptions{Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+	for y := range board.Grid {
+		copy(gameService.GetBoard().Grid[y], board.Grid[y])
+	}
+	current := gameService.GetCurrentPiece()
+	*current = *piece.Clone()
+
+	for _, move := range moves {
+		switch move {
+		case MoveLeft:
+			err = gameService.MovePiece(model.Point{X: -1})
+		case MoveRight:
+			err = gameService.MovePiece(model.Point{X: 1})
+		case MoveDown:
+			err = gameService.MovePiece(model.Point{Y: 1})
+		case MoveRotate:
+			err = gameService.RotatePiece()
+		case MoveDrop:
+			for err == nil {
+				err = gameService.MovePiece(model.Point{Y: 1})
+			}
+			if !errors.Is(err, service.ErrInvalidMove) {
+				t.Fatalf("drop replay error = %v", err)
+			}
+			return blocksKey(gameService.GetCurrentPiece().GetBlocks())
+		}
+		if err != nil {
+			t.Fatalf("move %q in %v failed: %v", move, moves, err)
+		}
+	}
+
+	t.Fatalf("moves %v do not end with drop", moves)
+	return ""
+}
+
+func findPlacement(placements []Placement, blocks ...model.Point) (Placement, bool) {
+	key := blocksKey(blocks)
+	for _, placement := range placements {
+		if placement.Key() == key {
+			return placement, true
+		}
+	}
+	return Placement{}, false
+}
+
+func TestReachablePlacements_EmptyBoardMatchesSimpleGenerator(t *testing.T) {
+	for tetrominoType := model.I; tetrominoType <= model.L; tetrominoType++ {
+		board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+		piece := spawnPiece(t, tetrominoType, board)
+
+		reachable, err := ReachablePlacements(board, piece)
+		if err != nil {
+			t.Fatalf("ReachablePlacements() error = %v", err)
+		}
+		simple, err := referencePlacements(board, piece)
+		if err != nil {
+			t.Fatalf("referencePlacements() error = %v", err)
+		}
+
+		if len(reachable) != len(simple) {
+			t.Errorf("type %d: len(ReachablePlacements()) = %d, want %d", tetrominoType, len(reachable), len(simple))
+		}
+		for _, placement := range simple {
+			found, ok := findPlacement(reachable, placement.Piece.GetBlocks()...)
+			if !ok {
+				t.Errorf("type %d: placement %s not reachable", tetrominoType, placement.Key())
+				continue
+			}
+			if len(found.Moves) > len(placement.Moves) {
+				t.Errorf("type %d: path %v is longer than %v", tetrominoType, found.Moves, placement.Moves)
+			}
+		}
+	}
+}
+
+func TestReachablePlacements_ShortestPath(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	piece := spawnPiece(t, model.O, board)
+
+	placements, err := ReachablePlacements(board, piece)
+	if err != nil {
+		t.Fatalf("ReachablePlacements() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		blocks []model.Point
+		moves  []string
+	}{
+		{
+			name:   "そのまま落下",
+			blocks: []model.Point{{X: 4, Y: 18}, {X: 5, Y: 18}, {X: 4, Y: 19}, {X: 5, Y: 19}},
+			moves:  []string{MoveDrop},
+		},
+		{
+			name:   "左端",
+			blocks: []model.Point{{X: 0, Y: 18}, {X: 1, Y: 18}, {X: 0, Y: 19}, {X: 1, Y: 19}},
+			moves:  []string{MoveLeft, MoveLeft, MoveLeft, MoveLeft, MoveDrop},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placement, ok := findPlacement(placements, tt.blocks...)
+			if !ok {
+				t.Fatal("placement not found")
+			}
+			if len(placement.Moves) != len(tt.moves) {
+				t.Errorf("Moves = %v, want %v", placement.Moves, tt.moves)
+			}
+		})
+	}
+}
+
+func TestReachablePlacements_HandBuiltBoards(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      []string
+		piece     model.TetrominoType
+		blocks    []model.Point
+		lastMoves []string
+	}{
+		{
+			name: "屋根の下への差し込み",
+			rows: []string{
+				"...XXXXXXX",
+				"..........",
+				"..........",
+			},
+			piece:     model.O,
+			blocks:    []model.Point{{X: 8, Y: 18}, {X: 9, Y: 18}, {X: 8, Y: 19}, {X: 9, Y: 19}},
+			lastMoves: []string{MoveRight, MoveDrop},
+		},
+		{
+			name: "キックを伴うTスピン",
+			rows: []string{
+				"XXXX......",
+				"XXX...XXXX",
+				"XXXX.XXXXX",
+			},
+			piece:     model.T,
+			blocks:    []model.Point{{X: 3, Y: 18}, {X: 4, Y: 18}, {X: 5, Y: 18}, {X: 4, Y: 19}},
+			lastMoves: []string{MoveRotate, MoveDrop},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := boardFromRows(t, tt.rows...)
+			piece := spawnPiece(t, tt.piece, board)
+
+			placements, err := ReachablePlacements(board, piece)
+			if err != nil {
+				t.Fatalf("ReachablePlacements() error = %v", err)
+			}
+			placement, ok := findPlacement(placements, tt.blocks...)
+			if !ok {
+				t.Fatalf("placement %v not found", tt.blocks)
+			}
+
+			simple, err := referencePlacements(board, piece)
+			if err != nil {
+				t.Fatalf("referencePlacements() error = %v", err)
+			}
+			if _, ok := findPlacement(simple, tt.blocks...); ok {
+				t.Error("placement should not be reachable by rotate-shift-drop only")
+			}
+
+			tail := placement.Moves[len(placement.Moves)-len(tt.lastMoves):]
+			for i, move := range tt.lastMoves {
+				if tail[i] != move {
+					t.Errorf("Moves = %v, want suffix %v", placement.Moves, tt.lastMoves)
+					break
+				}
+			}
+
+			if got := replayMoves(t, board, piece, placement.Moves); got != placement.Key() {
+				t.Errorf("replayed placement = %s, want %s", got, placement.Key())
+			}
+		})
+	}
+}
+
+func TestReachablePlacements_AllPathsReplay(t *testing.T) {
+	board := boardFromRows(t,
+		"X.........",
+		"XX..X...XX",
+		"XXX.XX.XXX",
+		"XXXX.XXXXX")
+
+	for tetrominoType := model.I; tetrominoType <= model.L; tetrominoType++ {
+		piece := spawnPiece(t, tetrominoType, board)
+		placements, err := ReachablePlacements(board, piece)
+		if err != nil {
+			t.Fatalf("ReachablePlacements() error = %v", err)
+		}
+
+		for _, placement := range placements {
+			if got := replayMoves(t, board, piece, placement.Moves); got != placement.Key() {
+				t.Errorf("type %d: moves %v replay to %s, want %s", tetrominoType, placement.Moves, got, placement.Key())
+			}
+		}
+	}
+}
+
+func TestReachablePlacements_BlockedSpawn(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	piece := spawnPiece(t, model.T, board)
+	_ = board.PlaceTetromino(piece)
+
+	if _, err := ReachablePlacements(board, piece); !errors.Is(err, ErrNoPlacement) {
+		t.Errorf("ReachablePlacements() error = %v, want %v", err, ErrNoPlacement)
+	}
+}
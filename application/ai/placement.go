@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"tetris/domain/model"
+)
+
+const (
+	MoveLeft   = "left"
+	MoveRight  = "right"
+	MoveDown   = "down"
+	MoveRotate = "rotate"
+	MoveDrop   = "drop"
+)
+
+var ErrNoPlacement = errors.New("配置可能な位置がありません")
+
+type Placement struct {
+	Piece          *model.Tetromino
+	Moves          []string
+	Board          *model.Board
+	CompletedLines int
+}
+
+// Key は最終的なブロック位置を表す文字列で、回転が異なっても同じ形になる配置を同一視する
+func (p Placement) Key() string {
+	return blocksKey(p.Piece.GetBlocks())
+}
+
+func blocksKey(blocks []model.Point) string {
+	sorted := make([]model.Point, len(blocks))
+	copy(sorted, blocks)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Y != sorted[j].Y {
+			return sorted[i].Y < sorted[j].Y
+		}
+		return sorted[i].X < sorted[j].X
+	})
+
+	var key strings.Builder
+	for _, block := range sorted {
+		fmt.Fprintf(&key, "%d,%d;", block.X, block.Y)
+	}
+	return key.String()
+}
+
+func shift(board *model.Board, piece *model.Tetromino, direction string) bool {
+	delta := model.Point{X: -1}
+	if direction == MoveRight {
+		delta = model.Point{X: 1}
+	}
+
+	original := piece.Position
+	piece.Position = original.Add(delta)
+	if board.CanPlaceTetromino(piece) {
+		return true
+	}
+	piece.Position = original
+	return false
+}
+
+// land は一気に落下した後のピースを返す
+func land(board *model.Board, piece *model.Tetromino) *model.Tetromino {
+	landed := piece.Clone()
+	for {
+		landed.Position.Y++
+		if !board.CanPlaceTetromino(landed) {
+			landed.Position.Y--
+			return landed
+		}
+	}
+}
+
+func lockPlacement(board *model.Board, piece *model.Tetromino, moves []string) (Placement, error) {
+	result := board.Clone()
+	if err := result.PlaceTetromino(piece); err != nil {
+		return Placement{}, fmt.Errorf("配置シミュレーションエラー: %w", err)
+	}
+
+	completed := result.GetCompletedLines()
+	if err := result.ClearLines(completed); err != nil {
+		return Placement{}, fmt.Errorf("ライン消去シミュレーションエラー: %w", err)
+	}
+
+	return Placement{
+		Piece:          piece,
+		Moves:          append([]string(nil), moves...),
+		Board:          result,
+		CompletedLines: len(completed),
+	}, nil
+}
@@ -0,0 +1,163 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+	"tetris/domain/model"
+	"tetris/domain/service"
+)
+
+func spawnPiece(t *testing.T, tetrominoType model.TetrominoType, board *model.Board) *model.Tetromino {
+	t.Helper()
+
+	piece, err := model.NewTetromino(tetrominoType, model.Point{X: board.Width/2 - 2, Y: 0})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	return piece
+}
+
+func TestReferencePlacements_EmptyBoard(t *testing.T) {
+	tests := []struct {
+		name     string
+		piece    model.TetrominoType
+		expected int
+	}{
+		{name: "I", piece: model.I, expected: 7 + 10},
+		{name: "O", piece: model.O, expected: 9},
+		{name: "T", piece: model.T, expected: 8 + 9 + 8 + 9},
+		{name: "S", piece: model.S, expected: 8 + 9},
+		{name: "Z", piece: model.Z, expected: 8 + 9},
+		{name: "J", piece: model.J, expected: 8 + 9 + 8 + 9},
+		{name: "L", piece: model.L, expected: 8 + 9 + 8 + 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+			placements, err := referencePlacements(board, spawnPiece(t, tt.piece, board))
+			if err != nil {
+				t.Fatalf("referencePlacements() error = %v", err)
+			}
+			if len(placements) != tt.expected {
+				t.Errorf("len(referencePlacements()) = %d, want %d", len(placements), tt.expected)
+			}
+
+			for _, placement := range placements {
+				if placement.Moves[len(placement.Moves)-1] != MoveDrop {
+					t.Errorf("moves %v should end with drop", placement.Moves)
+				}
+				for _, block := range placement.Piece.GetBlocks() {
+					if block.Y < board.Height-4 {
+						t.Errorf("placement %v is not resting on the floor", placement.Piece.GetBlocks())
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReferencePlacements_DoesNotModifyInput(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	piece := spawnPiece(t, model.T, board)
+	position := piece.Position
+
+	if _, err := referencePlacements(board, piece); err != nil {
+		t.Fatalf("referencePlacements() error = %v", err)
+	}
+
+	if piece.Position != position || piece.Rotation() != 0 {
+		t.Error("referencePlacements() should not move the input piece")
+	}
+	if len(board.GetCompletedLines()) != 0 || board.Grid[board.Height-1][0] {
+		t.Error("referencePlacements() should not modify the input board")
+	}
+}
+
+func TestReferencePlacements_BlockedSpawn(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	piece := spawnPiece(t, model.O, board)
+	_ = board.PlaceTetromino(piece)
+
+	if _, err := referencePlacements(board, piece); !errors.Is(err, ErrNoPlacement) {
+		t.Errorf("referencePlacements() error = %v, want %v", err, ErrNoPlacement)
+	}
+}
+
+func TestBot_BestPlacementClearsTetris(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	for y := board.Height - 4; y < board.Height; y++ {
+		for x := 0; x < board.Width-1; x++ {
+			board.Grid[y][x] = true
+		}
+	}
+
+	bot := NewBot(DefaultWeights())
+	placement, err := bot.BestPlacement(board, spawnPiece(t, model.I, board))
+	if err != nil {
+		t.Fatalf("BestPlacement() error = %v", err)
+	}
+
+	if placement.CompletedLines != 4 {
+		t.Errorf("CompletedLines = %d, want 4 (moves %v)", placement.CompletedLines, placement.Moves)
+	}
+	if ComputeFeatures(placement.Board, 0).AggregateHeight != 0 {
+		t.Error("board should be empty after the tetris")
+	}
+}
+
+// referencePlacements は回転してから左右に移動し一気に落下する手順で到達できる全ての最終配置を列挙する。
+// ReachablePlacements と結果を突き合わせるための単純な参照実装
+func referencePlacements(board *model.Board, piece *model.Tetromino) ([]Placement, error) {
+	if !board.CanPlaceTetromino(piece) {
+		return nil, ErrNoPlacement
+	}
+
+	var found []Placement
+	seen := make(map[string]bool)
+
+	rotated := piece.Clone()
+	var rotations []string
+	for r := 0; r < piece.RotationCount(); r++ {
+		if r > 0 {
+			ok, err := service.TryRotate(board, rotated)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			rotations = append(rotations, MoveRotate)
+		}
+
+		for _, direction := range []string{"", MoveLeft, MoveRight} {
+			shifted := rotated.Clone()
+			moves := append([]string(nil), rotations...)
+			for {
+				placement, err := dropPlacement(board, shifted, append(moves, MoveDrop))
+				if err != nil {
+					return nil, err
+				}
+				if key := placement.Key(); !seen[key] {
+					seen[key] = true
+					found = append(found, placement)
+				}
+
+				if direction == "" || !shift(board, shifted, direction) {
+					break
+				}
+				moves = append(moves, direction)
+			}
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, ErrNoPlacement
+	}
+	return found, nil
+}
+
+func dropPlacement(board *model.Board, piece *model.Tetromino, moves []string) (Placement, error) {
+	return lockPlacement(board, land(board, piece), moves)
+}
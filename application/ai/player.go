@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"tetris/application"
+	"tetris/domain/model"
+)
+
+// Planner は局面から次のピースの配置と入力手順を決める
+type Planner interface {
+	Plan(state application.GameState) (Placement, error)
+}
+
+// Player はプランナーの手順を1手ずつGameControllerに入力する自動プレイヤー
+type Player struct {
+	controller *application.GameController
+	planner    Planner
+	moves      []string
+	plannedFor int
+	// expected は直前の入力の後のピースの位置。自然落下でずれていれば残りの手順は使えない
+	expected model.Point
+	planned  bool
+}
+
+func NewPlayer(controller *application.GameController, planner Planner) *Player {
+	return &Player{
+		controller: controller,
+		planner:    planner,
+	}
+}
+
+// Step は次の1手を入力する。新しいピースが出現したときと、自然落下でピースの位置が手順の想定からずれたときに計画し直す
+func (p *Player) Step() error {
+	if p.controller.IsPaused() || p.controller.IsFinished() {
+		return nil
+	}
+
+	state := p.controller.GetGameState()
+	if state.GameOver || state.CurrentPiece == nil {
+		return nil
+	}
+
+	if !p.planned || state.PiecesLocked != p.plannedFor || state.CurrentPiece.Position != p.expected {
+		placement, err := p.planner.Plan(state)
+		if errors.Is(err, ErrNoPlacement) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("配置計算エラー: %w", err)
+		}
+		p.moves = placement.Moves
+		p.plannedFor = state.PiecesLocked
+		p.expected = state.CurrentPiece.Position
+		p.planned = true
+	}
+
+	if len(p.moves) == 0 {
+		return nil
+	}
+
+	move := p.moves[0]
+	p.moves = p.moves[1:]
+	if err := p.controller.HandleInput(move); err != nil {
+		return err
+	}
+	if piece := p.controller.GetGameState().CurrentPiece; piece != nil {
+		p.expected = piece.Position
+	}
+	return nil
+}
+
+// Reset はリスタート後に古い手順を使わないよう計画を破棄する
+func (p *Player) Reset() {
+	p.moves = nil
+	p.planned = false
+}
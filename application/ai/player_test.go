@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"testing"
+	"tetris/application"
+	"time"
+)
+
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// recordingPlanner は計画した配置を記録する
+type recordingPlanner struct {
+	planner Planner
+	plans   []Placement
+}
+
+func (r *recordingPlanner) Plan(state application.GameState) (Placement, error) {
+	placement, err := r.planner.Plan(state)
+	if err == nil {
+		r.plans = append(r.plans, placement)
+	}
+	return placement, err
+}
+
+func TestPlayer_PlaysWithoutToppingOut(t *testing.T) {
+	const pieces = 200
+
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{Seed: 42})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	player := NewPlayer(controller, NewBot(DefaultWeights()))
+
+	for steps := 0; controller.GetGameState().PiecesLocked < pieces; steps++ {
+		if steps > pieces*40 {
+			t.Fatalf("player stalled after %d pieces", controller.GetGameState().PiecesLocked)
+		}
+		if err := player.Step(); err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+		if controller.GetGameState().GameOver {
+			t.Fatalf("game over after %d pieces", controller.GetGameState().PiecesLocked)
+		}
+	}
+
+	if lines := controller.GetGameState().Lines; lines < pieces*4/10/2 {
+		t.Errorf("cleared %d lines in %d pieces, want at least %d", lines, pieces, pieces*4/10/2)
+	}
+}
+
+func TestPlayer_ResetAfterRestart(t *testing.T) {
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	player := NewPlayer(controller, NewBot(DefaultWeights()))
+
+	if err := player.Step(); err != nil {
+		t.Fatalf("Step() error = %v", err)
+	}
+	if err := controller.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	player.Reset()
+
+	for controller.GetGameState().PiecesLocked == 0 {
+		if err := player.Step(); err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+	}
+}
+
+func TestPlayer_ReplansAfterGravity(t *testing.T) {
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{Clock: clock, Seed: 5})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	planner := &recordingPlanner{planner: NewBot(DefaultWeights())}
+	player := NewPlayer(controller, planner)
+
+	// 1手ごとにピースが1段落ちる
+	for steps := 0; controller.GetGameState().PiecesLocked == 0; steps++ {
+		if steps > 100 {
+			t.Fatal("player stalled")
+		}
+		if err := player.Step(); err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+		clock.now = clock.now.Add(time.Second)
+		if err := controller.Update(); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	if len(planner.plans) < 2 {
+		t.Fatalf("planned %d times, want a new plan after the piece fell", len(planner.plans))
+	}
+	board := controller.GetGameState().Board
+	for _, block := range planner.plans[len(planner.plans)-1].Piece.GetBlocks() {
+		if !board.Grid[block.Y][block.X] {
+			t.Errorf("block %+v of the last plan is not on the board:\n%s", block, board.String())
+		}
+	}
+}
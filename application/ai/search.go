@@ -0,0 +1,336 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"tetris/application"
+	"tetris/domain/model"
+	"time"
+)
+
+var ErrInvalidSearchConfig = errors.New("無効な探索設定です")
+
+const (
+	DefaultBeamWidth  = 8
+	DefaultBeamDepth  = 2
+	DefaultTimeBudget = 50 * time.Millisecond
+)
+
+// SearchConfig はビームサーチの幅・深さと1手あたりの持ち時間。TimeBudgetが0なら時間制限なし
+type SearchConfig struct {
+	Width      int
+	Depth      int
+	TimeBudget time.Duration
+	UseHold    bool
+}
+
+func DefaultSearchConfig() SearchConfig {
+	return SearchConfig{
+		Width:      DefaultBeamWidth,
+		Depth:      DefaultBeamDepth,
+		TimeBudget: DefaultTimeBudget,
+	}
+}
+
+// SearchInput は探索の開始局面。Queueはネクストの並びで、Holdはホールド中のピース（なければnil）
+type SearchInput struct {
+	Board   *model.Board
+	Current *model.Tetromino
+	Queue   []model.TetrominoType
+	Hold    *model.TetrominoType
+}
+
+// SearchResult は最善の1手目と探索の統計
+type SearchResult struct {
+	Placement Placement
+	UseHold   bool
+	Score     float64
+	Depth     int
+	Nodes     int
+	Elapsed   time.Duration
+}
+
+type Searcher struct {
+	weights Weights
+	config  SearchConfig
+	now     func() time.Time
+}
+
+func NewSearcher(weights Weights, config SearchConfig) (*Searcher, error) {
+	if config.Width < 1 || config.Depth < 1 || config.TimeBudget < 0 {
+		return nil, fmt.Errorf("%w: 幅=%d, 深さ=%d, 持ち時間=%v",
+			ErrInvalidSearchConfig, config.Width, config.Depth, config.TimeBudget)
+	}
+	return &Searcher{weights: weights, config: config, now: time.Now}, nil
+}
+
+func (s *Searcher) Config() SearchConfig {
+	return s.config
+}
+
+type beamNode struct {
+	board   *model.Board
+	current *model.Tetromino
+	hold    *model.TetrominoType
+	next    int
+	lines   int
+	score   float64
+	first   Placement
+	hold1st bool
+}
+
+type transposition struct {
+	board uint64
+	next  int
+	hold  model.TetrominoType
+	held  bool
+}
+
+// Search はネクストとホールドを使って数手先まで読み、最も評価の高い局面に至る1手目を返す。
+// 各深さでは評価上位Width個の局面だけを残し、同じ盤面・残りピース・ホールドの局面は置換表で1つにまとめる。
+// 持ち時間を使い切った場合は読み終えた最も深い段の結果を返す
+func (s *Searcher) Search(input SearchInput) (SearchResult, error) {
+	start := s.now()
+	var deadline time.Time
+	if s.config.TimeBudget > 0 {
+		deadline = start.Add(s.config.TimeBudget)
+	}
+
+	root := beamNode{
+		board:   input.Board,
+		current: input.Current,
+		hold:    input.Hold,
+	}
+	beam := []beamNode{root}
+	result := SearchResult{}
+	found := false
+
+	for depth := 1; depth <= s.config.Depth; depth++ {
+		// 1段目を読み終えるまでは持ち時間を過ぎても打ち切らない
+		var stop time.Time
+		if found {
+			stop = deadline
+		}
+
+		children, nodes, err := s.expandBeam(beam, input.Queue, depth == 1, stop)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		result.Nodes += nodes
+		if len(children) == 0 {
+			break
+		}
+
+		best := children[0]
+		result.Placement = best.first
+		result.UseHold = best.hold1st
+		result.Score = best.score
+		result.Depth = depth
+		found = true
+		beam = children
+	}
+
+	result.Elapsed = s.now().Sub(start)
+	if !found {
+		return result, ErrNoPlacement
+	}
+	return result, nil
+}
+
+// expandBeam は1段分の子局面を置換表でまとめ、評価の高い順にWidth個まで返す。
+// 途中で deadline を過ぎた場合は、その段を読み終えていないため子局面を返さない
+func (s *Searcher) expandBeam(
+	beam []beamNode, queue []model.TetrominoType, root bool, deadline time.Time,
+) ([]beamNode, int, error) {
+	table := make(map[transposition]int)
+	var children []beamNode
+	nodes := 0
+
+	for _, node := range beam {
+		if !deadline.IsZero() && !s.now().Before(deadline) {
+			return nil, nodes, nil
+		}
+
+		expanded, expandedNodes, err := s.expand(node, queue, root)
+		if err != nil {
+			return nil, nodes, err
+		}
+		nodes += expandedNodes
+
+		for _, child := range expanded {
+			key := child.transposition()
+			if i, exists := table[key]; exists {
+				if child.score > children[i].score {
+					children[i] = child
+				}
+				continue
+			}
+			table[key] = len(children)
+			children = append(children, child)
+		}
+	}
+
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].score > children[j].score
+	})
+	if len(children) > s.config.Width {
+		children = children[:s.config.Width]
+	}
+	return children, nodes, nil
+}
+
+func (n beamNode) transposition() transposition {
+	key := transposition{board: hashBoard(n.board), next: n.next}
+	if n.hold != nil {
+		key.hold, key.held = *n.hold, true
+	}
+	return key
+}
+
+// expand は局面から現在のピース、またはホールドしたピースを置いた子局面を列挙する
+func (s *Searcher) expand(node beamNode, queue []model.TetrominoType, root bool) ([]beamNode, int, error) {
+	if node.current == nil {
+		return nil, 0, nil
+	}
+
+	children, err := s.place(node, node.current, node.hold, node.next, root, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if s.config.UseHold {
+		held := node.current.Type
+		if node.hold != nil {
+			if *node.hold != held {
+				piece, err := spawnTetromino(node.board, *node.hold)
+				if err != nil {
+					return nil, 0, err
+				}
+				swapped, err := s.place(node, piece, &held, node.next, root, true)
+				if err != nil {
+					return nil, 0, err
+				}
+				children = append(children, swapped...)
+			}
+		} else if node.next < len(queue) {
+			piece, err := spawnTetromino(node.board, queue[node.next])
+			if err != nil {
+				return nil, 0, err
+			}
+			swapped, err := s.place(node, piece, &held, node.next+1, root, true)
+			if err != nil {
+				return nil, 0, err
+			}
+			children = append(children, swapped...)
+		}
+	}
+
+	nextPieces := make(map[int]*model.Tetromino)
+	for i := range children {
+		index := children[i].next
+		piece, exists := nextPieces[index]
+		if !exists && index < len(queue) {
+			piece, err = spawnTetromino(children[i].board, queue[index])
+			if err != nil {
+				return nil, 0, err
+			}
+			nextPieces[index] = piece
+		}
+		if piece != nil {
+			children[i].current = piece
+			children[i].next = index + 1
+		}
+	}
+
+	return children, len(children), nil
+}
+
+func (s *Searcher) place(
+	node beamNode, piece *model.Tetromino, hold *model.TetrominoType, next int, root, usedHold bool,
+) ([]beamNode, error) {
+	if !node.board.CanPlaceTetromino(piece) {
+		return nil, nil
+	}
+
+	placements, err := ReachablePlacements(node.board, piece)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([]beamNode, 0, len(placements))
+	for _, placement := range placements {
+		if placement.Board.IsGameOver() {
+			continue
+		}
+
+		lines := node.lines + placement.CompletedLines
+		child := beamNode{
+			board:   placement.Board,
+			hold:    hold,
+			next:    next,
+			lines:   lines,
+			score:   s.weights.Score(ComputeFeatures(placement.Board, lines)),
+			first:   node.first,
+			hold1st: node.hold1st,
+		}
+		if root {
+			child.first = placement
+			child.hold1st = usedHold
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+func spawnTetromino(board *model.Board, tetrominoType model.TetrominoType) (*model.Tetromino, error) {
+	piece, err := model.SpawnTetromino(tetrominoType, board.Width)
+	if err != nil {
+		return nil, fmt.Errorf("探索用テトロミノ生成エラー: %w", err)
+	}
+	return piece, nil
+}
+
+// hashBoard は置換表のキーに使う盤面のFNV-1aハッシュ
+func hashBoard(board *model.Board) uint64 {
+	hash := fnv.New64a()
+	row := make([]byte, board.Width)
+	for _, cells := range board.Grid {
+		for x, filled := range cells {
+			row[x] = 0
+			if filled {
+				row[x] = 1
+			}
+		}
+		_, _ = hash.Write(row)
+	}
+	return hash.Sum64()
+}
+
+// Plan はゲームの現在のピース、見えているネクストとホールドから探索する。
+// ゲームでホールドを使える場合はホールドする手も読み、選んだ手がホールドを使うなら入力の先頭に"hold"を加える
+func (s *Searcher) Plan(state application.GameState) (Placement, error) {
+	input := SearchInput{
+		Board:   state.Board.Clone(),
+		Current: state.CurrentPiece.Clone(),
+		Queue:   state.Queue,
+	}
+	if state.HoldPiece != nil {
+		held := state.HoldPiece.Type
+		input.Hold = &held
+	}
+
+	searcher := *s
+	searcher.config.UseHold = state.HoldAvailable
+	result, err := searcher.Search(input)
+	if err != nil {
+		return Placement{}, err
+	}
+
+	placement := result.Placement
+	if result.UseHold {
+		placement.Moves = append([]string{"hold"}, placement.Moves...)
+	}
+	return placement, nil
+}
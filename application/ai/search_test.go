@@ -0,0 +1,352 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+	"tetris/application"
+	"tetris/domain/model"
+	"time"
+)
+
+func newTestSearcher(t testing.TB, config SearchConfig) *Searcher {
+	t.Helper()
+
+	searcher, err := NewSearcher(DefaultWeights(), config)
+	if err != nil {
+		t.Fatalf("NewSearcher() error = %v", err)
+	}
+	return searcher
+}
+
+func tetrominoType(tetrominoType model.TetrominoType) *model.TetrominoType {
+	return &tetrominoType
+}
+
+func TestNewSearcher_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config SearchConfig
+	}{
+		{name: "幅0", config: SearchConfig{Width: 0, Depth: 1}},
+		{name: "深さ0", config: SearchConfig{Width: 1, Depth: 0}},
+		{name: "負の持ち時間", config: SearchConfig{Width: 1, Depth: 1, TimeBudget: -time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSearcher(DefaultWeights(), tt.config); !errors.Is(err, ErrInvalidSearchConfig) {
+				t.Errorf("NewSearcher() error = %v, want %v", err, ErrInvalidSearchConfig)
+			}
+		})
+	}
+}
+
+func TestSearcher_DepthOneMatchesGreedyBot(t *testing.T) {
+	board := boardFromRows(t,
+		"XX....X...",
+		"XXX..XXX.X",
+		"XXXX.XXXXX")
+
+	for tetrominoType := model.I; tetrominoType <= model.L; tetrominoType++ {
+		piece := spawnPiece(t, tetrominoType, board)
+
+		expected, err := NewBot(DefaultWeights()).BestPlacement(board, piece)
+		if err != nil {
+			t.Fatalf("BestPlacement() error = %v", err)
+		}
+		result, err := newTestSearcher(t, SearchConfig{Width: 4, Depth: 1}).Search(SearchInput{Board: board, Current: piece})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+
+		if result.Placement.Key() != expected.Key() {
+			t.Errorf("type %d: Search() = %s, want %s", tetrominoType, result.Placement.Key(), expected.Key())
+		}
+		if result.Depth != 1 || result.Nodes == 0 {
+			t.Errorf("type %d: Depth = %d, Nodes = %d", tetrominoType, result.Depth, result.Nodes)
+		}
+	}
+}
+
+func TestSearcher_Hold(t *testing.T) {
+	wellRows := []string{
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+	}
+
+	tests := []struct {
+		name     string
+		current  model.TetrominoType
+		queue    []model.TetrominoType
+		hold     *model.TetrominoType
+		useHold  bool
+		wantHold bool
+		wantType model.TetrominoType
+	}{
+		{
+			name:     "ホールド中のIと交換してテトリス",
+			current:  model.O,
+			queue:    []model.TetrominoType{model.O},
+			hold:     tetrominoType(model.I),
+			useHold:  true,
+			wantHold: true,
+			wantType: model.I,
+		},
+		{
+			name:     "ホールドが空ならネクストのIを使う",
+			current:  model.O,
+			queue:    []model.TetrominoType{model.I},
+			useHold:  true,
+			wantHold: true,
+			wantType: model.I,
+		},
+		{
+			name:     "ホールド無効",
+			current:  model.O,
+			queue:    []model.TetrominoType{model.O},
+			hold:     tetrominoType(model.I),
+			useHold:  false,
+			wantHold: false,
+			wantType: model.O,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := boardFromRows(t, wellRows...)
+			searcher := newTestSearcher(t, SearchConfig{Width: 4, Depth: 1, UseHold: tt.useHold})
+
+			result, err := searcher.Search(SearchInput{
+				Board:   board,
+				Current: spawnPiece(t, tt.current, board),
+				Queue:   tt.queue,
+				Hold:    tt.hold,
+			})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			if result.UseHold != tt.wantHold {
+				t.Errorf("UseHold = %v, want %v", result.UseHold, tt.wantHold)
+			}
+			if result.Placement.Piece.Type != tt.wantType {
+				t.Errorf("placed type = %d, want %d", result.Placement.Piece.Type, tt.wantType)
+			}
+			if tt.wantType == model.I && result.Placement.CompletedLines != 4 {
+				t.Errorf("CompletedLines = %d, want 4", result.Placement.CompletedLines)
+			}
+		})
+	}
+}
+
+func TestSearcher_PlanPassesHoldAndQueue(t *testing.T) {
+	board := boardFromRows(t,
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.",
+		"XXXXXXXXX.")
+
+	tests := []struct {
+		name      string
+		queue     []model.TetrominoType
+		hold      model.TetrominoType
+		available bool
+		wantHold  bool
+		wantType  model.TetrominoType
+	}{
+		{name: "ホールド中のIと交換", queue: []model.TetrominoType{model.O, model.O}, hold: model.I, available: true,
+			wantHold: true, wantType: model.I},
+		{name: "ネクストのIをホールドで先に使う", queue: []model.TetrominoType{model.I, model.O}, hold: -1, available: true,
+			wantHold: true, wantType: model.I},
+		{name: "ホールドを使えない", queue: []model.TetrominoType{model.O, model.O}, hold: model.I, available: false,
+			wantHold: false, wantType: model.O},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := application.GameState{
+				Board:         board,
+				CurrentPiece:  spawnPiece(t, model.O, board),
+				Queue:         tt.queue,
+				HoldEnabled:   true,
+				HoldAvailable: tt.available,
+			}
+			if tt.hold >= 0 {
+				state.HoldPiece = spawnPiece(t, tt.hold, board)
+			}
+
+			placement, err := newTestSearcher(t, SearchConfig{Width: 4, Depth: 2}).Plan(state)
+			if err != nil {
+				t.Fatalf("Plan() error = %v", err)
+			}
+			if got := len(placement.Moves) > 0 && placement.Moves[0] == "hold"; got != tt.wantHold {
+				t.Errorf("Moves = %v, want hold first = %v", placement.Moves, tt.wantHold)
+			}
+			if placement.Piece.Type != tt.wantType {
+				t.Errorf("placed type = %d, want %d", placement.Piece.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestSearcher_DepthLimitedByQueue(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	searcher := newTestSearcher(t, SearchConfig{Width: 2, Depth: 5})
+
+	result, err := searcher.Search(SearchInput{
+		Board:   board,
+		Current: spawnPiece(t, model.T, board),
+		Queue:   []model.TetrominoType{model.I},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if result.Depth != 2 {
+		t.Errorf("Depth = %d, want 2", result.Depth)
+	}
+}
+
+func TestSearcher_TimeBudget(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	searcher := newTestSearcher(t, SearchConfig{Width: 4, Depth: 3, TimeBudget: time.Millisecond})
+
+	now := time.Unix(0, 0)
+	searcher.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	result, err := searcher.Search(SearchInput{
+		Board:   board,
+		Current: spawnPiece(t, model.T, board),
+		Queue:   []model.TetrominoType{model.I, model.O},
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if result.Depth != 1 {
+		t.Errorf("Depth = %d, want 1 when the budget is exhausted", result.Depth)
+	}
+}
+
+func TestHashBoard(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	clone := board.Clone()
+	if hashBoard(board) != hashBoard(clone) {
+		t.Error("hashBoard() differs for equal boards")
+	}
+
+	_ = clone.SetBlock(model.Point{X: 0, Y: 19}, true)
+	if hashBoard(board) == hashBoard(clone) {
+		t.Error("hashBoard() equal for different boards")
+	}
+}
+
+func TestSearcher_NoPlacement(t *testing.T) {
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	piece := spawnPiece(t, model.T, board)
+	_ = board.PlaceTetromino(piece)
+
+	_, err := newTestSearcher(t, DefaultSearchConfig()).Search(SearchInput{Board: board, Current: piece})
+	if !errors.Is(err, ErrNoPlacement) {
+		t.Errorf("Search() error = %v, want %v", err, ErrNoPlacement)
+	}
+}
+
+func TestPlayer_WithSearcher(t *testing.T) {
+	const pieces = 50
+
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{Seed: 7})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	player := NewPlayer(controller, newTestSearcher(t, SearchConfig{Width: 4, Depth: 2}))
+
+	for steps := 0; controller.GetGameState().PiecesLocked < pieces; steps++ {
+		if steps > pieces*40 {
+			t.Fatalf("player stalled after %d pieces", controller.GetGameState().PiecesLocked)
+		}
+		if err := player.Step(); err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+		if controller.GetGameState().GameOver {
+			t.Fatalf("game over after %d pieces", controller.GetGameState().PiecesLocked)
+		}
+	}
+}
+
+func TestPlayer_BoardSizes(t *testing.T) {
+	const pieces = 40
+
+	tests := []struct {
+		name  string
+		width int
+	}{
+		{name: "4列", width: 4},
+		{name: "20列", width: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, err := application.NewGameControllerWithConfig(application.GameConfig{Seed: 7, Width: tt.width})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+			player := NewPlayer(controller, newTestSearcher(t, SearchConfig{Width: 4, Depth: 2}))
+
+			for steps := 0; controller.GetGameState().PiecesLocked < pieces; steps++ {
+				if steps > pieces*40 {
+					t.Fatalf("player stalled after %d pieces", controller.GetGameState().PiecesLocked)
+				}
+				if err := player.Step(); err != nil {
+					t.Fatalf("Step() error = %v", err)
+				}
+				if controller.GetGameState().GameOver {
+					t.Fatalf("game over after %d pieces", controller.GetGameState().PiecesLocked)
+				}
+			}
+			if controller.GetGameState().Lines == 0 {
+				t.Error("Lines = 0, want the player to clear lines")
+			}
+		})
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	board := boardFromRows(b,
+		"X.........",
+		"XX..X...XX",
+		"XXX.XX.XXX",
+		"XXXX.XXXXX")
+	queue := []model.TetrominoType{model.I, model.S, model.L, model.O, model.Z}
+
+	benchmarks := []struct {
+		name   string
+		config SearchConfig
+	}{
+		{name: "depth1", config: SearchConfig{Width: 8, Depth: 1}},
+		{name: "depth2", config: SearchConfig{Width: 8, Depth: 2}},
+		{name: "depth3", config: SearchConfig{Width: 8, Depth: 3}},
+		{name: "depth3-hold", config: SearchConfig{Width: 8, Depth: 3, UseHold: true}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			searcher := newTestSearcher(b, bm.config)
+			piece, _ := model.NewTetromino(model.T, model.Point{X: board.Width/2 - 2, Y: 0})
+			nodes := 0
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				result, err := searcher.Search(SearchInput{Board: board, Current: piece, Queue: queue})
+				if err != nil {
+					b.Fatalf("Search() error = %v", err)
+				}
+				nodes += result.Nodes
+			}
+			b.ReportMetric(float64(nodes)/b.Elapsed().Seconds(), "nodes/s")
+		})
+	}
+}
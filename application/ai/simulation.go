@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"tetris/application"
+	"time"
+)
+
+// SimulationResult は画面を使わない1ゲーム分の自動プレイの結果
+type SimulationResult struct {
+	Seed      uint64
+	Pieces    int
+	Lines     int
+	Score     int
+	Level     int
+	ToppedOut bool
+}
+
+// Simulate は指定したシードのゲームをプランナーに最大maxPieces個までプレイさせる。maxPiecesが0なら終了するまで続ける
+func Simulate(planner Planner, seed uint64, maxPieces int) (SimulationResult, error) {
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{
+		Clock: application.FixedClock{Time: time.Unix(0, 0)},
+		Seed:  seed,
+	})
+	if err != nil {
+		return SimulationResult{}, fmt.Errorf("シミュレーション初期化エラー: %w", err)
+	}
+
+	result := SimulationResult{Seed: seed}
+	for {
+		state := controller.GetGameState()
+		result.Pieces = state.PiecesLocked
+		result.Lines = state.Lines
+		result.Score = state.Score
+		result.Level = state.Level
+
+		if state.GameOver {
+			result.ToppedOut = true
+			return result, nil
+		}
+		if maxPieces > 0 && state.PiecesLocked >= maxPieces {
+			return result, nil
+		}
+
+		placement, err := planner.Plan(state)
+		if errors.Is(err, ErrNoPlacement) {
+			result.ToppedOut = true
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("配置計算エラー: %w", err)
+		}
+
+		for _, move := range placement.Moves {
+			if err := controller.HandleInput(move); err != nil {
+				return result, fmt.Errorf("シミュレーション入力エラー: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package ai
+
+import "testing"
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name      string
+		weights   Weights
+		maxPieces int
+		toppedOut bool
+	}{
+		{name: "最大ピース数で打ち切る", weights: DefaultWeights(), maxPieces: 30, toppedOut: false},
+		{name: "評価なしのボットは積み上がって終了する", weights: Weights{}, maxPieces: 0, toppedOut: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Simulate(NewBot(tt.weights), 3, tt.maxPieces)
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+			if result.ToppedOut != tt.toppedOut {
+				t.Errorf("ToppedOut = %v, want %v (pieces=%d)", result.ToppedOut, tt.toppedOut, result.Pieces)
+			}
+			if tt.maxPieces > 0 && result.Pieces != tt.maxPieces {
+				t.Errorf("Pieces = %d, want %d", result.Pieces, tt.maxPieces)
+			}
+
+			again, err := Simulate(NewBot(tt.weights), 3, tt.maxPieces)
+			if err != nil {
+				t.Fatalf("Simulate() error = %v", err)
+			}
+			if again != result {
+				t.Errorf("Simulate() is not deterministic: %+v != %+v", again, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,393 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrInvalidTrainerConfig = errors.New("無効な学習設定です")
+
+const (
+	FitnessLines = "lines"
+	FitnessScore = "score"
+)
+
+type TrainerConfig struct {
+	Population     int
+	Generations    int
+	Games          int
+	MaxPieces      int
+	Workers        int
+	Elite          int
+	TournamentSize int
+	MutationRate   float64
+	MutationScale  float64
+	Fitness        string
+	Seed           uint64
+}
+
+func DefaultTrainerConfig() TrainerConfig {
+	return TrainerConfig{
+		Population:     24,
+		Generations:    20,
+		Games:          3,
+		MaxPieces:      500,
+		Workers:        1,
+		Elite:          2,
+		TournamentSize: 3,
+		MutationRate:   0.3,
+		MutationScale:  0.2,
+		Fitness:        FitnessLines,
+		Seed:           1,
+	}
+}
+
+type Individual struct {
+	Weights Weights `json:"weights"`
+	Fitness float64 `json:"fitness"`
+}
+
+// Checkpoint は完了した世代数と次に評価する集団、これまでの最良個体を保存する
+type Checkpoint struct {
+	Generation int          `json:"generation"`
+	Population []Individual `json:"population"`
+	Best       *Individual  `json:"best,omitempty"`
+	RNG        []byte       `json:"rng,omitempty"`
+}
+
+type CheckpointStore interface {
+	Load() (Checkpoint, error)
+	Save(checkpoint Checkpoint) error
+}
+
+// GenerationReport は1世代の評価が終わるたびに通知される進捗
+type GenerationReport struct {
+	Generation  int
+	Best        Individual
+	MeanFitness float64
+	BestEver    Individual
+	Elapsed     time.Duration
+}
+
+// Trainer は評価関数の重みを遺伝的アルゴリズムで進化させる。
+// 各個体は世代ごとに共通のシードで複数ゲームを画面なしでプレイし、消去ライン数またはスコアの平均で評価される
+type Trainer struct {
+	config TrainerConfig
+	store  CheckpointStore
+	pcg    *rand.PCG
+	rng    *rand.Rand
+}
+
+func NewTrainer(config TrainerConfig, store CheckpointStore) (*Trainer, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	pcg := rand.NewPCG(config.Seed, config.Seed^0x9e3779b97f4a7c15)
+	return &Trainer{
+		config: config,
+		store:  store,
+		pcg:    pcg,
+		rng:    rand.New(pcg),
+	}, nil
+}
+
+func (c TrainerConfig) validate() error {
+	switch {
+	case c.Population < 2, c.Generations < 1, c.Games < 1, c.MaxPieces < 1, c.Workers < 1:
+		return fmt.Errorf("%w: 個体数=%d, 世代数=%d, ゲーム数=%d, ピース数=%d, ワーカー数=%d",
+			ErrInvalidTrainerConfig, c.Population, c.Generations, c.Games, c.MaxPieces, c.Workers)
+	case c.Elite < 0, c.Elite >= c.Population, c.TournamentSize < 1:
+		return fmt.Errorf("%w: エリート数=%d, トーナメントサイズ=%d", ErrInvalidTrainerConfig, c.Elite, c.TournamentSize)
+	case c.MutationRate < 0, c.MutationRate > 1, c.MutationScale < 0:
+		return fmt.Errorf("%w: 突然変異率=%v, 突然変異幅=%v", ErrInvalidTrainerConfig, c.MutationRate, c.MutationScale)
+	case c.Fitness != FitnessLines && c.Fitness != FitnessScore:
+		return fmt.Errorf("%w: 評価基準=%q", ErrInvalidTrainerConfig, c.Fitness)
+	}
+	return nil
+}
+
+// Run はチェックポイントがあればその世代から再開し、世代ごとにチェックポイントを保存する。
+// ctxがキャンセルされた場合は評価中の世代を破棄し、最後に保存した状態のまま終了する
+func (t *Trainer) Run(ctx context.Context, report func(GenerationReport)) (Individual, error) {
+	checkpoint, err := t.resume()
+	if err != nil {
+		return Individual{}, err
+	}
+
+	population := t.resize(checkpoint.Population)
+	best := checkpoint.Best
+
+	for generation := checkpoint.Generation + 1; generation <= t.config.Generations; generation++ {
+		started := time.Now()
+		if err := t.evaluate(ctx, population, generation); err != nil {
+			if best != nil {
+				return *best, err
+			}
+			return Individual{}, err
+		}
+
+		sort.SliceStable(population, func(i, j int) bool {
+			return population[i].Fitness > population[j].Fitness
+		})
+		champion := population[0]
+		if best == nil || champion.Fitness > best.Fitness {
+			best = &champion
+		}
+		mean := meanFitness(population)
+
+		population = t.breed(population)
+		if err := t.save(generation, population, best); err != nil {
+			return *best, err
+		}
+
+		if report != nil {
+			report(GenerationReport{
+				Generation:  generation,
+				Best:        champion,
+				MeanFitness: mean,
+				BestEver:    *best,
+				Elapsed:     time.Since(started),
+			})
+		}
+	}
+
+	if best == nil {
+		return Individual{Weights: population[0].Weights}, nil
+	}
+	return *best, nil
+}
+
+// resume はチェックポイントを読み込み、保存された乱数の状態を復元する
+func (t *Trainer) resume() (Checkpoint, error) {
+	checkpoint, err := t.store.Load()
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("チェックポイント読み込みエラー: %w", err)
+	}
+	if len(checkpoint.RNG) > 0 {
+		if err := t.pcg.UnmarshalBinary(checkpoint.RNG); err != nil {
+			return Checkpoint{}, fmt.Errorf("チェックポイントの乱数状態が不正です: %w", err)
+		}
+	}
+	return checkpoint, nil
+}
+
+// save は完了した世代と次に評価する集団を、乱数の状態とともに保存する
+func (t *Trainer) save(generation int, population []Individual, best *Individual) error {
+	state, err := t.pcg.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("乱数状態の保存エラー: %w", err)
+	}
+	if err := t.store.Save(Checkpoint{
+		Generation: generation,
+		Population: population,
+		Best:       best,
+		RNG:        state,
+	}); err != nil {
+		return fmt.Errorf("チェックポイント保存エラー: %w", err)
+	}
+	return nil
+}
+
+func meanFitness(population []Individual) float64 {
+	mean := 0.0
+	for _, individual := range population {
+		mean += individual.Fitness
+	}
+	return mean / float64(len(population))
+}
+
+// resize は保存された集団を設定した個体数に合わせる。新しく学習する場合は既定の重みと、その周辺のランダムな個体から始める
+func (t *Trainer) resize(population []Individual) []Individual {
+	if len(population) == 0 {
+		population = []Individual{{Weights: DefaultWeights()}}
+	}
+	if len(population) > t.config.Population {
+		return population[:t.config.Population]
+	}
+
+	for len(population) < t.config.Population {
+		population = append(population, Individual{Weights: t.mutate(DefaultWeights(), 1, 0.5)})
+	}
+	return population
+}
+
+type simulationJob struct {
+	individual int
+	seed       uint64
+}
+
+type simulationOutcome struct {
+	individual int
+	fitness    float64
+	err        error
+}
+
+// evaluate は全個体×全ゲームのシミュレーションをワーカープールで並列に実行する
+func (t *Trainer) evaluate(ctx context.Context, population []Individual, generation int) error {
+	jobs := make(chan simulationJob)
+	outcomes := t.startWorkers(population, jobs)
+	go t.enqueue(ctx, jobs, len(population), generation)
+
+	totals, completed, err := collectOutcomes(outcomes, len(population))
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if completed != len(population)*t.config.Games {
+		return fmt.Errorf("シミュレーションが完了していません: %d/%d", completed, len(population)*t.config.Games)
+	}
+
+	for i := range population {
+		population[i].Fitness = totals[i] / float64(t.config.Games)
+	}
+	return nil
+}
+
+// startWorkers はジョブを処理するワーカーを起動する。結果のチャネルは全ワーカーが終わると閉じる
+func (t *Trainer) startWorkers(population []Individual, jobs <-chan simulationJob) <-chan simulationOutcome {
+	outcomes := make(chan simulationOutcome)
+
+	var wg sync.WaitGroup
+	for w := 0; w < t.config.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				fitness, err := t.play(population[job.individual].Weights, job.seed)
+				outcomes <- simulationOutcome{individual: job.individual, fitness: fitness, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+	return outcomes
+}
+
+// enqueue は全個体×全ゲームのジョブを送る。ctxがキャンセルされたら残りのジョブは送らない
+func (t *Trainer) enqueue(ctx context.Context, jobs chan<- simulationJob, individuals, generation int) {
+	defer close(jobs)
+	for i := 0; i < individuals; i++ {
+		for game := 0; game < t.config.Games; game++ {
+			select {
+			case jobs <- simulationJob{individual: i, seed: t.gameSeed(generation, game)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// collectOutcomes は個体ごとの適応度の合計と完了したシミュレーション数を集める。
+// ワーカーを止めないよう全ての結果を受け取ってから、最初のエラーを返す
+func collectOutcomes(outcomes <-chan simulationOutcome, individuals int) ([]float64, int, error) {
+	totals := make([]float64, individuals)
+	completed := 0
+	var firstErr error
+	for outcome := range outcomes {
+		if outcome.err != nil && firstErr == nil {
+			firstErr = outcome.err
+		}
+		totals[outcome.individual] += outcome.fitness
+		completed++
+	}
+	return totals, completed, firstErr
+}
+
+func (t *Trainer) play(weights Weights, seed uint64) (float64, error) {
+	result, err := Simulate(NewBot(weights), seed, t.config.MaxPieces)
+	if err != nil {
+		return 0, err
+	}
+	if t.config.Fitness == FitnessScore {
+		return float64(result.Score), nil
+	}
+	return float64(result.Lines), nil
+}
+
+// gameSeed は同じ世代の全個体が同じ並びのピースで評価されるよう、世代とゲーム番号からシードを決める
+func (t *Trainer) gameSeed(generation, game int) uint64 {
+	return t.config.Seed*1_000_003 + uint64(generation)*uint64(t.config.Games) + uint64(game) + 1
+}
+
+// breed は評価済みで降順に並んだ集団から、エリートをそのまま残し、残りをトーナメント選択・一様交叉・突然変異で作る
+func (t *Trainer) breed(ranked []Individual) []Individual {
+	next := make([]Individual, 0, len(ranked))
+	for i := 0; i < t.config.Elite; i++ {
+		next = append(next, Individual{Weights: ranked[i].Weights})
+	}
+
+	for len(next) < len(ranked) {
+		mother := t.tournament(ranked)
+		father := t.tournament(ranked)
+		child := t.crossover(mother.Weights, father.Weights)
+		next = append(next, Individual{Weights: t.mutate(child, t.config.MutationRate, t.config.MutationScale)})
+	}
+	return next
+}
+
+func (t *Trainer) tournament(ranked []Individual) Individual {
+	best := t.rng.IntN(len(ranked))
+	for i := 1; i < t.config.TournamentSize; i++ {
+		// 降順に並んでいるため添字が小さいほど適応度が高い
+		if candidate := t.rng.IntN(len(ranked)); candidate < best {
+			best = candidate
+		}
+	}
+	return ranked[best]
+}
+
+func (t *Trainer) crossover(mother, father Weights) Weights {
+	genes := mother.genes()
+	fatherGenes := father.genes()
+	for i := range genes {
+		if t.rng.IntN(2) == 1 {
+			genes[i] = fatherGenes[i]
+		}
+	}
+	return weightsFromGenes(genes)
+}
+
+func (t *Trainer) mutate(weights Weights, rate, scale float64) Weights {
+	genes := weights.genes()
+	for i := range genes {
+		if t.rng.Float64() < rate {
+			genes[i] += t.rng.NormFloat64() * scale
+		}
+	}
+	return weightsFromGenes(genes)
+}
+
+func (w Weights) genes() []float64 {
+	return []float64{
+		w.AggregateHeight,
+		w.Holes,
+		w.Bumpiness,
+		w.CompletedLines,
+		w.Wells,
+		w.RowTransitions,
+		w.ColumnTransitions,
+	}
+}
+
+func weightsFromGenes(genes []float64) Weights {
+	return Weights{
+		AggregateHeight:   genes[0],
+		Holes:             genes[1],
+		Bumpiness:         genes[2],
+		CompletedLines:    genes[3],
+		Wells:             genes[4],
+		RowTransitions:    genes[5],
+		ColumnTransitions: genes[6],
+	}
+}
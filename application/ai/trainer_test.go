@@ -0,0 +1,130 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type memoryCheckpointStore struct {
+	checkpoint Checkpoint
+	saves      int
+}
+
+func (s *memoryCheckpointStore) Load() (Checkpoint, error) {
+	return s.checkpoint, nil
+}
+
+func (s *memoryCheckpointStore) Save(checkpoint Checkpoint) error {
+	s.checkpoint = checkpoint
+	s.saves++
+	return nil
+}
+
+func smallTrainerConfig(generations int) TrainerConfig {
+	config := DefaultTrainerConfig()
+	config.Population = 4
+	config.Generations = generations
+	config.Games = 2
+	config.MaxPieces = 15
+	config.Workers = 3
+	config.Elite = 1
+	return config
+}
+
+func TestNewTrainer_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*TrainerConfig)
+	}{
+		{name: "個体数不足", modify: func(c *TrainerConfig) { c.Population = 1 }},
+		{name: "ワーカーなし", modify: func(c *TrainerConfig) { c.Workers = 0 }},
+		{name: "エリートが個体数以上", modify: func(c *TrainerConfig) { c.Elite = c.Population }},
+		{name: "突然変異率が1超", modify: func(c *TrainerConfig) { c.MutationRate = 1.5 }},
+		{name: "不明な評価基準", modify: func(c *TrainerConfig) { c.Fitness = "time" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultTrainerConfig()
+			tt.modify(&config)
+			if _, err := NewTrainer(config, &memoryCheckpointStore{}); !errors.Is(err, ErrInvalidTrainerConfig) {
+				t.Errorf("NewTrainer() error = %v, want %v", err, ErrInvalidTrainerConfig)
+			}
+		})
+	}
+}
+
+func TestTrainer_RunSavesCheckpoints(t *testing.T) {
+	store := &memoryCheckpointStore{}
+	trainer, err := NewTrainer(smallTrainerConfig(2), store)
+	if err != nil {
+		t.Fatalf("NewTrainer() error = %v", err)
+	}
+
+	var reports []GenerationReport
+	best, err := trainer.Run(context.Background(), func(report GenerationReport) {
+		reports = append(reports, report)
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(reports) != 2 || reports[0].Generation != 1 || reports[1].Generation != 2 {
+		t.Fatalf("reports = %+v, want generations 1 and 2", reports)
+	}
+	if store.saves != 2 || store.checkpoint.Generation != 2 {
+		t.Errorf("saves = %d, generation = %d, want 2 and 2", store.saves, store.checkpoint.Generation)
+	}
+	if len(store.checkpoint.Population) != 4 || store.checkpoint.Best == nil {
+		t.Fatalf("checkpoint = %+v", store.checkpoint)
+	}
+	if *store.checkpoint.Best != best || best.Fitness < reports[0].Best.Fitness {
+		t.Errorf("best = %+v, checkpoint best = %+v", best, *store.checkpoint.Best)
+	}
+}
+
+func TestTrainer_ResumeMatchesUninterruptedRun(t *testing.T) {
+	uninterrupted := &memoryCheckpointStore{}
+	trainer, _ := NewTrainer(smallTrainerConfig(2), uninterrupted)
+	if _, err := trainer.Run(context.Background(), nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	resumed := &memoryCheckpointStore{}
+	first, _ := NewTrainer(smallTrainerConfig(1), resumed)
+	if _, err := first.Run(context.Background(), nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var generations []int
+	second, _ := NewTrainer(smallTrainerConfig(2), resumed)
+	if _, err := second.Run(context.Background(), func(report GenerationReport) {
+		generations = append(generations, report.Generation)
+	}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(generations, []int{2}) {
+		t.Errorf("resumed generations = %v, want [2]", generations)
+	}
+	if !reflect.DeepEqual(resumed.checkpoint, uninterrupted.checkpoint) {
+		t.Errorf("resumed checkpoint = %+v, want %+v", resumed.checkpoint, uninterrupted.checkpoint)
+	}
+}
+
+func TestTrainer_Cancel(t *testing.T) {
+	store := &memoryCheckpointStore{}
+	trainer, _ := NewTrainer(smallTrainerConfig(3), store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := trainer.Run(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if store.saves != 0 {
+		t.Errorf("saves = %d, want 0 after cancellation", store.saves)
+	}
+}
@@ -0,0 +1,22 @@
+package application
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock は常に同じ時刻を返す。自然落下させず入力だけでゲームを進める、画面なしの実行で使う
+type FixedClock struct {
+	Time time.Time
+}
+
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
@@ -0,0 +1,125 @@
+package application
+
+import "sync"
+
+const feedBufferSize = 16
+
+type FeedUpdate struct {
+	Snapshot *GameSnapshot  `json:"snapshot,omitempty"`
+	Delta    *SnapshotDelta `json:"delta,omitempty"`
+}
+
+type feedSubscriber struct {
+	updates       chan FeedUpdate
+	needsSnapshot bool
+}
+
+type StateFeed struct {
+	mu          sync.Mutex
+	current     GameSnapshot
+	hasState    bool
+	subscribers map[*feedSubscriber]struct{}
+	closed      bool
+}
+
+func NewStateFeed() *StateFeed {
+	return &StateFeed{
+		subscribers: make(map[*feedSubscriber]struct{}),
+	}
+}
+
+func (f *StateFeed) Publish(state GameState) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return
+	}
+
+	snapshot := NewGameSnapshot(state)
+	if f.hasState && snapshot.Equal(f.current) {
+		return
+	}
+
+	previous := f.current
+	f.current = snapshot
+	f.hasState = true
+
+	delta := DiffSnapshots(previous, snapshot)
+	for subscriber := range f.subscribers {
+		f.deliver(subscriber, &delta)
+	}
+}
+
+func (f *StateFeed) Subscribe() (<-chan FeedUpdate, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscriber := &feedSubscriber{
+		updates:       make(chan FeedUpdate, feedBufferSize),
+		needsSnapshot: true,
+	}
+
+	if f.closed {
+		close(subscriber.updates)
+		return subscriber.updates, func() {}
+	}
+
+	f.subscribers[subscriber] = struct{}{}
+	if f.hasState {
+		f.deliver(subscriber, nil)
+	}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			if _, exists := f.subscribers[subscriber]; exists {
+				delete(f.subscribers, subscriber)
+				close(subscriber.updates)
+			}
+		})
+	}
+
+	return subscriber.updates, unsubscribe
+}
+
+func (f *StateFeed) SubscriberCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.subscribers)
+}
+
+func (f *StateFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return
+	}
+	f.closed = true
+
+	for subscriber := range f.subscribers {
+		close(subscriber.updates)
+		delete(f.subscribers, subscriber)
+	}
+}
+
+// 購読者の受信が追いつかない場合は差分を捨て、次回に全体スナップショットを送る
+func (f *StateFeed) deliver(subscriber *feedSubscriber, delta *SnapshotDelta) {
+	update := FeedUpdate{Delta: delta}
+	if subscriber.needsSnapshot || delta == nil {
+		snapshot := f.current
+		update = FeedUpdate{Snapshot: &snapshot}
+	}
+
+	select {
+	case subscriber.updates <- update:
+		subscriber.needsSnapshot = false
+	default:
+		subscriber.needsSnapshot = true
+	}
+}
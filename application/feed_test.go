@@ -0,0 +1,101 @@
+package application
+
+import "testing"
+
+func receiveFeedUpdate(t *testing.T, updates <-chan FeedUpdate) FeedUpdate {
+	t.Helper()
+
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("feed channel closed unexpectedly")
+		}
+		return update
+	default:
+		t.Fatal("no feed update available")
+	}
+	return FeedUpdate{}
+}
+
+func TestStateFeed_SnapshotThenDeltas(t *testing.T) {
+	controller, err := NewGameControllerWithConfig(GameConfig{Clock: newFakeClock(), Seed: 4})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	feed := NewStateFeed()
+	feed.Publish(controller.GetGameState())
+
+	updates, unsubscribe := feed.Subscribe()
+	defer unsubscribe()
+
+	first := receiveFeedUpdate(t, updates)
+	if first.Snapshot == nil {
+		t.Fatal("first update should be a full snapshot")
+	}
+
+	feed.Publish(controller.GetGameState())
+	select {
+	case update := <-updates:
+		t.Errorf("unchanged state should not be published, got %+v", update)
+	default:
+	}
+
+	if err := controller.HandleInput("drop"); err != nil {
+		t.Fatalf("HandleInput() error = %v", err)
+	}
+	feed.Publish(controller.GetGameState())
+
+	second := receiveFeedUpdate(t, updates)
+	if second.Delta == nil {
+		t.Fatal("second update should be a delta")
+	}
+
+	restored, err := first.Snapshot.Apply(*second.Delta)
+	if err != nil {
+		t.Fatalf("GameSnapshot.Apply() error = %v", err)
+	}
+	if !restored.Equal(NewGameSnapshot(controller.GetGameState())) {
+		t.Error("snapshot plus delta should equal the current state")
+	}
+}
+
+func TestStateFeed_SlowSubscriberResyncs(t *testing.T) {
+	feed := NewStateFeed()
+	updates, unsubscribe := feed.Subscribe()
+	defer unsubscribe()
+
+	for score := 1; score <= feedBufferSize+5; score++ {
+		feed.Publish(GameState{Score: score})
+	}
+
+	for i := 0; i < feedBufferSize; i++ {
+		receiveFeedUpdate(t, updates)
+	}
+
+	feed.Publish(GameState{Score: 100})
+
+	update := receiveFeedUpdate(t, updates)
+	if update.Snapshot == nil {
+		t.Fatal("subscriber that missed updates should receive a full snapshot")
+	}
+	if update.Snapshot.Score != 100 {
+		t.Errorf("Snapshot.Score = %d, want 100", update.Snapshot.Score)
+	}
+}
+
+func TestStateFeed_Close(t *testing.T) {
+	feed := NewStateFeed()
+	updates, unsubscribe := feed.Subscribe()
+
+	feed.Close()
+	unsubscribe()
+
+	if _, ok := <-updates; ok {
+		t.Error("feed channel should be closed after Close()")
+	}
+
+	if feed.SubscriberCount() != 0 {
+		t.Errorf("SubscriberCount() = %d, want 0", feed.SubscriberCount())
+	}
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"fmt"
+	"tetris/domain/model"
+	"tetris/domain/service"
+)
+
+// FinessePiece は1ピース分の入力数（左右移動と回転）と、同じ列・向きに置くための最小入力数
+type FinessePiece struct {
+	Type    model.TetrominoType
+	Inputs  int
+	Minimal int
+}
+
+func (p FinessePiece) Faults() int {
+	return max(p.Inputs-p.Minimal, 0)
+}
+
+// FinesseStats はゲーム開始からのフィネスの集計。Lastは最後に固定されたピースの結果（まだなければnil）
+type FinesseStats struct {
+	Pieces       int
+	FaultyPieces int
+	WastedInputs int
+	Last         *FinessePiece
+}
+
+// finesseTracker は出現から固定までの入力を数え、固定されたピースの最終位置と比べる
+type finesseTracker struct {
+	stats  FinesseStats
+	spawn  *model.Tetromino
+	inputs int
+}
+
+func (t *finesseTracker) reset(current *model.Tetromino) {
+	t.stats = FinesseStats{}
+	t.begin(current)
+}
+
+func (t *finesseTracker) begin(current *model.Tetromino) {
+	t.spawn = nil
+	if current != nil {
+		t.spawn = current.Clone()
+	}
+	t.inputs = 0
+}
+
+func (t *finesseTracker) press() {
+	t.inputs++
+}
+
+// lock は固定されたピースの最終位置を受け取って集計し、次のピースの追跡を始める
+func (t *finesseTracker) lock(board *model.Board, final, next *model.Tetromino) error {
+	if t.spawn != nil && final != nil {
+		minimal, err := service.MinimalInputs(board.Width, board.Height, t.spawn, final)
+		if err != nil {
+			return fmt.Errorf("フィネス計算エラー: %w", err)
+		}
+
+		piece := FinessePiece{Type: final.Type, Inputs: t.inputs, Minimal: minimal}
+		t.stats.Pieces++
+		if faults := piece.Faults(); faults > 0 {
+			t.stats.FaultyPieces++
+			t.stats.WastedInputs += faults
+		}
+		t.stats.Last = &piece
+	}
+
+	t.begin(next)
+	return nil
+}
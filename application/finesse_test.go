@@ -0,0 +1,107 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGameController_Finesse(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputs       []string
+		pieces       int
+		faultyPieces int
+		wastedInputs int
+		lastInputs   int
+	}{
+		{name: "そのまま落とす", inputs: []string{"drop"}, pieces: 1},
+		{name: "最短の移動", inputs: []string{"left", "left", "drop"}, pieces: 1, lastInputs: 2},
+		{
+			name:   "往復は無駄",
+			inputs: []string{"left", "right", "drop"},
+			pieces: 1, faultyPieces: 1, wastedInputs: 2, lastInputs: 2,
+		},
+		{
+			name:   "1周回転は無駄",
+			inputs: []string{"rotate", "rotate", "rotate", "rotate", "drop"},
+			pieces: 1, faultyPieces: 1, wastedInputs: 4, lastInputs: 4,
+		},
+		{name: "ソフトドロップは数えない", inputs: []string{"down", "down", "drop"}, pieces: 1},
+		{
+			name:   "ピースごとに数え直す",
+			inputs: []string{"left", "right", "drop", "drop"},
+			pieces: 2, faultyPieces: 1, wastedInputs: 2,
+		},
+		{name: "固定前は集計しない", inputs: []string{"left", "right"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, err := NewGameControllerWithConfig(GameConfig{
+				Clock: FixedClock{Time: time.Unix(0, 0)},
+				Seed:  1,
+			})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+
+			for _, input := range tt.inputs {
+				if err := controller.HandleInput(input); err != nil {
+					t.Fatalf("HandleInput(%q) error = %v", input, err)
+				}
+			}
+
+			finesse := controller.GetGameState().Finesse
+			if finesse.Pieces != tt.pieces || finesse.FaultyPieces != tt.faultyPieces ||
+				finesse.WastedInputs != tt.wastedInputs {
+				t.Errorf("Finesse = %+v, want pieces=%d faulty=%d wasted=%d",
+					finesse, tt.pieces, tt.faultyPieces, tt.wastedInputs)
+			}
+			if tt.pieces == 0 {
+				if finesse.Last != nil {
+					t.Errorf("Finesse.Last = %+v, want nil", finesse.Last)
+				}
+				return
+			}
+			if finesse.Last == nil || finesse.Last.Inputs != tt.lastInputs {
+				t.Errorf("Finesse.Last = %+v, want inputs=%d", finesse.Last, tt.lastInputs)
+			}
+		})
+	}
+}
+
+func TestGameController_FinesseSummary(t *testing.T) {
+	controller, err := NewGameControllerWithConfig(GameConfig{
+		Clock: FixedClock{Time: time.Unix(0, 0)},
+		Seed:  1,
+	})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	for i := 0; i < 100 && !controller.IsFinished(); i++ {
+		for _, input := range []string{"left", "right", "drop"} {
+			if err := controller.HandleInput(input); err != nil {
+				t.Fatalf("HandleInput(%q) error = %v", input, err)
+			}
+		}
+	}
+
+	state := controller.GetGameState()
+	if state.Result == nil {
+		t.Fatal("Result = nil, want end-of-game summary")
+	}
+	if state.Result.Finesse.Pieces != state.PiecesLocked {
+		t.Errorf("Result.Finesse.Pieces = %d, want %d", state.Result.Finesse.Pieces, state.PiecesLocked)
+	}
+	if state.Result.Finesse.FaultyPieces == 0 {
+		t.Errorf("Result.Finesse.FaultyPieces = 0, want faults for wasted inputs")
+	}
+
+	if err := controller.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if finesse := controller.GetGameState().Finesse; finesse.Pieces != 0 || finesse.Last != nil {
+		t.Errorf("Finesse after Reset() = %+v, want zero", finesse)
+	}
+}
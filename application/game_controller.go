@@ -9,13 +9,46 @@ import (
 )
 
 type GameState struct {
-	Board        *model.Board
-	CurrentPiece *model.Tetromino
-	NextPiece    *model.Tetromino
-	Score        int
-	Lines        int
-	Level        int
-	GameOver     bool
+	Board         *model.Board
+	CurrentPiece  *model.Tetromino
+	NextPiece     *model.Tetromino
+	Queue         []model.TetrominoType
+	HoldPiece     *model.Tetromino
+	HoldEnabled   bool
+	HoldAvailable bool
+	Pieces        []model.TetrominoType
+	Score         int
+	Lines         int
+	Level         int
+	GameOver      bool
+	Garbage       int
+	LastClear     service.ClearInfo
+	TopOut        service.TopOutCause
+	PiecesLocked  int
+	Finesse       FinesseStats
+	Stats         PlayStats
+	History       HistoryInfo
+	Mode          ModeInfo
+	Status        ModeStatus
+	Elapsed       time.Duration
+	Result        *ModeResult
+}
+
+type GameConfig struct {
+	Mode       GameMode
+	Clock      Clock
+	Records    RecordRepository
+	StartLevel int
+	Seed       uint64
+	// UndoLimit は取り消せるピースの数。0なら履歴を記録せず、取り消しとやり直しは何もしない
+	UndoLimit int
+	// Pieces はランダムに出現するピースの種類。空ならゲームモードの設定（標準の7種類）のまま
+	Pieces []model.TetrominoType
+	// Width と Height はボードの大きさ。0ならゲームモードの設定（標準の10x20）のまま
+	Width  int
+	Height int
+	// Preview は先に見えるネクストの数。0ならゲームモードの設定（1つ）のまま
+	Preview int
 }
 
 type GameController struct {
@@ -23,75 +56,310 @@ type GameController struct {
 	dropTimer    time.Time
 	dropInterval time.Duration
 	isPaused     bool
+	mode         GameMode
+	clock        Clock
+	records      RecordRepository
+	startLevel   int
+	seed         uint64
+	status       ModeStatus
+	playTime     time.Duration
+	lastTick     time.Time
+	result       *ModeResult
+	finesse      finesseTracker
+	stats        statsCollector
+	subscribers  []service.EventHandler
+	undoLimit    int
+	history      *history
+	pieces       []model.TetrominoType
+	width        int
+	height       int
+	preview      int
 }
 
 func NewGameController() (*GameController, error) {
-	gameService, err := service.NewGameService()
-	if err != nil {
+	return NewGameControllerWithConfig(GameConfig{})
+}
+
+func NewGameControllerWithConfig(config GameConfig) (*GameController, error) {
+	if config.Mode == nil {
+		config.Mode = NewEndlessMode()
+	}
+	if config.Clock == nil {
+		config.Clock = systemClock{}
+	}
+
+	gc := &GameController{
+		mode:       config.Mode,
+		clock:      config.Clock,
+		records:    config.Records,
+		startLevel: config.StartLevel,
+		seed:       config.Seed,
+		undoLimit:  config.UndoLimit,
+		pieces:     config.Pieces,
+		width:      config.Width,
+		height:     config.Height,
+		preview:    config.Preview,
+	}
+
+	if err := gc.start(); err != nil {
 		return nil, fmt.Errorf("ゲームサービス初期化エラー: %w", err)
 	}
 
-	return &GameController{
-		gameService:  gameService,
-		dropTimer:    time.Now(),
-		dropInterval: time.Second,
-		isPaused:     false,
-	}, nil
+	return gc, nil
+}
+
+func (gc *GameController) start() error {
+	options := gc.mode.Options()
+	if gc.startLevel > 0 {
+		options.StartLevel = gc.startLevel
+	}
+	options.Seed = gc.seed
+	if len(gc.pieces) > 0 {
+		options.Pieces = gc.pieces
+	}
+	if gc.width > 0 {
+		options.Width = gc.width
+	}
+	if gc.height > 0 {
+		options.Height = gc.height
+	}
+	if gc.preview > 0 {
+		options.Preview = gc.preview
+	}
+
+	gameService, err := service.NewGameServiceWithOptions(options)
+	if err != nil {
+		return err
+	}
+
+	if err := gc.mode.Start(gameService); err != nil {
+		return fmt.Errorf("ゲームモード開始エラー: %w", err)
+	}
+
+	now := gc.clock.Now()
+	gc.gameService = gameService
+	gc.dropTimer = now
+	gc.dropInterval = time.Second
+	gc.isPaused = false
+	gc.status = ModePlaying
+	gc.playTime = 0
+	gc.lastTick = now
+	gc.result = nil
+	gc.finesse.reset(gameService.GetCurrentPiece())
+	gc.stats.reset()
+	gameService.Subscribe(gc.stats.handle)
+	for _, handler := range gc.subscribers {
+		gameService.Subscribe(handler)
+	}
+	gc.updateDropInterval()
+
+	gc.history = nil
+	if gc.undoLimit > 0 {
+		entry, err := gc.captureHistory()
+		if err != nil {
+			return err
+		}
+		gc.history = newHistory(gc.undoLimit, entry)
+	}
+
+	// パズルのように最初のピースが出現できない盤面から始まった場合は、開始時点で終了させる
+	return gc.evaluateMode()
 }
 
 func (gc *GameController) GetGameState() GameState {
 	return GameState{
-		Board:        gc.gameService.GetBoard(),
-		CurrentPiece: gc.gameService.GetCurrentPiece(),
-		NextPiece:    gc.gameService.GetNextPiece(),
-		Score:        gc.gameService.GetScore(),
-		Lines:        gc.gameService.GetLines(),
-		Level:        gc.gameService.GetLevel(),
-		GameOver:     gc.gameService.IsGameOver(),
+		Board:         gc.gameService.GetBoard(),
+		CurrentPiece:  gc.gameService.GetCurrentPiece(),
+		NextPiece:     gc.gameService.GetNextPiece(),
+		Queue:         gc.gameService.GetQueue(),
+		HoldPiece:     gc.gameService.GetHoldPiece(),
+		HoldEnabled:   gc.gameService.HoldEnabled(),
+		HoldAvailable: gc.gameService.HoldAvailable(),
+		Pieces:        gc.gameService.GetPieces(),
+		Score:         gc.gameService.GetScore(),
+		Lines:         gc.gameService.GetLines(),
+		Level:         gc.gameService.GetLevel(),
+		GameOver:      gc.gameService.IsGameOver(),
+		Garbage:       gc.gameService.GetGarbageLines(),
+		LastClear:     gc.gameService.GetLastClear(),
+		TopOut:        gc.gameService.GetTopOutCause(),
+		PiecesLocked:  gc.gameService.GetPiecesLocked(),
+		Finesse:       gc.finesse.stats,
+		Stats:         gc.stats.snapshot(gc.Elapsed()),
+		History:       gc.historyInfo(),
+		Mode:          gc.mode.Info(),
+		Status:        gc.status,
+		Elapsed:       gc.Elapsed(),
+		Result:        gc.result,
+	}
+}
+
+// Subscribe はゲーム中の出来事の購読者を登録する。リセットで始まる新しいゲームにも引き継がれる
+func (gc *GameController) Subscribe(handler service.EventHandler) {
+	gc.subscribers = append(gc.subscribers, handler)
+	gc.gameService.Subscribe(handler)
+}
+
+func (gc *GameController) Elapsed() time.Duration {
+	if gc.isRunning() {
+		return gc.clampToTimeLimit(gc.playTime + gc.clock.Now().Sub(gc.lastTick))
 	}
+	return gc.playTime
+}
+
+func (gc *GameController) RemainingTime() time.Duration {
+	timeLimit := gc.mode.Info().TimeLimit
+	if timeLimit <= 0 {
+		return 0
+	}
+	return timeLimit - gc.Elapsed()
+}
+
+func (gc *GameController) IsFinished() bool {
+	return gc.status != ModePlaying
 }
 
 func (gc *GameController) Update() error {
-	if gc.isPaused || gc.gameService.IsGameOver() {
+	gc.tick()
+
+	if gc.isPaused || gc.IsFinished() {
 		return nil
 	}
 
-	if time.Since(gc.dropTimer) >= gc.dropInterval {
-		if err := gc.gameService.Update(); err != nil {
-			if errors.Is(err, service.ErrGameOver) {
-				return nil
-			}
-			return fmt.Errorf("ゲーム更新エラー: %w", err)
+	if gc.gameService.IsGameOver() || gc.isTimeUp() {
+		return gc.evaluateMode()
+	}
+
+	if gc.clock.Now().Sub(gc.dropTimer) >= gc.dropInterval {
+		before := gc.snapshotPiece()
+		updateErr := gc.gameService.Update()
+		if updateErr != nil && !errors.Is(updateErr, service.ErrGameOver) {
+			return fmt.Errorf("ゲーム更新エラー: %w", updateErr)
+		}
+		if err := gc.trackLock(before); err != nil {
+			return err
 		}
-		gc.dropTimer = time.Now()
+		if updateErr != nil {
+			return gc.evaluateMode()
+		}
+		gc.dropTimer = gc.clock.Now()
 		gc.updateDropInterval()
 	}
 
-	return nil
+	return gc.evaluateMode()
 }
 
 func (gc *GameController) HandleInput(input string) error {
-	if gc.gameService.IsGameOver() {
+	switch input {
+	case "undo", "z", "Z":
+		return gc.Undo()
+	case "redo", "y", "Y":
+		return gc.Redo()
+	case "pause", "p", "P":
+		if !gc.IsFinished() && !gc.gameService.IsGameOver() {
+			gc.togglePause()
+		}
 		return nil
 	}
 
+	// 一時停止中は時計が止まっているため、移動や固定を受け付けるとタイムを止めたまま遊べてしまう
+	if gc.isPaused || gc.gameService.IsGameOver() || gc.IsFinished() {
+		return nil
+	}
+
+	gc.tick()
+	if gc.isTimeUp() {
+		return gc.evaluateMode()
+	}
+
+	before := gc.snapshotPiece()
+	var err error
 	switch input {
 	case "left", "a", "A":
-		return gc.movePieceLeft()
+		gc.finesse.press()
+		err = gc.movePieceLeft()
 	case "right", "d", "D":
-		return gc.movePieceRight()
+		gc.finesse.press()
+		err = gc.movePieceRight()
 	case "down", "s", "S":
-		return gc.movePieceDown()
+		err = gc.movePieceDown()
 	case "rotate", "w", "W":
-		return gc.rotatePiece()
+		gc.finesse.press()
+		err = gc.rotatePiece()
 	case "drop", "space":
-		return gc.dropPiece()
-	case "pause", "p", "P":
-		gc.togglePause()
-		return nil
+		err = gc.dropPiece()
+	case "hold", "c", "C":
+		err = gc.holdPiece()
 	default:
 		return fmt.Errorf("不明な入力: %s", input)
 	}
+	if err != nil {
+		return err
+	}
+	gc.stats.press()
+	if err := gc.trackLock(before); err != nil {
+		return err
+	}
+
+	gc.tick()
+	return gc.evaluateMode()
+}
+
+type pieceSnapshot struct {
+	piece  *model.Tetromino
+	locked int
+}
+
+func (gc *GameController) snapshotPiece() pieceSnapshot {
+	snapshot := pieceSnapshot{locked: gc.gameService.GetPiecesLocked()}
+	if current := gc.gameService.GetCurrentPiece(); current != nil {
+		snapshot.piece = current.Clone()
+	}
+	return snapshot
+}
+
+// trackLock は操作の前後で固定されたピースが増えていれば、操作前の位置を最終位置としてフィネスを集計し、
+// 取り消しの履歴を記録する。ハードドロップと自然落下による固定はどちらも列と向きを変えないため、操作前の位置で比べられる
+func (gc *GameController) trackLock(before pieceSnapshot) error {
+	if gc.gameService.GetPiecesLocked() == before.locked {
+		return nil
+	}
+	var next *model.Tetromino
+	if !gc.gameService.IsGameOver() {
+		next = gc.gameService.GetCurrentPiece()
+	}
+	if err := gc.finesse.lock(gc.gameService.GetBoard(), before.piece, next); err != nil {
+		return err
+	}
+
+	if gc.history == nil {
+		return nil
+	}
+	entry, err := gc.captureHistory()
+	if err != nil {
+		return err
+	}
+	gc.history.commit(entry)
+	return nil
+}
+
+func (gc *GameController) historyInfo() HistoryInfo {
+	if gc.history == nil {
+		return HistoryInfo{}
+	}
+	return gc.history.info()
+}
+
+func (gc *GameController) ReceiveGarbage(lines int) error {
+	if gc.IsFinished() {
+		return nil
+	}
+
+	if err := gc.gameService.AddGarbage(lines); err != nil && !errors.Is(err, service.ErrGameOver) {
+		return fmt.Errorf("おじゃまライン受信エラー: %w", err)
+	}
+
+	return gc.evaluateMode()
 }
 
 func (gc *GameController) movePieceLeft() error {
@@ -116,7 +384,7 @@ func (gc *GameController) movePieceDown() error {
 		return fmt.Errorf("下移動エラー: %w", err)
 	}
 	if err == nil {
-		gc.dropTimer = time.Now()
+		gc.dropTimer = gc.clock.Now()
 	}
 	return nil
 }
@@ -134,18 +402,139 @@ func (gc *GameController) dropPiece() error {
 	if err != nil {
 		return fmt.Errorf("ドロップエラー: %w", err)
 	}
-	gc.dropTimer = time.Now()
+	gc.dropTimer = gc.clock.Now()
+	return nil
+}
+
+func (gc *GameController) holdPiece() error {
+	err := gc.gameService.HoldPiece()
+	if errors.Is(err, service.ErrHoldUnavailable) {
+		return nil
+	}
+	if err != nil && !errors.Is(err, service.ErrGameOver) {
+		return fmt.Errorf("ホールドエラー: %w", err)
+	}
+	gc.finesse.begin(gc.gameService.GetCurrentPiece())
+	gc.dropTimer = gc.clock.Now()
 	return nil
 }
 
 func (gc *GameController) togglePause() {
+	gc.tick()
 	gc.isPaused = !gc.isPaused
+	if !gc.isPaused {
+		gc.dropTimer = gc.clock.Now()
+	}
 }
 
 func (gc *GameController) IsPaused() bool {
 	return gc.isPaused
 }
 
+func (gc *GameController) isRunning() bool {
+	return !gc.isPaused && !gc.IsFinished()
+}
+
+func (gc *GameController) tick() {
+	now := gc.clock.Now()
+	if gc.isRunning() {
+		gc.playTime = gc.clampToTimeLimit(gc.playTime + now.Sub(gc.lastTick))
+	}
+	gc.lastTick = now
+}
+
+func (gc *GameController) isTimeUp() bool {
+	timeLimit := gc.mode.Info().TimeLimit
+	return timeLimit > 0 && gc.playTime >= timeLimit
+}
+
+func (gc *GameController) clampToTimeLimit(elapsed time.Duration) time.Duration {
+	timeLimit := gc.mode.Info().TimeLimit
+	if timeLimit > 0 && elapsed > timeLimit {
+		return timeLimit
+	}
+	return elapsed
+}
+
+func (gc *GameController) evaluateMode() error {
+	if gc.IsFinished() {
+		return nil
+	}
+
+	progress := ModeProgress{
+		Elapsed:          gc.playTime,
+		Score:            gc.gameService.GetScore(),
+		Lines:            gc.gameService.GetLines(),
+		Level:            gc.gameService.GetLevel(),
+		GarbageRemaining: gc.gameService.GetGarbageLines(),
+		GameOver:         gc.gameService.IsGameOver(),
+	}
+
+	status, err := gc.mode.Update(gc.gameService, progress)
+	if err != nil {
+		return fmt.Errorf("ゲームモード更新エラー: %w", err)
+	}
+	gc.status = status
+	if gc.status == ModePlaying {
+		return nil
+	}
+
+	return gc.finish(progress)
+}
+
+func (gc *GameController) finish(progress ModeProgress) error {
+	info := gc.mode.Info()
+	board := gc.gameService.GetBoard()
+	result := &ModeResult{
+		Mode:       RecordMode(info.Name, board.Width, board.Height),
+		Status:     gc.status,
+		Elapsed:    progress.Elapsed,
+		Score:      progress.Score,
+		Lines:      progress.Lines,
+		Level:      progress.Level,
+		Splits:     info.Splits,
+		RankByTime: info.RankByTime,
+		Finesse:    gc.finesse.stats,
+		Stats:      gc.stats.snapshot(progress.Elapsed),
+	}
+	gc.result = result
+
+	if gc.records == nil || !gc.standardPieces() {
+		return nil
+	}
+
+	records, err := gc.records.Load()
+	if err != nil {
+		return fmt.Errorf("記録読み込みエラー: %w", err)
+	}
+
+	result.PersonalBest = records.Add(*result, gc.clock.Now())
+	result.BestTime, _ = records.BestTime(result.Mode)
+	result.BestScore, _ = records.BestScore(result.Mode)
+
+	if err := gc.records.Save(records); err != nil {
+		return fmt.Errorf("記録保存エラー: %w", err)
+	}
+
+	return nil
+}
+
+// standardPieces は標準の7種類のピースで遊んでいるかを返す。他のピースセットの結果は標準の記録と比べられないため残さない
+func (gc *GameController) standardPieces() bool {
+	if len(gc.pieces) == 0 {
+		return true
+	}
+	if len(gc.pieces) != len(model.StandardPieces) {
+		return false
+	}
+	for i, pieceType := range gc.pieces {
+		if pieceType != model.StandardPieces[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (gc *GameController) updateDropInterval() {
 	level := gc.gameService.GetLevel()
 	baseInterval := 1000 * time.Millisecond
@@ -158,15 +547,9 @@ func (gc *GameController) updateDropInterval() {
 }
 
 func (gc *GameController) Reset() error {
-	gameService, err := service.NewGameService()
-	if err != nil {
+	if err := gc.start(); err != nil {
 		return fmt.Errorf("ゲームリセットエラー: %w", err)
 	}
 
-	gc.gameService = gameService
-	gc.dropTimer = time.Now()
-	gc.dropInterval = time.Second
-	gc.isPaused = false
-
 	return nil
 }
@@ -1,8 +1,10 @@
 package application
 
 import (
+	"reflect"
 	"testing"
 	"tetris/domain/model"
+	"tetris/domain/service"
 	"time"
 )
 
@@ -275,6 +277,68 @@ func TestGameController_PauseToggle(t *testing.T) {
 	}
 }
 
+func TestGameController_InputWhilePaused(t *testing.T) {
+	for _, input := range []string{"drop", "left", "right", "down", "rotate", "hold"} {
+		t.Run(input, func(t *testing.T) {
+			controller, err := NewGameControllerWithConfig(GameConfig{Clock: newFakeClock(), Seed: 3})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+			if err := controller.HandleInput("pause"); err != nil {
+				t.Fatalf("HandleInput(pause) error = %v", err)
+			}
+			before := controller.GetGameState()
+
+			if err := controller.HandleInput(input); err != nil {
+				t.Fatalf("HandleInput(%s) error = %v", input, err)
+			}
+
+			after := controller.GetGameState()
+			if after.Board.String() != before.Board.String() {
+				t.Errorf("board changed while paused:\n%s", after.Board.String())
+			}
+			if after.Score != before.Score || after.PiecesLocked != before.PiecesLocked {
+				t.Errorf("score = %d, locked = %d, want %d, %d",
+					after.Score, after.PiecesLocked, before.Score, before.PiecesLocked)
+			}
+			if !reflect.DeepEqual(after.CurrentPiece.GetBlocks(), before.CurrentPiece.GetBlocks()) {
+				t.Errorf("piece moved while paused: %+v", after.CurrentPiece)
+			}
+			if !controller.IsPaused() {
+				t.Error("controller should stay paused")
+			}
+		})
+	}
+}
+
+func TestGameController_Preview(t *testing.T) {
+	tests := []struct {
+		name    string
+		preview int
+		want    int
+	}{
+		{name: "指定なし", preview: 0, want: 1},
+		{name: "4つ先まで", preview: 4, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, err := NewGameControllerWithConfig(GameConfig{Clock: newFakeClock(), Seed: 3, Preview: tt.preview})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+
+			state := controller.GetGameState()
+			if len(state.Queue) != tt.want {
+				t.Fatalf("len(Queue) = %d, want %d", len(state.Queue), tt.want)
+			}
+			if state.Queue[0] != state.NextPiece.Type {
+				t.Errorf("Queue[0] = %d, want NextPiece %d", state.Queue[0], state.NextPiece.Type)
+			}
+		})
+	}
+}
+
 func TestGameController_Update(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -464,3 +528,55 @@ func TestGameController_DropIntervalUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestGameController_Subscribe(t *testing.T) {
+	controller, err := NewGameControllerWithConfig(GameConfig{Clock: newFakeClock(), Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	locked := 0
+	controller.Subscribe(func(event service.Event) {
+		if _, ok := event.(service.PieceLocked); ok {
+			locked++
+		}
+	})
+
+	if err := controller.HandleInput("drop"); err != nil {
+		t.Fatalf("HandleInput() error = %v", err)
+	}
+	if err := controller.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if err := controller.HandleInput("drop"); err != nil {
+		t.Fatalf("HandleInput() error = %v", err)
+	}
+
+	if locked != 2 {
+		t.Errorf("PieceLocked events = %d, want 2 (subscription should survive Reset)", locked)
+	}
+}
+
+func TestGameController_Pieces(t *testing.T) {
+	gc, err := NewGameControllerWithConfig(GameConfig{Seed: 1, Pieces: model.PentominoPieces})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	active := make(map[model.TetrominoType]bool)
+	for _, pieceType := range model.PentominoPieces {
+		active[pieceType] = true
+	}
+	for i := 0; i < 10; i++ {
+		state := gc.GetGameState()
+		if !active[state.CurrentPiece.Type] {
+			t.Fatalf("piece %d = %v, want a pentomino", i, state.CurrentPiece.Type)
+		}
+		if err := gc.HandleInput("drop"); err != nil {
+			t.Fatalf("HandleInput(drop) error = %v", err)
+		}
+		if gc.GetGameState().GameOver {
+			break
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"tetris/domain/model"
+	"tetris/domain/service"
+	"time"
+)
+
+const (
+	ModeEndless  = "endless"
+	ModeMarathon = "marathon"
+	ModeSprint   = "sprint"
+	ModeUltra    = "ultra"
+	ModeDig      = "dig"
+	ModeSurvival = "survival"
+	ModePractice = "practice"
+)
+
+var ErrUnknownMode = errors.New("不明なゲームモードです")
+
+type ModeStatus int
+
+const (
+	ModePlaying ModeStatus = iota
+	ModeCompleted
+	ModeFailed
+)
+
+type ModeProgress struct {
+	Elapsed          time.Duration
+	Score            int
+	Lines            int
+	Level            int
+	GarbageRemaining int
+	GameOver         bool
+}
+
+type ModeInfo struct {
+	Name       string
+	LineGoal   int
+	TimeLimit  time.Duration
+	Splits     []time.Duration
+	RankByTime bool
+	NextRise   time.Duration
+	Puzzle     *PuzzleInfo
+}
+
+type ModeResult struct {
+	// Mode は記録の区分。RecordMode でボードの大きさを含めたモード名
+	Mode         string
+	Status       ModeStatus
+	Elapsed      time.Duration
+	Score        int
+	Lines        int
+	Level        int
+	Splits       []time.Duration
+	RankByTime   bool
+	PersonalBest bool
+	BestTime     time.Duration
+	BestScore    int
+	Finesse      FinesseStats
+	Stats        PlayStats
+}
+
+type GameMode interface {
+	Name() string
+	Options() service.GameOptions
+	Start(gameService *service.GameService) error
+	Update(gameService *service.GameService, progress ModeProgress) (ModeStatus, error)
+	Info() ModeInfo
+	// Snapshot と Restore は取り消しで履歴を戻すときに、モード内部の進行状態もゲームと同じ時点に戻す
+	Snapshot() ModeSnapshot
+	Restore(snapshot ModeSnapshot)
+}
+
+// ModeSnapshot はモード内部の進行状態の複製。使わないフィールドはモードごとにゼロ値のまま
+type ModeSnapshot struct {
+	splits     []time.Duration
+	interval   time.Duration
+	nextRise   time.Duration
+	piecesLeft int
+}
+
+func NewGameMode(name string) (GameMode, error) {
+	switch strings.ToLower(name) {
+	case "", ModeEndless:
+		return NewEndlessMode(), nil
+	case ModeMarathon:
+		return NewMarathonMode(), nil
+	case ModeSprint:
+		return NewSprintMode(), nil
+	case ModeUltra:
+		return NewUltraMode(), nil
+	case ModeDig:
+		return NewDigMode(DigGarbageLines), nil
+	case ModeSurvival:
+		return NewSurvivalMode(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMode, name)
+	}
+}
+
+type EndlessMode struct{}
+
+func NewEndlessMode() *EndlessMode {
+	return &EndlessMode{}
+}
+
+func (m *EndlessMode) Name() string {
+	return ModeEndless
+}
+
+func (m *EndlessMode) Options() service.GameOptions {
+	return service.GameOptions{}
+}
+
+func (m *EndlessMode) Start(_ *service.GameService) error {
+	return nil
+}
+
+func (m *EndlessMode) Update(_ *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *EndlessMode) Info() ModeInfo {
+	return ModeInfo{Name: ModeEndless}
+}
+
+func (m *EndlessMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{}
+}
+
+func (m *EndlessMode) Restore(_ ModeSnapshot) {}
+
+const (
+	MarathonLineGoal = 150
+	MarathonMaxLevel = 15
+)
+
+type MarathonMode struct {
+	lineGoal int
+	maxLevel int
+}
+
+func NewMarathonMode() *MarathonMode {
+	return &MarathonMode{
+		lineGoal: MarathonLineGoal,
+		maxLevel: MarathonMaxLevel,
+	}
+}
+
+func (m *MarathonMode) Name() string {
+	return ModeMarathon
+}
+
+func (m *MarathonMode) Options() service.GameOptions {
+	return service.GameOptions{MaxLevel: m.maxLevel}
+}
+
+func (m *MarathonMode) Start(_ *service.GameService) error {
+	return nil
+}
+
+func (m *MarathonMode) Update(_ *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	if progress.Lines >= m.lineGoal {
+		return ModeCompleted, nil
+	}
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *MarathonMode) Info() ModeInfo {
+	return ModeInfo{
+		Name:     ModeMarathon,
+		LineGoal: m.lineGoal,
+	}
+}
+
+func (m *MarathonMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{}
+}
+
+func (m *MarathonMode) Restore(_ ModeSnapshot) {}
+
+const (
+	SprintLineGoal      = 40
+	SprintSplitInterval = 10
+)
+
+type SprintMode struct {
+	lineGoal      int
+	splitInterval int
+	splits        []time.Duration
+}
+
+func NewSprintMode() *SprintMode {
+	return &SprintMode{
+		lineGoal:      SprintLineGoal,
+		splitInterval: SprintSplitInterval,
+	}
+}
+
+func (m *SprintMode) Name() string {
+	return ModeSprint
+}
+
+func (m *SprintMode) Options() service.GameOptions {
+	return service.GameOptions{}
+}
+
+func (m *SprintMode) Start(_ *service.GameService) error {
+	m.splits = nil
+	return nil
+}
+
+func (m *SprintMode) Update(_ *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	maxSplits := m.lineGoal / m.splitInterval
+	for len(m.splits) < maxSplits && progress.Lines >= (len(m.splits)+1)*m.splitInterval {
+		m.splits = append(m.splits, progress.Elapsed)
+	}
+
+	if progress.Lines >= m.lineGoal {
+		return ModeCompleted, nil
+	}
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *SprintMode) Info() ModeInfo {
+	splits := make([]time.Duration, len(m.splits))
+	copy(splits, m.splits)
+
+	return ModeInfo{
+		Name:       ModeSprint,
+		LineGoal:   m.lineGoal,
+		Splits:     splits,
+		RankByTime: true,
+	}
+}
+
+func (m *SprintMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{splits: slices.Clone(m.splits)}
+}
+
+func (m *SprintMode) Restore(snapshot ModeSnapshot) {
+	m.splits = slices.Clone(snapshot.splits)
+}
+
+const UltraTimeLimit = 2 * time.Minute
+
+type UltraMode struct {
+	timeLimit time.Duration
+}
+
+func NewUltraMode() *UltraMode {
+	return &UltraMode{timeLimit: UltraTimeLimit}
+}
+
+func (m *UltraMode) Name() string {
+	return ModeUltra
+}
+
+func (m *UltraMode) Options() service.GameOptions {
+	return service.GameOptions{}
+}
+
+func (m *UltraMode) Start(_ *service.GameService) error {
+	return nil
+}
+
+func (m *UltraMode) Update(_ *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	if progress.Elapsed >= m.timeLimit {
+		return ModeCompleted, nil
+	}
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *UltraMode) Info() ModeInfo {
+	return ModeInfo{
+		Name:      ModeUltra,
+		TimeLimit: m.timeLimit,
+	}
+}
+
+func (m *UltraMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{}
+}
+
+func (m *UltraMode) Restore(_ ModeSnapshot) {}
+
+const DigGarbageLines = 10
+
+type DigMode struct {
+	garbageLines int
+}
+
+func NewDigMode(garbageLines int) *DigMode {
+	return &DigMode{garbageLines: garbageLines}
+}
+
+func (m *DigMode) Name() string {
+	return ModeDig
+}
+
+func (m *DigMode) Options() service.GameOptions {
+	return service.GameOptions{}
+}
+
+func (m *DigMode) Start(gameService *service.GameService) error {
+	if err := gameService.AddGarbage(m.garbageLines); err != nil {
+		return fmt.Errorf("おじゃまライン生成エラー: %w", err)
+	}
+	return nil
+}
+
+func (m *DigMode) Update(_ *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	if progress.GarbageRemaining == 0 {
+		return ModeCompleted, nil
+	}
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *DigMode) Info() ModeInfo {
+	return ModeInfo{
+		Name:       ModeDig,
+		RankByTime: true,
+	}
+}
+
+func (m *DigMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{}
+}
+
+func (m *DigMode) Restore(_ ModeSnapshot) {}
+
+const (
+	SurvivalInitialInterval = 10 * time.Second
+	SurvivalMinInterval     = 2 * time.Second
+	SurvivalSpeedUpPercent  = 90
+)
+
+type SurvivalMode struct {
+	initialInterval time.Duration
+	minInterval     time.Duration
+	interval        time.Duration
+	nextRise        time.Duration
+}
+
+func NewSurvivalMode() *SurvivalMode {
+	return &SurvivalMode{
+		initialInterval: SurvivalInitialInterval,
+		minInterval:     SurvivalMinInterval,
+	}
+}
+
+func (m *SurvivalMode) Name() string {
+	return ModeSurvival
+}
+
+func (m *SurvivalMode) Options() service.GameOptions {
+	return service.GameOptions{}
+}
+
+func (m *SurvivalMode) Start(_ *service.GameService) error {
+	m.interval = m.initialInterval
+	m.nextRise = m.initialInterval
+	return nil
+}
+
+func (m *SurvivalMode) Update(gameService *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	for !progress.GameOver && progress.Elapsed >= m.nextRise {
+		if err := gameService.AddGarbage(1); err != nil {
+			// せり上がりで上端を超えたときだけが失敗で、それ以外のエラーは呼び出し元に返す
+			if errors.Is(err, service.ErrGameOver) || errors.Is(err, model.ErrTopOut) {
+				return ModeFailed, nil
+			}
+			return ModePlaying, fmt.Errorf("おじゃまライン生成エラー: %w", err)
+		}
+		progress.GameOver = gameService.IsGameOver()
+
+		m.interval = max(m.interval*SurvivalSpeedUpPercent/100, m.minInterval)
+		m.nextRise += m.interval
+	}
+
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *SurvivalMode) Info() ModeInfo {
+	return ModeInfo{
+		Name:     ModeSurvival,
+		NextRise: m.nextRise,
+	}
+}
+
+func (m *SurvivalMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{interval: m.interval, nextRise: m.nextRise}
+}
+
+func (m *SurvivalMode) Restore(snapshot ModeSnapshot) {
+	m.interval = snapshot.interval
+	m.nextRise = snapshot.nextRise
+}
+
+// PracticeMode は共有された盤面などの指定した盤面から始めるエンドレスの練習モード
+type PracticeMode struct {
+	board *model.Board
+}
+
+func NewPracticeMode(board *model.Board) *PracticeMode {
+	return &PracticeMode{board: board.Clone()}
+}
+
+func (m *PracticeMode) Name() string {
+	return ModePractice
+}
+
+func (m *PracticeMode) Options() service.GameOptions {
+	return service.GameOptions{Board: m.board}
+}
+
+func (m *PracticeMode) Start(_ *service.GameService) error {
+	return nil
+}
+
+func (m *PracticeMode) Update(_ *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	if progress.GameOver {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *PracticeMode) Info() ModeInfo {
+	return ModeInfo{Name: ModePractice}
+}
+
+func (m *PracticeMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{}
+}
+
+func (m *PracticeMode) Restore(_ ModeSnapshot) {}
@@ -0,0 +1,735 @@
+package application
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"tetris/domain/model"
+	"tetris/domain/service"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type memoryRecordRepository struct {
+	records Records
+	saved   int
+}
+
+func (r *memoryRecordRepository) Load() (Records, error) {
+	return r.records, nil
+}
+
+func (r *memoryRecordRepository) Save(records Records) error {
+	r.records = records
+	r.saved++
+	return nil
+}
+
+func TestNewGameMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		modeName    string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "デフォルトはエンドレス",
+			modeName: "",
+			expected: ModeEndless,
+		},
+		{
+			name:     "スプリント",
+			modeName: "sprint",
+			expected: ModeSprint,
+		},
+		{
+			name:     "大文字でも指定可能",
+			modeName: "SPRINT",
+			expected: ModeSprint,
+		},
+		{
+			name:        "不明なモード",
+			modeName:    "unknown",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := NewGameMode(tt.modeName)
+
+			if tt.expectError {
+				if !errors.Is(err, ErrUnknownMode) {
+					t.Errorf("NewGameMode() error = %v, wantErr %v", err, ErrUnknownMode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewGameMode() unexpected error = %v", err)
+			}
+
+			if mode.Name() != tt.expected {
+				t.Errorf("NewGameMode() name = %s, want %s", mode.Name(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSprintMode_Update(t *testing.T) {
+	mode := NewSprintMode()
+	if err := mode.Start(nil); err != nil {
+		t.Fatalf("SprintMode.Start() error = %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		progress       ModeProgress
+		expectedStatus ModeStatus
+		expectedSplits int
+	}{
+		{
+			name:           "開始直後",
+			progress:       ModeProgress{Elapsed: time.Second, Lines: 0},
+			expectedStatus: ModePlaying,
+			expectedSplits: 0,
+		},
+		{
+			name:           "10ラインでスプリット記録",
+			progress:       ModeProgress{Elapsed: 10 * time.Second, Lines: 11},
+			expectedStatus: ModePlaying,
+			expectedSplits: 1,
+		},
+		{
+			name:           "複数スプリットを一度に通過",
+			progress:       ModeProgress{Elapsed: 30 * time.Second, Lines: 32},
+			expectedStatus: ModePlaying,
+			expectedSplits: 3,
+		},
+		{
+			name:           "40ラインで完走",
+			progress:       ModeProgress{Elapsed: 40 * time.Second, Lines: 41},
+			expectedStatus: ModeCompleted,
+			expectedSplits: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := mode.Update(nil, tt.progress)
+			if err != nil {
+				t.Fatalf("SprintMode.Update() error = %v", err)
+			}
+			if status != tt.expectedStatus {
+				t.Errorf("SprintMode.Update() status = %v, want %v", status, tt.expectedStatus)
+			}
+
+			if splits := mode.Info().Splits; len(splits) != tt.expectedSplits {
+				t.Errorf("SprintMode.Update() splits = %v, want %d splits", splits, tt.expectedSplits)
+			}
+		})
+	}
+
+	splits := mode.Info().Splits
+	if splits[0] != 10*time.Second || splits[1] != 30*time.Second || splits[3] != 40*time.Second {
+		t.Errorf("SprintMode splits = %v, unexpected split times", splits)
+	}
+}
+
+func TestSprintMode_Restore(t *testing.T) {
+	mode := NewSprintMode()
+	if err := mode.Start(nil); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	mode.Update(nil, ModeProgress{Lines: 8, Elapsed: 20 * time.Second})
+	snapshot := mode.Snapshot()
+
+	mode.Update(nil, ModeProgress{Lines: 12, Elapsed: 30 * time.Second})
+	mode.Restore(snapshot)
+	mode.Update(nil, ModeProgress{Lines: 10, Elapsed: 35 * time.Second})
+
+	if splits := mode.Info().Splits; !reflect.DeepEqual(splits, []time.Duration{35 * time.Second}) {
+		t.Errorf("Splits = %v, want the split recorded again after restore", splits)
+	}
+}
+
+func TestSprintMode_GameOver(t *testing.T) {
+	mode := NewSprintMode()
+
+	status, err := mode.Update(nil, ModeProgress{Lines: 5, GameOver: true})
+	if err != nil {
+		t.Fatalf("SprintMode.Update() error = %v", err)
+	}
+	if status != ModeFailed {
+		t.Errorf("SprintMode.Update() status = %v, want %v", status, ModeFailed)
+	}
+}
+
+func TestGameController_ElapsedExcludesPause(t *testing.T) {
+	clock := newFakeClock()
+	controller, err := NewGameControllerWithConfig(GameConfig{Mode: NewSprintMode(), Clock: clock})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	clock.Advance(1500 * time.Millisecond)
+	if err := controller.HandleInput("pause"); err != nil {
+		t.Fatalf("HandleInput(pause) error = %v", err)
+	}
+
+	clock.Advance(10 * time.Second)
+	if err := controller.Update(); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if err := controller.HandleInput("pause"); err != nil {
+		t.Fatalf("HandleInput(pause) error = %v", err)
+	}
+	clock.Advance(250 * time.Millisecond)
+
+	if elapsed := controller.GetGameState().Elapsed; elapsed != 1750*time.Millisecond {
+		t.Errorf("GameState.Elapsed = %v, want %v", elapsed, 1750*time.Millisecond)
+	}
+}
+
+func TestGameController_FinishSavesRecords(t *testing.T) {
+	clock := newFakeClock()
+	repository := &memoryRecordRepository{}
+	controller, err := NewGameControllerWithConfig(GameConfig{Clock: clock, Records: repository})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	controller.status = ModeFailed
+	if err := controller.finish(ModeProgress{Score: 500, Lines: 5, Level: 1}); err != nil {
+		t.Fatalf("finish() error = %v", err)
+	}
+
+	state := controller.GetGameState()
+	if state.Result == nil {
+		t.Fatal("GameState.Result is nil after finish")
+	}
+
+	if !state.Result.PersonalBest {
+		t.Error("first result should be a personal best")
+	}
+
+	if repository.saved != 1 {
+		t.Errorf("records saved %d times, want 1", repository.saved)
+	}
+
+	if best, _ := repository.records.BestScore(ModeEndless); best != 500 {
+		t.Errorf("BestScore(endless) = %d, want 500", best)
+	}
+}
+
+func TestRecords_Add(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var records Records
+
+	tests := []struct {
+		name         string
+		result       ModeResult
+		expectedBest bool
+	}{
+		{
+			name:         "最初のスプリント記録",
+			result:       ModeResult{Mode: ModeSprint, RankByTime: true, Status: ModeCompleted, Elapsed: 90 * time.Second},
+			expectedBest: true,
+		},
+		{
+			name:         "遅いスプリント記録",
+			result:       ModeResult{Mode: ModeSprint, RankByTime: true, Status: ModeCompleted, Elapsed: 100 * time.Second},
+			expectedBest: false,
+		},
+		{
+			name:         "未完走のスプリントは記録しない",
+			result:       ModeResult{Mode: ModeSprint, RankByTime: true, Status: ModeFailed, Elapsed: 10 * time.Second},
+			expectedBest: false,
+		},
+		{
+			name:         "速いスプリント記録",
+			result:       ModeResult{Mode: ModeSprint, RankByTime: true, Status: ModeCompleted, Elapsed: 80 * time.Second},
+			expectedBest: true,
+		},
+		{
+			name:         "エンドレスのスコア",
+			result:       ModeResult{Mode: ModeEndless, Status: ModeFailed, Score: 300},
+			expectedBest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := records.Add(tt.result, date); got != tt.expectedBest {
+				t.Errorf("Records.Add() = %v, want %v", got, tt.expectedBest)
+			}
+		})
+	}
+
+	if len(records.BestTimes[ModeSprint]) != 3 {
+		t.Errorf("BestTimes[sprint] length = %d, want 3", len(records.BestTimes[ModeSprint]))
+	}
+
+	if best, _ := records.BestTime(ModeSprint); best != 80*time.Second {
+		t.Errorf("BestSprintTime() = %v, want %v", best, 80*time.Second)
+	}
+}
+
+func TestGameController_UltraTimeLimit(t *testing.T) {
+	clock := newFakeClock()
+	repository := &memoryRecordRepository{}
+	controller, err := NewGameControllerWithConfig(GameConfig{
+		Mode:    NewUltraMode(),
+		Clock:   clock,
+		Records: repository,
+	})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		action            func()
+		expectedFinished  bool
+		expectedRemaining time.Duration
+	}{
+		{
+			name: "1分経過",
+			action: func() {
+				clock.Advance(time.Minute)
+			},
+			expectedFinished:  false,
+			expectedRemaining: time.Minute,
+		},
+		{
+			name: "一時停止中は時間が減らない",
+			action: func() {
+				controller.togglePause()
+				clock.Advance(5 * time.Minute)
+			},
+			expectedFinished:  false,
+			expectedRemaining: time.Minute,
+		},
+		{
+			name: "再開後も残り時間が継続",
+			action: func() {
+				controller.togglePause()
+				clock.Advance(59 * time.Second)
+			},
+			expectedFinished:  false,
+			expectedRemaining: time.Second,
+		},
+		{
+			name: "制限時間を超えると終了",
+			action: func() {
+				clock.Advance(3 * time.Second)
+			},
+			expectedFinished:  true,
+			expectedRemaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.action()
+			if err := controller.Update(); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if controller.IsFinished() != tt.expectedFinished {
+				t.Errorf("IsFinished() = %v, want %v", controller.IsFinished(), tt.expectedFinished)
+			}
+
+			if remaining := controller.RemainingTime(); remaining != tt.expectedRemaining {
+				t.Errorf("RemainingTime() = %v, want %v", remaining, tt.expectedRemaining)
+			}
+		})
+	}
+
+	state := controller.GetGameState()
+	if state.Result == nil || state.Result.Status != ModeCompleted {
+		t.Fatalf("GameState.Result = %+v, want completed result", state.Result)
+	}
+
+	if state.Result.Elapsed != UltraTimeLimit {
+		t.Errorf("Result.Elapsed = %v, want exactly %v", state.Result.Elapsed, UltraTimeLimit)
+	}
+
+	if _, ok := repository.records.BestScore(ModeUltra); !ok {
+		t.Error("ultra score should be stored in high scores")
+	}
+
+	if err := controller.HandleInput("drop"); err != nil {
+		t.Errorf("HandleInput() after time up error = %v", err)
+	}
+}
+
+func TestMarathonMode_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		progress       ModeProgress
+		expectedStatus ModeStatus
+	}{
+		{
+			name:           "プレイ中",
+			progress:       ModeProgress{Lines: 149, Level: 15},
+			expectedStatus: ModePlaying,
+		},
+		{
+			name:           "150ラインでクリア",
+			progress:       ModeProgress{Lines: 150, Level: 15},
+			expectedStatus: ModeCompleted,
+		},
+		{
+			name:           "トップアウトで失敗",
+			progress:       ModeProgress{Lines: 30, Level: 4, GameOver: true},
+			expectedStatus: ModeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := NewMarathonMode()
+			status, err := mode.Update(nil, tt.progress)
+			if err != nil {
+				t.Fatalf("MarathonMode.Update() error = %v", err)
+			}
+			if status != tt.expectedStatus {
+				t.Errorf("MarathonMode.Update() status = %v, want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestGameController_StartLevel(t *testing.T) {
+	tests := []struct {
+		name                 string
+		config               GameConfig
+		expectError          bool
+		expectedLevel        int
+		expectedDropInterval time.Duration
+	}{
+		{
+			name:                 "開始レベル指定",
+			config:               GameConfig{Mode: NewMarathonMode(), StartLevel: 5},
+			expectedLevel:        5,
+			expectedDropInterval: 600 * time.Millisecond,
+		},
+		{
+			name:        "マラソンの最大レベル超過",
+			config:      GameConfig{Mode: NewMarathonMode(), StartLevel: 16},
+			expectError: true,
+		},
+		{
+			name:                 "エンドレスは最大レベルなし",
+			config:               GameConfig{Mode: NewEndlessMode(), StartLevel: 16},
+			expectedLevel:        16,
+			expectedDropInterval: 100 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, err := NewGameControllerWithConfig(tt.config)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("NewGameControllerWithConfig() error = nil, wantErr")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() unexpected error = %v", err)
+			}
+
+			if level := controller.GetGameState().Level; level != tt.expectedLevel {
+				t.Errorf("GameState.Level = %d, want %d", level, tt.expectedLevel)
+			}
+
+			if controller.dropInterval != tt.expectedDropInterval {
+				t.Errorf("dropInterval = %v, want %v", controller.dropInterval, tt.expectedDropInterval)
+			}
+		})
+	}
+}
+
+func TestDigMode(t *testing.T) {
+	controller, err := NewGameControllerWithConfig(GameConfig{Mode: NewDigMode(8), Seed: 3})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	if garbage := controller.GetGameState().Garbage; garbage != 8 {
+		t.Errorf("GameState.Garbage = %d, want 8", garbage)
+	}
+
+	tests := []struct {
+		name           string
+		progress       ModeProgress
+		expectedStatus ModeStatus
+	}{
+		{
+			name:           "おじゃまライン残りあり",
+			progress:       ModeProgress{GarbageRemaining: 3},
+			expectedStatus: ModePlaying,
+		},
+		{
+			name:           "おじゃまラインを全消去",
+			progress:       ModeProgress{GarbageRemaining: 0},
+			expectedStatus: ModeCompleted,
+		},
+		{
+			name:           "トップアウト",
+			progress:       ModeProgress{GarbageRemaining: 5, GameOver: true},
+			expectedStatus: ModeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := NewDigMode(8)
+			status, err := mode.Update(nil, tt.progress)
+			if err != nil {
+				t.Fatalf("DigMode.Update() error = %v", err)
+			}
+			if status != tt.expectedStatus {
+				t.Errorf("DigMode.Update() status = %v, want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestSurvivalMode_RisingGarbage(t *testing.T) {
+	clock := newFakeClock()
+	controller, err := NewGameControllerWithConfig(GameConfig{Mode: NewSurvivalMode(), Clock: clock, Seed: 5})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		advance         time.Duration
+		expectedGarbage int
+	}{
+		{
+			name:            "最初のせり上がり前",
+			advance:         9 * time.Second,
+			expectedGarbage: 0,
+		},
+		{
+			name:            "10秒で1段",
+			advance:         time.Second,
+			expectedGarbage: 1,
+		},
+		{
+			name:            "間隔が9秒に短縮される",
+			advance:         9 * time.Second,
+			expectedGarbage: 2,
+		},
+		{
+			name:            "間隔が8.1秒に短縮される",
+			advance:         8100 * time.Millisecond,
+			expectedGarbage: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+			controller.dropTimer = clock.Now()
+
+			if err := controller.Update(); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if garbage := controller.GetGameState().Garbage; garbage != tt.expectedGarbage {
+				t.Errorf("GameState.Garbage = %d, want %d", garbage, tt.expectedGarbage)
+			}
+		})
+	}
+}
+
+func TestSurvivalMode_Deterministic(t *testing.T) {
+	boards := make([][][]bool, 2)
+	for i := range boards {
+		mode := NewSurvivalMode()
+		controller, err := NewGameControllerWithConfig(GameConfig{Mode: mode, Clock: newFakeClock(), Seed: 11})
+		if err != nil {
+			t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+		}
+
+		if _, err := mode.Update(controller.gameService, ModeProgress{Elapsed: 30 * time.Second}); err != nil {
+			t.Fatalf("SurvivalMode.Update() error = %v", err)
+		}
+		boards[i] = controller.GetGameState().Board.Grid
+	}
+
+	for y := range boards[0] {
+		for x := range boards[0][y] {
+			if boards[0][y][x] != boards[1][y][x] {
+				t.Fatalf("board cell (%d, %d) differs between runs with the same seed", x, y)
+			}
+		}
+	}
+}
+
+func TestSurvivalMode_RiseAfterGameOver(t *testing.T) {
+	gameService, err := service.NewGameServiceWithOptions(service.GameOptions{Seed: 3})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+	for i := 0; i < 100 && !gameService.IsGameOver(); i++ {
+		if err := gameService.DropPiece(); err != nil {
+			t.Fatalf("DropPiece() error = %v", err)
+		}
+	}
+
+	mode := NewSurvivalMode()
+	if err := mode.Start(gameService); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	// ゲームオーバーのサービスへのせり上がりはエラーではなく失敗として扱う
+	status, err := mode.Update(gameService, ModeProgress{Elapsed: SurvivalInitialInterval})
+	if err != nil {
+		t.Fatalf("SurvivalMode.Update() error = %v", err)
+	}
+	if status != ModeFailed {
+		t.Errorf("SurvivalMode.Update() status = %v, want %v", status, ModeFailed)
+	}
+}
+
+func TestPracticeMode(t *testing.T) {
+	board, err := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for x := 1; x < board.Width; x++ {
+		board.Grid[board.Height-1][x] = true
+	}
+
+	controller, err := NewGameControllerWithConfig(GameConfig{Mode: NewPracticeMode(board), Seed: 3})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	board.Grid[board.Height-1][0] = true
+
+	state := controller.GetGameState()
+	if state.Mode.Name != ModePractice {
+		t.Errorf("Mode.Name = %q, want %q", state.Mode.Name, ModePractice)
+	}
+	bottom := state.Board.Grid[state.Board.Height-1]
+	if bottom[0] || !bottom[1] || !bottom[9] {
+		t.Errorf("bottom row = %v, want the practice board unaffected by later changes", bottom)
+	}
+
+	status, err := NewPracticeMode(board).Update(nil, ModeProgress{GameOver: true})
+	if err != nil {
+		t.Fatalf("PracticeMode.Update() error = %v", err)
+	}
+	if status != ModeFailed {
+		t.Errorf("PracticeMode.Update() status = %v, want %v", status, ModeFailed)
+	}
+}
+
+func TestRecordMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		want   string
+	}{
+		{name: "標準のボード", width: model.BoardWidth, height: model.BoardHeight, want: ModeSprint},
+		{name: "4列のボード", width: 4, height: 20, want: "sprint@4x20"},
+		{name: "20列のボード", width: 20, height: 24, want: "sprint@20x24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecordMode(ModeSprint, tt.width, tt.height); got != tt.want {
+				t.Errorf("RecordMode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameController_BoardSizeRecords(t *testing.T) {
+	clock := newFakeClock()
+	repository := &memoryRecordRepository{}
+	controller, err := NewGameControllerWithConfig(GameConfig{
+		Mode:    NewUltraMode(),
+		Clock:   clock,
+		Records: repository,
+		Width:   4,
+	})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	if board := controller.GetGameState().Board; board.Width != 4 || board.Height != model.BoardHeight {
+		t.Fatalf("board = %dx%d, want 4x%d", board.Width, board.Height, model.BoardHeight)
+	}
+
+	clock.Advance(UltraTimeLimit + time.Second)
+	if err := controller.Update(); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	records, _ := repository.Load()
+	if len(records.HighScores["ultra@4x20"]) != 1 || len(records.HighScores[ModeUltra]) != 0 {
+		t.Errorf("HighScores = %v, want one record under ultra@4x20", records.HighScores)
+	}
+}
+
+func TestGameController_PieceSetRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces []model.TetrominoType
+		want   int
+	}{
+		{name: "標準のピース", pieces: model.StandardPieces, want: 1},
+		{name: "ペントミノ", pieces: model.PentominoPieces, want: 0},
+		{name: "大きいピース", pieces: model.BigPieces, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			repository := &memoryRecordRepository{}
+			controller, err := NewGameControllerWithConfig(GameConfig{
+				Mode:    NewUltraMode(),
+				Clock:   clock,
+				Records: repository,
+				Pieces:  tt.pieces,
+			})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+
+			clock.Advance(UltraTimeLimit + time.Second)
+			if err := controller.Update(); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			records, _ := repository.Load()
+			if got := len(records.HighScores[ModeUltra]); got != tt.want {
+				t.Errorf("len(HighScores[%s]) = %d, want %d", ModeUltra, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"fmt"
+	"tetris/domain/service"
+)
+
+// HistoryInfo は取り消しとやり直しができる回数。Enabledがfalseなら履歴を記録していない
+type HistoryInfo struct {
+	Enabled bool
+	Undo    int
+	Redo    int
+}
+
+// historyEntry はピースが出現した時点のゲームとモードの状態、その時点までの統計
+type historyEntry struct {
+	game    service.Snapshot
+	mode    ModeSnapshot
+	stats   PlayStats
+	finesse FinesseStats
+}
+
+// history はピースの固定ごとに状態を記録する。取り消しの記録はlimit個までで、古いものから捨てる
+type history struct {
+	limit   int
+	current historyEntry
+	undo    []historyEntry
+	redo    []historyEntry
+}
+
+func newHistory(limit int, current historyEntry) *history {
+	return &history{limit: limit, current: current}
+}
+
+func (h *history) info() HistoryInfo {
+	return HistoryInfo{Enabled: true, Undo: len(h.undo), Redo: len(h.redo)}
+}
+
+// commit は新しいピースが出現した時点の状態を記録する。やり直しの記録は新しい手を指した時点で捨てる
+func (h *history) commit(entry historyEntry) {
+	h.undo = append(h.undo, h.current)
+	if len(h.undo) > h.limit {
+		h.undo = h.undo[len(h.undo)-h.limit:]
+	}
+	h.redo = nil
+	h.current = entry
+}
+
+func (h *history) back() (historyEntry, bool) {
+	if len(h.undo) == 0 {
+		return historyEntry{}, false
+	}
+	entry := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, h.current)
+	h.current = entry
+	return entry, true
+}
+
+func (h *history) forward() (historyEntry, bool) {
+	if len(h.redo) == 0 {
+		return historyEntry{}, false
+	}
+	entry := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, h.current)
+	h.current = entry
+	return entry, true
+}
+
+func (gc *GameController) captureHistory() (historyEntry, error) {
+	game, err := gc.gameService.Snapshot()
+	if err != nil {
+		return historyEntry{}, fmt.Errorf("履歴の記録エラー: %w", err)
+	}
+	return historyEntry{
+		game:    game,
+		mode:    gc.mode.Snapshot(),
+		stats:   gc.stats.snapshot(0),
+		finesse: gc.finesse.stats,
+	}, nil
+}
+
+// Undo は最後に固定したピースを取り消し、そのピースが出現した時点に戻す。ゲームオーバーや
+// モードの終了も取り消してプレイを再開する。履歴を記録していないか、戻れる記録がなければ何もしない
+func (gc *GameController) Undo() error {
+	if gc.history == nil {
+		return nil
+	}
+	entry, ok := gc.history.back()
+	if !ok {
+		return nil
+	}
+	return gc.restoreHistory(entry)
+}
+
+// Redo は取り消したピースの固定をやり直す
+func (gc *GameController) Redo() error {
+	if gc.history == nil {
+		return nil
+	}
+	entry, ok := gc.history.forward()
+	if !ok {
+		return nil
+	}
+	return gc.restoreHistory(entry)
+}
+
+func (gc *GameController) restoreHistory(entry historyEntry) error {
+	if err := gc.gameService.Restore(entry.game); err != nil {
+		return fmt.Errorf("履歴の復元エラー: %w", err)
+	}
+
+	gc.mode.Restore(entry.mode)
+	gc.stats.restore(entry.stats)
+	gc.finesse.stats = entry.finesse
+	gc.finesse.begin(gc.gameService.GetCurrentPiece())
+
+	gc.tick()
+	gc.dropTimer = gc.clock.Now()
+	gc.updateDropInterval()
+	gc.status = ModePlaying
+	gc.result = nil
+	// 戻した先がゲームオーバーの状態なら、そのまま終了として扱う
+	return gc.evaluateMode()
+}
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"reflect"
+	"testing"
+	"tetris/domain/model"
+)
+
+type controllerState struct {
+	Grid         [][]bool
+	Current      model.Tetromino
+	Next         model.Tetromino
+	Score        int
+	Lines        int
+	PiecesLocked int
+	Stats        PlayStats
+	Finesse      FinesseStats
+}
+
+func captureController(gc *GameController) controllerState {
+	state := gc.GetGameState()
+	state.Stats.Elapsed = 0
+	return controllerState{
+		Grid:         state.Board.Clone().Grid,
+		Current:      *state.CurrentPiece.Clone(),
+		Next:         *state.NextPiece.Clone(),
+		Score:        state.Score,
+		Lines:        state.Lines,
+		PiecesLocked: state.PiecesLocked,
+		Stats:        state.Stats,
+		Finesse:      state.Finesse,
+	}
+}
+
+func newHistoryController(t *testing.T, limit int) *GameController {
+	t.Helper()
+	controller, err := NewGameControllerWithConfig(GameConfig{Clock: newFakeClock(), Seed: 3, UndoLimit: limit})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	return controller
+}
+
+func handleInputs(t *testing.T, gc *GameController, inputs ...string) {
+	t.Helper()
+	for _, input := range inputs {
+		if err := gc.HandleInput(input); err != nil {
+			t.Fatalf("HandleInput(%q) error = %v", input, err)
+		}
+	}
+}
+
+func TestGameController_UndoRedo(t *testing.T) {
+	controller := newHistoryController(t, 10)
+
+	handleInputs(t, controller, "left", "drop")
+	beforeSecond := captureController(controller)
+	handleInputs(t, controller, "right", "right", "rotate", "drop")
+	afterSecond := captureController(controller)
+
+	handleInputs(t, controller, "undo")
+	if got := captureController(controller); !reflect.DeepEqual(got, beforeSecond) {
+		t.Fatalf("after undo = %+v, want %+v", got, beforeSecond)
+	}
+	if info := controller.GetGameState().History; info != (HistoryInfo{Enabled: true, Undo: 1, Redo: 1}) {
+		t.Errorf("History = %+v, want 1 undo and 1 redo", info)
+	}
+
+	handleInputs(t, controller, "redo")
+	if got := captureController(controller); !reflect.DeepEqual(got, afterSecond) {
+		t.Fatalf("after redo = %+v, want %+v", got, afterSecond)
+	}
+
+	// 取り消した後に同じ操作をすると、同じピースの並びで同じ局面になる
+	handleInputs(t, controller, "undo", "right", "right", "rotate", "drop")
+	if got := captureController(controller); !reflect.DeepEqual(got, afterSecond) {
+		t.Errorf("after replay = %+v, want %+v", got, afterSecond)
+	}
+	if info := controller.GetGameState().History; info.Redo != 0 {
+		t.Errorf("History.Redo = %d, want 0 after a new placement", info.Redo)
+	}
+}
+
+func TestGameController_UndoLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		drops    int
+		wantInfo HistoryInfo
+		wantLock int
+	}{
+		{name: "履歴なし", limit: 0, drops: 3, wantInfo: HistoryInfo{}, wantLock: 3},
+		{name: "上限まで戻す", limit: 2, drops: 3, wantInfo: HistoryInfo{Enabled: true, Redo: 2}, wantLock: 1},
+		{name: "全部戻す", limit: 5, drops: 3, wantInfo: HistoryInfo{Enabled: true, Redo: 3}, wantLock: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newHistoryController(t, tt.limit)
+			for i := 0; i < tt.drops; i++ {
+				handleInputs(t, controller, "drop")
+			}
+			for i := 0; i < tt.drops+1; i++ {
+				handleInputs(t, controller, "undo")
+			}
+
+			state := controller.GetGameState()
+			if state.History != tt.wantInfo {
+				t.Errorf("History = %+v, want %+v", state.History, tt.wantInfo)
+			}
+			if state.PiecesLocked != tt.wantLock {
+				t.Errorf("PiecesLocked = %d, want %d", state.PiecesLocked, tt.wantLock)
+			}
+		})
+	}
+}
+
+func TestGameController_UndoGameOver(t *testing.T) {
+	controller := newHistoryController(t, 100)
+	for i := 0; i < 100 && !controller.IsFinished(); i++ {
+		handleInputs(t, controller, "drop")
+	}
+	if !controller.IsFinished() || controller.GetGameState().Result == nil {
+		t.Fatal("game did not finish")
+	}
+
+	handleInputs(t, controller, "undo")
+
+	state := controller.GetGameState()
+	if state.GameOver || controller.IsFinished() || state.Result != nil {
+		t.Errorf("after undo: GameOver=%v finished=%v result=%v, want playing",
+			state.GameOver, controller.IsFinished(), state.Result)
+	}
+	handleInputs(t, controller, "left")
+}
+
+func TestGameController_UndoRestoresMode(t *testing.T) {
+	clock := newFakeClock()
+	controller, err := NewGameControllerWithConfig(GameConfig{
+		Mode:      NewSurvivalMode(),
+		Clock:     clock,
+		Seed:      3,
+		UndoLimit: 10,
+	})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	handleInputs(t, controller, "drop")
+	clock.Advance(SurvivalInitialInterval)
+	controller.dropTimer = clock.Now()
+	if err := controller.Update(); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if garbage := controller.GetGameState().Garbage; garbage != 1 {
+		t.Fatalf("Garbage = %d, want 1 after the first rise", garbage)
+	}
+
+	// 盤面はせり上がる前に戻るが、時間は戻らないので同じせり上がりがもう一度起きる
+	handleInputs(t, controller, "undo")
+	state := controller.GetGameState()
+	if state.Garbage != 1 {
+		t.Errorf("Garbage = %d, want 1 after undo", state.Garbage)
+	}
+	if want := SurvivalInitialInterval + SurvivalInitialInterval*SurvivalSpeedUpPercent/100; state.Mode.NextRise != want {
+		t.Errorf("Mode.NextRise = %v, want %v", state.Mode.NextRise, want)
+	}
+}
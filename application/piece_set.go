@@ -0,0 +1,100 @@
+package application
+
+import (
+	"fmt"
+	"tetris/domain/model"
+)
+
+// PieceSpec は設定ファイルで定義するピース。Shapeを指定すると回転状態を時計回りの回転から作り、
+// Rotationsを指定するとその回転状態をそのまま使う。形は'#'がブロック、'.'が空きマスの正方形。
+// Spawnは出現位置のずれ [x, y]、Kicksは回転時に試すずれの並び（省略するとキックなし）。
+// KickTableは回転前の状態ごとのずれの並びで、指定した状態ではKicksの代わりに使う
+type PieceSpec struct {
+	Name      string     `json:"name"`
+	Cell      string     `json:"cell,omitempty"`
+	Shape     []string   `json:"shape,omitempty"`
+	Rotations [][]string `json:"rotations,omitempty"`
+	Spawn     [2]int     `json:"spawn"`
+	Kicks     [][2]int   `json:"kicks,omitempty"`
+	KickTable [][][2]int `json:"kickTable,omitempty"`
+}
+
+// PieceSet はランダムに出現させるピースの組。Includeは"standard"などの組み込みのピースセット名
+type PieceSet struct {
+	Include []string    `json:"include,omitempty"`
+	Pieces  []PieceSpec `json:"pieces,omitempty"`
+}
+
+// Register はピースを登録し、出現させるピースの種類の一覧を返す
+func (s PieceSet) Register() ([]model.TetrominoType, error) {
+	var types []model.TetrominoType
+	for _, name := range s.Include {
+		set, err := model.ParsePieceSet(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, set...)
+	}
+
+	for _, spec := range s.Pieces {
+		definition, err := spec.definition()
+		if err != nil {
+			return nil, err
+		}
+		tetrominoType, err := model.RegisterPiece(definition)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, tetrominoType)
+	}
+
+	if len(types) == 0 {
+		return nil, fmt.Errorf("%w: ピースがありません", model.ErrInvalidPieceDefinition)
+	}
+	return types, nil
+}
+
+func (p PieceSpec) definition() (model.PieceDefinition, error) {
+	definition := model.PieceDefinition{
+		Name:  p.Name,
+		Spawn: model.Point{X: p.Spawn[0], Y: p.Spawn[1]},
+	}
+	if len(p.Cell) > 1 {
+		return definition, fmt.Errorf("%w: %s: 表記の文字%qは1文字にしてください", model.ErrInvalidPieceDefinition, p.Name, p.Cell)
+	}
+	if p.Cell != "" {
+		definition.Cell = p.Cell[0]
+	}
+	definition.Kicks = kickPoints(p.Kicks)
+	for _, kicks := range p.KickTable {
+		definition.KickTable = append(definition.KickTable, kickPoints(kicks))
+	}
+
+	switch {
+	case len(p.Shape) > 0 && len(p.Rotations) > 0:
+		return definition, fmt.Errorf("%w: %s: shapeとrotationsは同時に指定できません", model.ErrInvalidPieceDefinition, p.Name)
+	case len(p.Shape) > 0:
+		shape, err := model.ParseShape(p.Shape)
+		if err != nil {
+			return definition, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		definition.Rotations = model.RotationsOf(shape)
+	default:
+		for _, rows := range p.Rotations {
+			shape, err := model.ParseShape(rows)
+			if err != nil {
+				return definition, fmt.Errorf("%s: %w", p.Name, err)
+			}
+			definition.Rotations = append(definition.Rotations, shape)
+		}
+	}
+	return definition, nil
+}
+
+func kickPoints(kicks [][2]int) []model.Point {
+	var points []model.Point
+	for _, kick := range kicks {
+		points = append(points, model.Point{X: kick[0], Y: kick[1]})
+	}
+	return points
+}
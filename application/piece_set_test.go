@@ -0,0 +1,113 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"tetris/domain/model"
+)
+
+func TestPieceSet_Register(t *testing.T) {
+	tests := []struct {
+		name       string
+		set        PieceSet
+		wantCount  int
+		wantBlocks int
+		wantErr    bool
+	}{
+		{
+			name:      "組み込みのセットだけ",
+			set:       PieceSet{Include: []string{"standard", "pentomino"}},
+			wantCount: 19,
+		},
+		{
+			name: "形から回転状態を作る",
+			set: PieceSet{Pieces: []PieceSpec{
+				{Name: "AppPlus", Cell: "+", Shape: []string{".#.", "###", ".#."}},
+			}},
+			wantCount:  1,
+			wantBlocks: 5,
+		},
+		{
+			name: "回転状態と出現位置とキックを指定する",
+			set: PieceSet{Pieces: []PieceSpec{{
+				Name:      "AppDomino",
+				Rotations: [][]string{{"##", ".."}, {".#", ".#"}},
+				Spawn:     [2]int{1, 2},
+				Kicks:     [][2]int{{0, 0}, {0, -1}},
+			}}},
+			wantCount:  1,
+			wantBlocks: 2,
+		},
+		{
+			name: "回転前の状態ごとにキックを指定する",
+			set: PieceSet{Pieces: []PieceSpec{{
+				Name:      "AppKickDomino",
+				Rotations: [][]string{{"##", ".."}, {".#", ".#"}},
+				KickTable: [][][2]int{{{0, 0}, {-1, 0}}, {{0, 0}, {1, 0}}},
+			}}},
+			wantCount:  1,
+			wantBlocks: 2,
+		},
+		{
+			name: "キックの表が回転状態の数と違う",
+			set: PieceSet{Pieces: []PieceSpec{{
+				Name:      "AppBadKick",
+				Rotations: [][]string{{"##", ".."}, {".#", ".#"}},
+				KickTable: [][][2]int{{{0, 0}}},
+			}}},
+			wantErr: true,
+		},
+		{name: "空のセット", set: PieceSet{}, wantErr: true},
+		{name: "不明な組み込みセット", set: PieceSet{Include: []string{"tiny"}}, wantErr: true},
+		{
+			name:    "表記が2文字",
+			set:     PieceSet{Pieces: []PieceSpec{{Name: "AppLong", Cell: "ab", Shape: []string{"#"}}}},
+			wantErr: true,
+		},
+		{
+			name: "shapeとrotationsの両方",
+			set: PieceSet{Pieces: []PieceSpec{
+				{Name: "AppBoth", Shape: []string{"#"}, Rotations: [][]string{{"#"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "正方形でない形",
+			set:     PieceSet{Pieces: []PieceSpec{{Name: "AppWide", Shape: []string{"##"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "不明な文字",
+			set:     PieceSet{Pieces: []PieceSpec{{Name: "AppBad", Shape: []string{"#?", ".."}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types, err := tt.set.Register()
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidPieceDefinition) {
+					t.Errorf("Register() error = %v, want %v", err, model.ErrInvalidPieceDefinition)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			if len(types) != tt.wantCount {
+				t.Fatalf("Register() = %d pieces, want %d", len(types), tt.wantCount)
+			}
+			if tt.wantBlocks == 0 {
+				return
+			}
+			piece, err := model.NewTetromino(types[0], model.Point{})
+			if err != nil {
+				t.Fatalf("NewTetromino() error = %v", err)
+			}
+			if len(piece.GetBlocks()) != tt.wantBlocks {
+				t.Errorf("blocks = %d, want %d", len(piece.GetBlocks()), tt.wantBlocks)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"tetris/domain/model"
+	"tetris/domain/service"
+)
+
+const ModePuzzle = "puzzle"
+
+var ErrInvalidPuzzle = errors.New("無効なパズルです")
+
+const (
+	GoalLines        = "lines"
+	GoalPerfectClear = "perfect-clear"
+	GoalTetris       = "tetris"
+	GoalTSpinSingle  = "tspin-single"
+	GoalTSpinDouble  = "tspin-double"
+	GoalTSpinTriple  = "tspin-triple"
+)
+
+// goalClearTypes は特定の消し方を目標とするゴールと、その消し方の対応
+var goalClearTypes = map[string]service.ClearType{
+	GoalTetris:      service.ClearTetris,
+	GoalTSpinSingle: service.ClearTSpinSingle,
+	GoalTSpinDouble: service.ClearTSpinDouble,
+	GoalTSpinTriple: service.ClearTSpinTriple,
+}
+
+// PuzzleGoal はパズルの達成条件。Linesは種類がlinesのときに消すライン数
+type PuzzleGoal struct {
+	Type  string `json:"type"`
+	Lines int    `json:"lines,omitempty"`
+}
+
+func (g PuzzleGoal) String() string {
+	switch g.Type {
+	case GoalLines:
+		return fmt.Sprintf("%dライン消す", g.Lines)
+	case GoalPerfectClear:
+		return "パーフェクトクリア"
+	case GoalTetris:
+		return "テトリス"
+	case GoalTSpinSingle:
+		return "T-Spinシングル"
+	case GoalTSpinDouble:
+		return "T-Spinダブル"
+	case GoalTSpinTriple:
+		return "T-Spinトリプル"
+	default:
+		return g.Type
+	}
+}
+
+// Puzzle はパズルの定義。Boardは下揃えの行の並びで、'#'がブロック、'.'が空きマス。WidthとHeightはボードの大きさで、省略すると10x20。
+// Piecesはこの順番でだけ出現する。Holdを指定すると開始時にそのピースをホールドしており、AllowHoldまたはHoldでホールドが使える
+type Puzzle struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Width       int        `json:"width,omitempty"`
+	Height      int        `json:"height,omitempty"`
+	Board       []string   `json:"board"`
+	Pieces      []string   `json:"pieces"`
+	AllowHold   bool       `json:"allowHold,omitempty"`
+	Hold        string     `json:"hold,omitempty"`
+	Goal        PuzzleGoal `json:"goal"`
+}
+
+// GameOptions はパズルを検証し、ボードとピースの順番を設定したゲームオプションに変換する
+func (p Puzzle) GameOptions() (service.GameOptions, error) {
+	if p.Name == "" {
+		return service.GameOptions{}, fmt.Errorf("%w: 名前がありません", ErrInvalidPuzzle)
+	}
+	if err := p.Goal.validate(); err != nil {
+		return service.GameOptions{}, fmt.Errorf("%w: %s: %w", ErrInvalidPuzzle, p.Name, err)
+	}
+
+	board, err := p.board()
+	if err != nil {
+		return service.GameOptions{}, fmt.Errorf("%w: %s: %w", ErrInvalidPuzzle, p.Name, err)
+	}
+
+	if len(p.Pieces) == 0 {
+		return service.GameOptions{}, fmt.Errorf("%w: %s: ピースがありません", ErrInvalidPuzzle, p.Name)
+	}
+	sequence := make([]model.TetrominoType, len(p.Pieces))
+	for i, name := range p.Pieces {
+		if sequence[i], err = model.ParseTetrominoType(name); err != nil {
+			return service.GameOptions{}, fmt.Errorf("%w: %s: %w", ErrInvalidPuzzle, p.Name, err)
+		}
+	}
+
+	options := service.GameOptions{
+		Board:    board,
+		Sequence: sequence,
+		Hold:     p.AllowHold || p.Hold != "",
+	}
+	if p.Hold != "" {
+		hold, err := model.ParseTetrominoType(p.Hold)
+		if err != nil {
+			return service.GameOptions{}, fmt.Errorf("%w: %s: ホールド: %w", ErrInvalidPuzzle, p.Name, err)
+		}
+		options.InitialHold = &hold
+	}
+	return options, nil
+}
+
+func (g PuzzleGoal) validate() error {
+	switch {
+	case g.Type == GoalLines && g.Lines <= 0:
+		return fmt.Errorf("消すライン数が正ではありません: %d", g.Lines)
+	case g.Type == GoalLines, g.Type == GoalPerfectClear:
+		return nil
+	}
+	if _, exists := goalClearTypes[g.Type]; !exists {
+		return fmt.Errorf("不明なゴール %q", g.Type)
+	}
+	return nil
+}
+
+func (p Puzzle) board() (*model.Board, error) {
+	width, height := p.Width, p.Height
+	if width == 0 {
+		width = model.BoardWidth
+	}
+	if height == 0 {
+		height = model.BoardHeight
+	}
+	if err := model.ValidateBoardSize(width, height); err != nil {
+		return nil, err
+	}
+	board, err := model.NewBoard(width, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Board) > board.Height {
+		return nil, fmt.Errorf("ボードの行数%dが高さ%dを超えています", len(p.Board), board.Height)
+	}
+
+	top := board.Height - len(p.Board)
+	for i, row := range p.Board {
+		if len(row) != board.Width {
+			return nil, fmt.Errorf("%d行目の幅%dが%dではありません", i+1, len(row), board.Width)
+		}
+		for x, cell := range row {
+			switch cell {
+			case SnapshotFilledCell:
+				board.Grid[top+i][x] = true
+			case SnapshotEmptyCell:
+			default:
+				return nil, fmt.Errorf("%d行目に不明なセル %q があります", i+1, cell)
+			}
+		}
+	}
+	return board, nil
+}
+
+// PuzzleInfo は表示用のパズルの情報
+type PuzzleInfo struct {
+	Name        string
+	Description string
+	Goal        string
+	PiecesLeft  int
+}
+
+// PuzzleMode はパズルを解くモード。操作のたびにゴールを判定し、達成すればクリア、
+// ゲームオーバーになるかピースを使い切れば失敗になる。判定は盤面と直前の消去だけから行うため、取り消しにも追従する
+type PuzzleMode struct {
+	puzzle     Puzzle
+	options    service.GameOptions
+	piecesLeft int
+}
+
+func NewPuzzleMode(puzzle Puzzle) (*PuzzleMode, error) {
+	options, err := puzzle.GameOptions()
+	if err != nil {
+		return nil, err
+	}
+	mode := &PuzzleMode{puzzle: puzzle, options: options}
+	mode.piecesLeft = mode.PieceCount()
+	return mode, nil
+}
+
+func (m *PuzzleMode) Name() string {
+	return ModePuzzle
+}
+
+func (m *PuzzleMode) Puzzle() Puzzle {
+	return m.puzzle
+}
+
+// PieceCount はパズルで置けるピースの数。開始時にホールドしているピースも含む
+func (m *PuzzleMode) PieceCount() int {
+	if m.options.InitialHold != nil {
+		return len(m.options.Sequence) + 1
+	}
+	return len(m.options.Sequence)
+}
+
+func (m *PuzzleMode) Options() service.GameOptions {
+	return m.options
+}
+
+func (m *PuzzleMode) Start(_ *service.GameService) error {
+	m.piecesLeft = m.PieceCount()
+	return nil
+}
+
+func (m *PuzzleMode) Update(gameService *service.GameService, progress ModeProgress) (ModeStatus, error) {
+	m.piecesLeft = max(m.PieceCount()-gameService.GetPiecesLocked(), 0)
+
+	if m.goalReached(gameService, progress) {
+		return ModeCompleted, nil
+	}
+	if progress.GameOver || gameService.GetCurrentPiece() == nil {
+		return ModeFailed, nil
+	}
+	return ModePlaying, nil
+}
+
+func (m *PuzzleMode) goalReached(gameService *service.GameService, progress ModeProgress) bool {
+	if gameService.GetPiecesLocked() == 0 {
+		return false
+	}
+
+	clear := gameService.GetLastClear()
+	switch m.puzzle.Goal.Type {
+	case GoalLines:
+		return progress.Lines >= m.puzzle.Goal.Lines
+	case GoalPerfectClear:
+		return clear.Lines > 0 && gameService.GetBoard().IsEmpty()
+	default:
+		return clear.Type == goalClearTypes[m.puzzle.Goal.Type]
+	}
+}
+
+func (m *PuzzleMode) Info() ModeInfo {
+	return ModeInfo{
+		Name: ModePuzzle,
+		Puzzle: &PuzzleInfo{
+			Name:        m.puzzle.Name,
+			Description: m.puzzle.Description,
+			Goal:        m.puzzle.Goal.String(),
+			PiecesLeft:  m.piecesLeft,
+		},
+	}
+}
+
+func (m *PuzzleMode) Snapshot() ModeSnapshot {
+	return ModeSnapshot{piecesLeft: m.piecesLeft}
+}
+
+func (m *PuzzleMode) Restore(snapshot ModeSnapshot) {
+	m.piecesLeft = snapshot.piecesLeft
+}
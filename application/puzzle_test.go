@@ -0,0 +1,252 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"tetris/domain/model"
+)
+
+func TestPuzzle_GameOptions(t *testing.T) {
+	valid := Puzzle{
+		Name:   "テスト",
+		Board:  []string{"###....###"},
+		Pieces: []string{"I", "o"},
+		Hold:   "T",
+		Goal:   PuzzleGoal{Type: GoalLines, Lines: 1},
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(p *Puzzle)
+		wantErr bool
+	}{
+		{name: "正しいパズル", modify: func(_ *Puzzle) {}},
+		{name: "名前がない", modify: func(p *Puzzle) { p.Name = "" }, wantErr: true},
+		{name: "ピースがない", modify: func(p *Puzzle) { p.Pieces = nil }, wantErr: true},
+		{name: "不明なピース", modify: func(p *Puzzle) { p.Pieces = []string{"X"} }, wantErr: true},
+		{name: "不明なホールド", modify: func(p *Puzzle) { p.Hold = "X" }, wantErr: true},
+		{name: "不明なゴール", modify: func(p *Puzzle) { p.Goal.Type = "win" }, wantErr: true},
+		{name: "消すライン数が0", modify: func(p *Puzzle) { p.Goal.Lines = 0 }, wantErr: true},
+		{name: "行の幅が違う", modify: func(p *Puzzle) { p.Board = []string{"###"} }, wantErr: true},
+		{name: "不明なセル", modify: func(p *Puzzle) { p.Board = []string{"###xxxx###"} }, wantErr: true},
+		{
+			name:   "4列のボード",
+			modify: func(p *Puzzle) { p.Width, p.Height, p.Board = 4, 8, []string{"#..#"} },
+		},
+		{name: "4列のボードで行の幅が違う", modify: func(p *Puzzle) { p.Width = 4 }, wantErr: true},
+		{name: "広すぎるボード", modify: func(p *Puzzle) { p.Width = 30 }, wantErr: true},
+		{
+			name:    "行数が高さを超える",
+			modify:  func(p *Puzzle) { p.Board = strings.Split(strings.Repeat("..........,", 21), ",")[:21] },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			puzzle := valid
+			tt.modify(&puzzle)
+
+			options, err := puzzle.GameOptions()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPuzzle) {
+					t.Errorf("GameOptions() error = %v, want %v", err, ErrInvalidPuzzle)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GameOptions() error = %v", err)
+			}
+
+			if len(options.Sequence) != 2 || !options.Hold || options.InitialHold == nil {
+				t.Errorf("GameOptions() = %+v, want 2 pieces with hold", options)
+			}
+			bottom := options.Board.Grid[options.Board.Height-1]
+			if !bottom[0] || bottom[len(bottom)/2-1] || !bottom[len(bottom)-1] {
+				t.Errorf("bottom row = %v, want %s", bottom, puzzle.Board[0])
+			}
+		})
+	}
+}
+
+func TestPuzzleMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		puzzle Puzzle
+		inputs []string
+		want   ModeStatus
+	}{
+		{
+			name: "ライン数を達成",
+			puzzle: Puzzle{
+				Board:  []string{"###....###", "###....###"},
+				Pieces: []string{"I", "I"},
+				Goal:   PuzzleGoal{Type: GoalLines, Lines: 2},
+			},
+			inputs: []string{"drop", "drop"},
+			want:   ModeCompleted,
+		},
+		{
+			name: "ライン数の途中",
+			puzzle: Puzzle{
+				Board:  []string{"###....###", "###....###"},
+				Pieces: []string{"I", "I"},
+				Goal:   PuzzleGoal{Type: GoalLines, Lines: 2},
+			},
+			inputs: []string{"drop"},
+			want:   ModePlaying,
+		},
+		{
+			name: "パーフェクトクリア",
+			puzzle: Puzzle{
+				Board:  []string{"###....###"},
+				Pieces: []string{"I"},
+				Goal:   PuzzleGoal{Type: GoalPerfectClear},
+			},
+			inputs: []string{"drop"},
+			want:   ModeCompleted,
+		},
+		{
+			name: "消し方が違う",
+			puzzle: Puzzle{
+				Board:  []string{"###....###"},
+				Pieces: []string{"I"},
+				Goal:   PuzzleGoal{Type: GoalTetris},
+			},
+			inputs: []string{"drop"},
+			want:   ModeFailed,
+		},
+		{
+			name: "ホールドしていたピースで達成",
+			puzzle: Puzzle{
+				Board:  []string{"###....###"},
+				Pieces: []string{"O"},
+				Hold:   "I",
+				Goal:   PuzzleGoal{Type: GoalLines, Lines: 1},
+			},
+			inputs: []string{"hold", "drop"},
+			want:   ModeCompleted,
+		},
+		{
+			name: "順番を使い切った後にホールドしていたピースで達成",
+			puzzle: Puzzle{
+				Board:  []string{".#########", ".#########", ".#########", ".#########"},
+				Pieces: []string{"O"},
+				Hold:   "I",
+				Goal:   PuzzleGoal{Type: GoalTetris},
+			},
+			inputs: []string{"drop", "rotate", "left", "left", "left", "left", "left", "left", "drop"},
+			want:   ModeCompleted,
+		},
+		{
+			name: "ホールドが使えない",
+			puzzle: Puzzle{
+				Board:  []string{"###....###"},
+				Pieces: []string{"O", "I"},
+				Goal:   PuzzleGoal{Type: GoalLines, Lines: 1},
+			},
+			inputs: []string{"hold", "drop", "drop"},
+			want:   ModeFailed,
+		},
+		{
+			name: "出現できない",
+			puzzle: Puzzle{
+				Board:  strings.Split(strings.Repeat("#########.,", 20), ",")[:20],
+				Pieces: []string{"O"},
+				Goal:   PuzzleGoal{Type: GoalLines, Lines: 1},
+			},
+			want: ModeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.puzzle.Name = tt.name
+			mode, err := NewPuzzleMode(tt.puzzle)
+			if err != nil {
+				t.Fatalf("NewPuzzleMode() error = %v", err)
+			}
+			controller, err := NewGameControllerWithConfig(GameConfig{Mode: mode, Clock: newFakeClock()})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+			handleInputs(t, controller, tt.inputs...)
+
+			state := controller.GetGameState()
+			status := ModePlaying
+			if state.Result != nil {
+				status = state.Result.Status
+			}
+			if status != tt.want {
+				t.Errorf("status = %v, want %v", status, tt.want)
+			}
+			if state.Mode.Puzzle == nil || state.Mode.Puzzle.Name != tt.name {
+				t.Errorf("Mode.Puzzle = %+v, want puzzle info", state.Mode.Puzzle)
+			}
+		})
+	}
+}
+
+func TestPuzzleMode_PiecesLeftCountsHold(t *testing.T) {
+	mode, err := NewPuzzleMode(Puzzle{
+		Name:   "ホールドを含む残りピース",
+		Board:  []string{".#########"},
+		Pieces: []string{"O"},
+		Hold:   "I",
+		Goal:   PuzzleGoal{Type: GoalTetris},
+	})
+	if err != nil {
+		t.Fatalf("NewPuzzleMode() error = %v", err)
+	}
+	controller, err := NewGameControllerWithConfig(GameConfig{Mode: mode, Clock: newFakeClock()})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	if left := controller.GetGameState().Mode.Puzzle.PiecesLeft; left != 2 {
+		t.Errorf("PiecesLeft = %d, want 2 with a held piece", left)
+	}
+	handleInputs(t, controller, "drop")
+	state := controller.GetGameState()
+	if state.Mode.Puzzle.PiecesLeft != 1 || state.CurrentPiece == nil || state.CurrentPiece.Type != model.I {
+		t.Errorf("PiecesLeft = %d, CurrentPiece = %+v, want the held I to come out", state.Mode.Puzzle.PiecesLeft,
+			state.CurrentPiece)
+	}
+	if state.HoldPiece != nil {
+		t.Errorf("HoldPiece = %+v, want nil after it came out", state.HoldPiece)
+	}
+}
+
+func TestPuzzleMode_UndoAfterFailure(t *testing.T) {
+	mode, err := NewPuzzleMode(Puzzle{
+		Name:   "取り消し",
+		Board:  []string{"###....###"},
+		Pieces: []string{"O", "I"},
+		Goal:   PuzzleGoal{Type: GoalLines, Lines: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewPuzzleMode() error = %v", err)
+	}
+	controller, err := NewGameControllerWithConfig(GameConfig{Mode: mode, Clock: newFakeClock(), UndoLimit: 2})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	handleInputs(t, controller, "drop", "drop")
+	if state := controller.GetGameState(); state.Result == nil || state.Result.Status != ModeFailed {
+		t.Fatalf("Result = %+v, want failed after using all pieces", state.Result)
+	}
+
+	handleInputs(t, controller, "undo", "undo")
+	state := controller.GetGameState()
+	if state.Result != nil || state.Mode.Puzzle.PiecesLeft != 2 {
+		t.Fatalf("after undo Result = %+v, PiecesLeft = %d, want playing with 2 pieces",
+			state.Result, state.Mode.Puzzle.PiecesLeft)
+	}
+
+	handleInputs(t, controller, "right", "right", "right", "right", "drop", "drop")
+	if state = controller.GetGameState(); state.Result == nil || state.Result.Status != ModeCompleted {
+		t.Errorf("Result = %+v, want completed", state.Result)
+	}
+}
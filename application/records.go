@@ -0,0 +1,113 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"tetris/domain/model"
+	"time"
+)
+
+const MaxRecordsPerMode = 10
+
+type TimeRecord struct {
+	Time   time.Duration   `json:"time"`
+	Splits []time.Duration `json:"splits,omitempty"`
+	Date   time.Time       `json:"date"`
+}
+
+type ScoreRecord struct {
+	Score int       `json:"score"`
+	Lines int       `json:"lines"`
+	Level int       `json:"level"`
+	Date  time.Time `json:"date"`
+}
+
+type Records struct {
+	BestTimes  map[string][]TimeRecord  `json:"bestTimes"`
+	HighScores map[string][]ScoreRecord `json:"highScores"`
+}
+
+// RecordMode は記録を分ける区分。標準以外の大きさのボードでは"sprint@4x20"のように大きさを付け、標準の記録と混ぜない
+func RecordMode(mode string, width, height int) string {
+	if width == model.BoardWidth && height == model.BoardHeight {
+		return mode
+	}
+	return fmt.Sprintf("%s@%dx%d", mode, width, height)
+}
+
+type RecordRepository interface {
+	Load() (Records, error)
+	Save(records Records) error
+}
+
+func (r *Records) Add(result ModeResult, date time.Time) bool {
+	if result.RankByTime {
+		if result.Status != ModeCompleted {
+			return false
+		}
+		return r.addBestTime(result.Mode, TimeRecord{Time: result.Elapsed, Splits: result.Splits, Date: date})
+	}
+
+	return r.addHighScore(result.Mode, ScoreRecord{
+		Score: result.Score,
+		Lines: result.Lines,
+		Level: result.Level,
+		Date:  date,
+	})
+}
+
+func (r *Records) BestTime(mode string) (time.Duration, bool) {
+	times := r.BestTimes[mode]
+	if len(times) == 0 {
+		return 0, false
+	}
+	return times[0].Time, true
+}
+
+func (r *Records) BestScore(mode string) (int, bool) {
+	scores := r.HighScores[mode]
+	if len(scores) == 0 {
+		return 0, false
+	}
+	return scores[0].Score, true
+}
+
+func (r *Records) addBestTime(mode string, record TimeRecord) bool {
+	if r.BestTimes == nil {
+		r.BestTimes = make(map[string][]TimeRecord)
+	}
+
+	best, exists := r.BestTime(mode)
+	isBest := !exists || record.Time < best
+
+	times := append(r.BestTimes[mode], record)
+	sort.SliceStable(times, func(i, j int) bool {
+		return times[i].Time < times[j].Time
+	})
+	if len(times) > MaxRecordsPerMode {
+		times = times[:MaxRecordsPerMode]
+	}
+	r.BestTimes[mode] = times
+
+	return isBest
+}
+
+func (r *Records) addHighScore(mode string, record ScoreRecord) bool {
+	if r.HighScores == nil {
+		r.HighScores = make(map[string][]ScoreRecord)
+	}
+
+	best, exists := r.BestScore(mode)
+	isBest := !exists || record.Score > best
+
+	scores := append(r.HighScores[mode], record)
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+	if len(scores) > MaxRecordsPerMode {
+		scores = scores[:MaxRecordsPerMode]
+	}
+	r.HighScores[mode] = scores
+
+	return isBest
+}
@@ -0,0 +1,238 @@
+package rl
+
+import (
+	"errors"
+	"fmt"
+	"tetris/domain/model"
+	"tetris/domain/service"
+)
+
+var (
+	ErrNotReset      = errors.New("resetが呼ばれていません")
+	ErrEpisodeDone   = errors.New("エピソードは終了しています")
+	ErrInvalidAction = errors.New("無効な行動です")
+)
+
+const DefaultMaxSteps = 10000
+
+type Action string
+
+const (
+	ActionNoop   Action = "noop"
+	ActionLeft   Action = "left"
+	ActionRight  Action = "right"
+	ActionDown   Action = "down"
+	ActionRotate Action = "rotate"
+	ActionDrop   Action = "drop"
+	ActionHold   Action = "hold"
+)
+
+// Actions は行動空間。外部の学習コードは添字で行動を指定できる
+var Actions = []Action{ActionNoop, ActionLeft, ActionRight, ActionDown, ActionRotate, ActionDrop, ActionHold}
+
+func ActionAt(index int) (Action, error) {
+	if index < 0 || index >= len(Actions) {
+		return "", fmt.Errorf("%w: 添字=%d", ErrInvalidAction, index)
+	}
+	return Actions[index], nil
+}
+
+type Config struct {
+	// MaxSteps を超えたエピソードは打ち切り（Truncated）として終了する。0なら既定値
+	MaxSteps int
+	// GravitySteps ごとにピースが1段自然落下する。0なら自然落下しない
+	GravitySteps int
+	StartLevel   int
+	// Width と Height はボードの大きさ。0なら標準の10x20
+	Width  int
+	Height int
+	// Hold はホールドの行動を有効にする。無効なときのホールドは何もしない行動として扱う
+	Hold bool
+	// Preview は観測に含めるネクストの数。0なら1つ
+	Preview int
+}
+
+type Piece struct {
+	Type     model.TetrominoType `json:"type"`
+	X        int                 `json:"x"`
+	Y        int                 `json:"y"`
+	Rotation int                 `json:"rotation"`
+	Blocks   []model.Point       `json:"blocks"`
+}
+
+// Observation はボードのビットマップ（1が埋まったセル、現在のピースは含まない）とピースの情報
+type Observation struct {
+	Width   int                   `json:"width"`
+	Height  int                   `json:"height"`
+	Board   [][]int               `json:"board"`
+	Current *Piece                `json:"current"`
+	Queue   []model.TetrominoType `json:"queue"`
+	Hold    *model.TetrominoType  `json:"hold"`
+}
+
+type Info struct {
+	Score        int  `json:"score"`
+	Lines        int  `json:"lines"`
+	Level        int  `json:"level"`
+	LinesCleared int  `json:"linesCleared"`
+	PiecesLocked int  `json:"piecesLocked"`
+	Steps        int  `json:"steps"`
+	TSpin        bool `json:"tSpin"`
+	Truncated    bool `json:"truncated"`
+}
+
+type StepResult struct {
+	Observation Observation `json:"observation"`
+	Reward      float64     `json:"reward"`
+	Done        bool        `json:"done"`
+	Info        Info        `json:"info"`
+}
+
+// Environment はGameServiceを描画なしで1行動ずつ進める強化学習用の環境。
+// 報酬はその行動で増えたスコアで、ゲームオーバーまたは最大ステップ数でエピソードが終わる
+type Environment struct {
+	config Config
+	game   *service.GameService
+	steps  int
+	done   bool
+}
+
+func NewEnvironment(config Config) *Environment {
+	if config.MaxSteps <= 0 {
+		config.MaxSteps = DefaultMaxSteps
+	}
+	return &Environment{config: config}
+}
+
+func (e *Environment) Reset(seed uint64) (Observation, error) {
+	game, err := service.NewGameServiceWithOptions(service.GameOptions{
+		StartLevel: e.config.StartLevel,
+		Seed:       seed,
+		Width:      e.config.Width,
+		Height:     e.config.Height,
+		Hold:       e.config.Hold,
+		Preview:    e.config.Preview,
+	})
+	if err != nil {
+		return Observation{}, fmt.Errorf("環境初期化エラー: %w", err)
+	}
+
+	e.game = game
+	e.steps = 0
+	e.done = false
+	return e.observe(), nil
+}
+
+func (e *Environment) Step(action Action) (StepResult, error) {
+	if e.game == nil {
+		return StepResult{}, ErrNotReset
+	}
+	if e.done {
+		return StepResult{}, ErrEpisodeDone
+	}
+
+	scoreBefore := e.game.GetScore()
+	linesBefore := e.game.GetLines()
+	lockedBefore := e.game.GetPiecesLocked()
+
+	if err := e.apply(action); err != nil {
+		return StepResult{}, err
+	}
+	e.steps++
+
+	if e.config.GravitySteps > 0 && e.steps%e.config.GravitySteps == 0 && !e.game.IsGameOver() {
+		if err := e.game.Update(); err != nil && !errors.Is(err, service.ErrGameOver) {
+			return StepResult{}, fmt.Errorf("自然落下エラー: %w", err)
+		}
+	}
+
+	info := Info{
+		Score:        e.game.GetScore(),
+		Lines:        e.game.GetLines(),
+		Level:        e.game.GetLevel(),
+		LinesCleared: e.game.GetLines() - linesBefore,
+		PiecesLocked: e.game.GetPiecesLocked(),
+		Steps:        e.steps,
+	}
+	if info.PiecesLocked != lockedBefore {
+		info.TSpin = e.game.GetLastClear().TSpin
+	}
+
+	gameOver := e.game.IsGameOver()
+	info.Truncated = !gameOver && e.steps >= e.config.MaxSteps
+	e.done = gameOver || info.Truncated
+
+	return StepResult{
+		Observation: e.observe(),
+		Reward:      float64(info.Score - scoreBefore),
+		Done:        e.done,
+		Info:        info,
+	}, nil
+}
+
+// apply は行動を適用する。壁や他のブロックで動けない移動・回転は何もしない行動として扱う
+func (e *Environment) apply(action Action) error {
+	var err error
+	switch action {
+	case ActionNoop:
+		return nil
+	case ActionLeft:
+		err = e.game.MovePiece(model.Point{X: -1})
+	case ActionRight:
+		err = e.game.MovePiece(model.Point{X: 1})
+	case ActionDown:
+		err = e.game.MovePiece(model.Point{Y: 1})
+	case ActionRotate:
+		err = e.game.RotatePiece()
+	case ActionDrop:
+		err = e.game.DropPiece()
+	case ActionHold:
+		err = e.game.HoldPiece()
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidAction, action)
+	}
+
+	if err != nil && !errors.Is(err, service.ErrInvalidMove) && !errors.Is(err, service.ErrGameOver) &&
+		!errors.Is(err, service.ErrHoldUnavailable) {
+		return fmt.Errorf("行動適用エラー: %w", err)
+	}
+	return nil
+}
+
+func (e *Environment) observe() Observation {
+	board := e.game.GetBoard()
+	observation := Observation{
+		Width:  board.Width,
+		Height: board.Height,
+		Board:  make([][]int, board.Height),
+		Queue:  e.game.GetQueue(),
+	}
+
+	for y, row := range board.Grid {
+		cells := make([]int, len(row))
+		for x, filled := range row {
+			if filled {
+				cells[x] = 1
+			}
+		}
+		observation.Board[y] = cells
+	}
+
+	if !e.game.IsGameOver() {
+		if current := e.game.GetCurrentPiece(); current != nil {
+			observation.Current = &Piece{
+				Type:     current.Type,
+				X:        current.Position.X,
+				Y:        current.Position.Y,
+				Rotation: current.Rotation(),
+				Blocks:   current.GetBlocks(),
+			}
+		}
+	}
+	if hold := e.game.GetHoldPiece(); hold != nil {
+		holdType := hold.Type
+		observation.Hold = &holdType
+	}
+
+	return observation
+}
@@ -0,0 +1,307 @@
+package rl
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"tetris/domain/model"
+)
+
+func resetEnvironment(t testing.TB, config Config, seed uint64) (*Environment, Observation) {
+	t.Helper()
+
+	env := NewEnvironment(config)
+	observation, err := env.Reset(seed)
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	return env, observation
+}
+
+func TestActionAt(t *testing.T) {
+	tests := []struct {
+		index    int
+		expected Action
+		wantErr  bool
+	}{
+		{index: 0, expected: ActionNoop},
+		{index: 5, expected: ActionDrop},
+		{index: 6, expected: ActionHold},
+		{index: -1, wantErr: true},
+		{index: len(Actions), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		action, err := ActionAt(tt.index)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAction) {
+				t.Errorf("ActionAt(%d) error = %v, want %v", tt.index, err, ErrInvalidAction)
+			}
+			continue
+		}
+		if err != nil || action != tt.expected {
+			t.Errorf("ActionAt(%d) = %q, %v, want %q", tt.index, action, err, tt.expected)
+		}
+	}
+}
+
+func TestEnvironment_Reset(t *testing.T) {
+	env, observation := resetEnvironment(t, Config{}, 7)
+
+	if observation.Width != model.BoardWidth || observation.Height != model.BoardHeight || len(observation.Board) != model.BoardHeight {
+		t.Errorf("observation size = %dx%d (%d rows)", observation.Width, observation.Height, len(observation.Board))
+	}
+	if observation.Current == nil || len(observation.Current.Blocks) != 4 {
+		t.Errorf("Current = %+v, want spawned piece", observation.Current)
+	}
+	if len(observation.Queue) != 1 || observation.Hold != nil {
+		t.Errorf("Queue = %v, Hold = %v", observation.Queue, observation.Hold)
+	}
+
+	if _, err := env.Step(ActionDrop); err != nil {
+		t.Fatalf("Step() error = %v", err)
+	}
+	again, err := env.Reset(7)
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if !reflect.DeepEqual(again, observation) {
+		t.Error("Reset() with the same seed should return the same observation")
+	}
+}
+
+func TestEnvironment_BoardSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+	}{
+		{name: "4列", width: 4, height: 20},
+		{name: "20列", width: 20, height: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, observation := resetEnvironment(t, Config{Width: tt.width, Height: tt.height}, 7)
+			if observation.Width != tt.width || observation.Height != tt.height || len(observation.Board[0]) != tt.width {
+				t.Fatalf("observation size = %dx%d (%d columns)", observation.Width, observation.Height, len(observation.Board[0]))
+			}
+			for _, block := range observation.Current.Blocks {
+				if block.X < 0 || block.X >= tt.width {
+					t.Errorf("Current block %v outside of width %d", block, tt.width)
+				}
+			}
+			if _, err := env.Step(ActionDrop); err != nil {
+				t.Fatalf("Step() error = %v", err)
+			}
+		})
+	}
+
+	env := NewEnvironment(Config{Width: model.MaxBoardWidth + 1})
+	if _, err := env.Reset(1); err == nil {
+		t.Error("Reset() with too wide board error = nil")
+	}
+}
+
+func TestEnvironment_StepErrors(t *testing.T) {
+	if _, err := NewEnvironment(Config{}).Step(ActionLeft); !errors.Is(err, ErrNotReset) {
+		t.Errorf("Step() before Reset error = %v, want %v", err, ErrNotReset)
+	}
+
+	env, _ := resetEnvironment(t, Config{}, 1)
+	if _, err := env.Step("jump"); !errors.Is(err, ErrInvalidAction) {
+		t.Errorf("Step(jump) error = %v, want %v", err, ErrInvalidAction)
+	}
+}
+
+func TestEnvironment_StepActions(t *testing.T) {
+	tests := []struct {
+		name   string
+		action Action
+		check  func(before, after Observation, info Info) bool
+	}{
+		{
+			name:   "左移動",
+			action: ActionLeft,
+			check: func(before, after Observation, _ Info) bool {
+				return after.Current.X == before.Current.X-1
+			},
+		},
+		{
+			name:   "下移動",
+			action: ActionDown,
+			check: func(before, after Observation, _ Info) bool {
+				return after.Current.Y == before.Current.Y+1
+			},
+		},
+		{
+			name:   "何もしない",
+			action: ActionNoop,
+			check: func(before, after Observation, _ Info) bool {
+				return reflect.DeepEqual(before, after)
+			},
+		},
+		{
+			name:   "一気に落下して固定",
+			action: ActionDrop,
+			check: func(_, after Observation, info Info) bool {
+				filled := 0
+				for _, row := range after.Board {
+					for _, cell := range row {
+						filled += cell
+					}
+				}
+				return info.PiecesLocked == 1 && filled == 4
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, before := resetEnvironment(t, Config{}, 3)
+			result, err := env.Step(tt.action)
+			if err != nil {
+				t.Fatalf("Step() error = %v", err)
+			}
+			if !tt.check(before, result.Observation, result.Info) {
+				t.Errorf("unexpected result: before = %+v, after = %+v, info = %+v", before.Current, result.Observation.Current, result.Info)
+			}
+			if result.Done || result.Info.Steps != 1 {
+				t.Errorf("Done = %v, Steps = %d", result.Done, result.Info.Steps)
+			}
+		})
+	}
+}
+
+func TestEnvironment_Hold(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		wantHold bool
+	}{
+		{name: "ホールド有効", config: Config{Hold: true, Preview: 3}, wantHold: true},
+		{name: "ホールド無効なら何もしない", config: Config{Preview: 3}, wantHold: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, before := resetEnvironment(t, tt.config, 3)
+			if len(before.Queue) != 3 {
+				t.Fatalf("Queue = %v, want 3 pieces", before.Queue)
+			}
+
+			result, err := env.Step(ActionHold)
+			if err != nil {
+				t.Fatalf("Step(hold) error = %v", err)
+			}
+			after := result.Observation
+			if !tt.wantHold {
+				if !reflect.DeepEqual(after, before) {
+					t.Errorf("observation changed: before = %+v, after = %+v", before, after)
+				}
+				return
+			}
+
+			if after.Hold == nil || *after.Hold != before.Current.Type {
+				t.Errorf("Hold = %v, want %d", after.Hold, before.Current.Type)
+			}
+			if after.Current.Type != before.Queue[0] {
+				t.Errorf("Current.Type = %d, want %d", after.Current.Type, before.Queue[0])
+			}
+			if len(after.Queue) != 3 || !reflect.DeepEqual(after.Queue[:2], before.Queue[1:]) {
+				t.Errorf("Queue = %v, want %v followed by a new piece", after.Queue, before.Queue[1:])
+			}
+		})
+	}
+}
+
+func TestEnvironment_RewardForLineClear(t *testing.T) {
+	env, _ := resetEnvironment(t, Config{}, 1)
+
+	piece, _ := model.NewTetromino(model.I, model.Point{X: model.BoardWidth/2 - 2, Y: 0})
+	*env.game.GetCurrentPiece() = *piece
+	board := env.game.GetBoard()
+	for x := 0; x < board.Width; x++ {
+		if x < 3 || x > 6 {
+			_ = board.SetBlock(model.Point{X: x, Y: board.Height - 1}, true)
+		}
+	}
+
+	result, err := env.Step(ActionDrop)
+	if err != nil {
+		t.Fatalf("Step() error = %v", err)
+	}
+	if result.Reward != 100 || result.Info.LinesCleared != 1 || result.Info.Lines != 1 {
+		t.Errorf("Reward = %v, info = %+v, want 100 for a single", result.Reward, result.Info)
+	}
+}
+
+func TestEnvironment_EpisodeEnds(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    Config
+		action    Action
+		truncated bool
+	}{
+		{name: "最大ステップで打ち切り", config: Config{MaxSteps: 3}, action: ActionNoop, truncated: true},
+		{name: "積み上がってゲームオーバー", config: Config{}, action: ActionDrop, truncated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, _ := resetEnvironment(t, tt.config, 5)
+
+			var result StepResult
+			for steps := 0; !result.Done; steps++ {
+				if steps > 1000 {
+					t.Fatal("episode did not end")
+				}
+				var err error
+				if result, err = env.Step(tt.action); err != nil {
+					t.Fatalf("Step() error = %v", err)
+				}
+			}
+
+			if result.Info.Truncated != tt.truncated {
+				t.Errorf("Truncated = %v, want %v", result.Info.Truncated, tt.truncated)
+			}
+			if !tt.truncated && result.Observation.Current != nil {
+				t.Error("Current should be nil after game over")
+			}
+			if _, err := env.Step(tt.action); !errors.Is(err, ErrEpisodeDone) {
+				t.Errorf("Step() after done error = %v, want %v", err, ErrEpisodeDone)
+			}
+		})
+	}
+}
+
+func TestEnvironment_Gravity(t *testing.T) {
+	env, before := resetEnvironment(t, Config{GravitySteps: 2}, 1)
+
+	first, _ := env.Step(ActionNoop)
+	second, _ := env.Step(ActionNoop)
+
+	if first.Observation.Current.Y != before.Current.Y {
+		t.Errorf("piece fell before GravitySteps: y = %d", first.Observation.Current.Y)
+	}
+	if second.Observation.Current.Y != before.Current.Y+1 {
+		t.Errorf("piece y = %d, want %d", second.Observation.Current.Y, before.Current.Y+1)
+	}
+}
+
+func BenchmarkEnvironment_Step(b *testing.B) {
+	env, _ := resetEnvironment(b, Config{}, 1)
+	actions := []Action{ActionLeft, ActionRotate, ActionRight, ActionDown, ActionDrop}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := env.Step(actions[i%len(actions)])
+		if err != nil {
+			b.Fatalf("Step() error = %v", err)
+		}
+		if result.Done {
+			_, _ = env.Reset(uint64(i))
+		}
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "steps/s")
+}
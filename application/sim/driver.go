@@ -0,0 +1,113 @@
+package sim
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"tetris/application"
+	"tetris/application/ai"
+)
+
+var (
+	ErrScriptEnded   = errors.New("入力スクリプトが終わりました")
+	ErrInvalidScript = errors.New("無効な入力スクリプトです")
+)
+
+var scriptCommands = map[string]bool{
+	"left":   true,
+	"right":  true,
+	"down":   true,
+	"rotate": true,
+	"drop":   true,
+}
+
+// Driver はシミュレーション中のゲームに入力を与える。Inputsは現在のピースを固定するまでの入力をまとめて返す
+type Driver interface {
+	Inputs(state application.GameState) ([]string, error)
+}
+
+// PlannerDriver はAIのプランナーが選んだ配置の手順を入力する
+type PlannerDriver struct {
+	planner ai.Planner
+}
+
+func NewPlannerDriver(planner ai.Planner) *PlannerDriver {
+	return &PlannerDriver{planner: planner}
+}
+
+func (d *PlannerDriver) Inputs(state application.GameState) ([]string, error) {
+	placement, err := d.planner.Plan(state)
+	if err != nil {
+		return nil, err
+	}
+	return placement.Moves, nil
+}
+
+// ScriptDriver は入力スクリプトのコマンドを先頭から順に、dropごとに区切って入力する
+type ScriptDriver struct {
+	commands []string
+	next     int
+}
+
+func NewScriptDriver(commands []string) *ScriptDriver {
+	return &ScriptDriver{commands: commands}
+}
+
+func (d *ScriptDriver) Inputs(application.GameState) ([]string, error) {
+	if d.next >= len(d.commands) {
+		return nil, ErrScriptEnded
+	}
+
+	start := d.next
+	for d.next < len(d.commands) {
+		command := d.commands[d.next]
+		d.next++
+		if command == "drop" {
+			break
+		}
+	}
+	return d.commands[start:d.next], nil
+}
+
+// ParseScript は1行1コマンドの入力スクリプトを読む。"left 3" のように回数を付けると繰り返し、#以降はコメントになる
+func ParseScript(reader io.Reader) ([]string, error) {
+	var commands []string
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if index := strings.IndexByte(line, '#'); index >= 0 {
+			line = line[:index]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 2 || !scriptCommands[fields[0]] {
+			return nil, fmt.Errorf("%w: %d行目 %q", ErrInvalidScript, lineNumber, scanner.Text())
+		}
+
+		count := 1
+		if len(fields) == 2 {
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: %d行目の回数 %q", ErrInvalidScript, lineNumber, fields[1])
+			}
+			count = n
+		}
+		for i := 0; i < count; i++ {
+			commands = append(commands, fields[0])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("入力スクリプト読み込みエラー: %w", err)
+	}
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("%w: コマンドがありません", ErrInvalidScript)
+	}
+	return commands, nil
+}
@@ -0,0 +1,70 @@
+package sim
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"tetris/application"
+)
+
+func TestParseScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "コメントと空行を無視する",
+			script:   "# 左端に置く\nleft\n\nrotate  # 縦にする\ndrop\n",
+			expected: []string{"left", "rotate", "drop"},
+		},
+		{
+			name:     "回数指定で繰り返す",
+			script:   "right 3\ndrop",
+			expected: []string{"right", "right", "right", "drop"},
+		},
+		{name: "不明なコマンド", script: "hold\n", wantErr: true},
+		{name: "不正な回数", script: "left 0\n", wantErr: true},
+		{name: "余分な引数", script: "left 1 2\n", wantErr: true},
+		{name: "コマンドなし", script: "# empty\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := ParseScript(strings.NewReader(tt.script))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidScript) {
+					t.Errorf("ParseScript() error = %v, want %v", err, ErrInvalidScript)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseScript() error = %v", err)
+			}
+			if !reflect.DeepEqual(commands, tt.expected) {
+				t.Errorf("ParseScript() = %v, want %v", commands, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScriptDriver_SplitsAtDrop(t *testing.T) {
+	driver := NewScriptDriver([]string{"left", "drop", "drop", "rotate", "right"})
+
+	expected := [][]string{{"left", "drop"}, {"drop"}, {"rotate", "right"}}
+	for i, want := range expected {
+		inputs, err := driver.Inputs(application.GameState{})
+		if err != nil {
+			t.Fatalf("Inputs() #%d error = %v", i, err)
+		}
+		if !reflect.DeepEqual(inputs, want) {
+			t.Errorf("Inputs() #%d = %v, want %v", i, inputs, want)
+		}
+	}
+
+	if _, err := driver.Inputs(application.GameState{}); !errors.Is(err, ErrScriptEnded) {
+		t.Errorf("Inputs() error = %v, want %v", err, ErrScriptEnded)
+	}
+}
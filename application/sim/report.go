@@ -0,0 +1,81 @@
+package sim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"tetris/domain/model"
+)
+
+// WriteJSON は集計とゲームごとの結果をJSONで書き出す
+func (r Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("JSON出力エラー: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV はゲームごとの結果を1行ずつCSVで書き出す。表計算ソフトでの比較用に集計は含めない
+func (r Report) WriteCSV(writer io.Writer) error {
+	output := csv.NewWriter(writer)
+
+	names := r.pieceNames()
+	header := []string{"game", "seed", "pieces", "lines", "score", "level", "end"}
+	header = append(header, names...)
+	if err := output.Write(header); err != nil {
+		return fmt.Errorf("CSV出力エラー: %w", err)
+	}
+
+	for _, result := range r.Results {
+		record := []string{
+			strconv.Itoa(result.Game),
+			strconv.FormatUint(result.Seed, 10),
+			strconv.Itoa(result.Pieces),
+			strconv.Itoa(result.Lines),
+			strconv.Itoa(result.Score),
+			strconv.Itoa(result.Level),
+			result.End,
+		}
+		for _, name := range names {
+			record = append(record, strconv.Itoa(result.Counts[name]))
+		}
+		if err := output.Write(record); err != nil {
+			return fmt.Errorf("CSV出力エラー: %w", err)
+		}
+	}
+
+	output.Flush()
+	if err := output.Error(); err != nil {
+		return fmt.Errorf("CSV出力エラー: %w", err)
+	}
+	return nil
+}
+
+// pieceNames は結果に現れるピースの名前を、ピースの種類の順に並べて返す
+func (r Report) pieceNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, result := range r.Results {
+		for name := range result.Counts {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		left, leftErr := model.ParseTetrominoType(names[i])
+		right, rightErr := model.ParseTetrominoType(names[j])
+		if leftErr != nil || rightErr != nil || left == right {
+			// 登録されていない名前は登録済みのピースの後に名前順で並べる
+			return rightErr != nil && (leftErr == nil || names[i] < names[j])
+		}
+		return left < right
+	})
+	return names
+}
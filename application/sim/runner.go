@@ -0,0 +1,142 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"tetris/application"
+	"tetris/application/ai"
+	"time"
+)
+
+var ErrInvalidConfig = errors.New("無効なシミュレーション設定です")
+
+// ゲームが終わった理由。トップアウトの場合は service.TopOutCause の名前になる
+const (
+	EndPieceLimit  = "piece-limit"
+	EndScriptEnded = "script-ended"
+)
+
+type Config struct {
+	Games      int
+	Seed       uint64
+	MaxPieces  int
+	StartLevel int
+	Workers    int
+	// NewDriver はゲームごとに新しいドライバーを作る
+	NewDriver func() Driver
+}
+
+type GameResult struct {
+	Game   int            `json:"game"`
+	Seed   uint64         `json:"seed"`
+	Pieces int            `json:"pieces"`
+	Lines  int            `json:"lines"`
+	Score  int            `json:"score"`
+	Level  int            `json:"level"`
+	End    string         `json:"end"`
+	Counts map[string]int `json:"pieceCounts"`
+}
+
+type Report struct {
+	Summary Summary      `json:"summary"`
+	Results []GameResult `json:"games"`
+}
+
+// Run はシードを1つずつずらした複数のゲームをワーカープールで並列に実行し、結果と集計を返す。
+// 同じ設定なら並列数に関わらず同じ結果になる
+func Run(config Config) (Report, error) {
+	if config.Games < 1 || config.Workers < 1 || config.MaxPieces < 0 || config.NewDriver == nil {
+		return Report{}, fmt.Errorf("%w: ゲーム数=%d, ワーカー数=%d, ピース数=%d",
+			ErrInvalidConfig, config.Games, config.Workers, config.MaxPieces)
+	}
+
+	jobs := make(chan int)
+	results := make([]GameResult, config.Games)
+	errs := make([]error, config.Games)
+
+	var wg sync.WaitGroup
+	for w := 0; w < config.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for game := range jobs {
+				results[game], errs[game] = Play(config, game)
+			}
+		}()
+	}
+	for game := 0; game < config.Games; game++ {
+		jobs <- game
+	}
+	close(jobs)
+	wg.Wait()
+
+	for game, err := range errs {
+		if err != nil {
+			return Report{}, fmt.Errorf("ゲーム%d: %w", game, err)
+		}
+	}
+
+	return Report{Summary: Summarize(results), Results: results}, nil
+}
+
+// Play は1ゲームを最後まで、または最大ピース数まで実行する
+func Play(config Config, game int) (GameResult, error) {
+	seed := config.Seed + uint64(game)
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{
+		Clock:      application.FixedClock{Time: time.Unix(0, 0)},
+		StartLevel: config.StartLevel,
+		Seed:       seed,
+	})
+	if err != nil {
+		return GameResult{}, fmt.Errorf("シミュレーション初期化エラー: %w", err)
+	}
+
+	driver := config.NewDriver()
+	// 出現するピースの種類ごとに0で始め、一度も出なかったピースも結果に残す
+	pieces := controller.GetGameState().Pieces
+	result := GameResult{Game: game, Seed: seed, Counts: make(map[string]int, len(pieces))}
+	for _, piece := range pieces {
+		result.Counts[piece.String()] = 0
+	}
+
+	for {
+		state := controller.GetGameState()
+		result.Pieces = state.PiecesLocked
+		result.Lines = state.Lines
+		result.Score = state.Score
+		result.Level = state.Level
+
+		if state.GameOver {
+			result.End = state.TopOut.String()
+			return result, nil
+		}
+		if config.MaxPieces > 0 && state.PiecesLocked >= config.MaxPieces {
+			result.End = EndPieceLimit
+			return result, nil
+		}
+		result.Counts[state.CurrentPiece.Type.String()]++
+
+		inputs, err := driver.Inputs(state)
+		if errors.Is(err, ErrScriptEnded) {
+			result.Counts[state.CurrentPiece.Type.String()]--
+			result.End = EndScriptEnded
+			return result, nil
+		}
+		if err != nil && !errors.Is(err, ai.ErrNoPlacement) {
+			return result, fmt.Errorf("入力決定エラー: %w", err)
+		}
+
+		for _, input := range inputs {
+			if err := controller.HandleInput(input); err != nil {
+				return result, fmt.Errorf("シミュレーション入力エラー: %w", err)
+			}
+		}
+		if controller.GetGameState().PiecesLocked == state.PiecesLocked && !controller.GetGameState().GameOver {
+			// 固定まで進まなかった入力の後は一気に落下させ、同じピースで止まり続けないようにする
+			if err := controller.HandleInput("drop"); err != nil {
+				return result, fmt.Errorf("シミュレーション入力エラー: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"tetris/application/ai"
+	"tetris/domain/model"
+)
+
+func aiConfig(games, workers, maxPieces int) Config {
+	return Config{
+		Games:     games,
+		Seed:      10,
+		MaxPieces: maxPieces,
+		Workers:   workers,
+		NewDriver: func() Driver {
+			return NewPlannerDriver(ai.NewBot(ai.DefaultWeights()))
+		},
+	}
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "ゲーム数0", config: Config{Games: 0, Workers: 1, NewDriver: aiConfig(1, 1, 1).NewDriver}},
+		{name: "ワーカー0", config: Config{Games: 1, Workers: 0, NewDriver: aiConfig(1, 1, 1).NewDriver}},
+		{name: "ドライバーなし", config: Config{Games: 1, Workers: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.config); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Run() error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestRun_DeterministicAcrossWorkers(t *testing.T) {
+	sequential, err := Run(aiConfig(6, 1, 40))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	parallel, err := Run(aiConfig(6, 4, 40))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Error("Run() results differ between 1 and 4 workers")
+	}
+	for i, result := range sequential.Results {
+		if result.Game != i || result.Seed != 10+uint64(i) {
+			t.Errorf("result %d: game = %d, seed = %d", i, result.Game, result.Seed)
+		}
+		if result.End != EndPieceLimit || result.Pieces != 40 {
+			t.Errorf("result %d: end = %s, pieces = %d", i, result.End, result.Pieces)
+		}
+		total := 0
+		for _, count := range result.Counts {
+			total += count
+		}
+		if total != 40 {
+			t.Errorf("result %d: piece counts total = %d, want 40", i, total)
+		}
+	}
+}
+
+func TestPlay_EndReasons(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		expected string
+	}{
+		{name: "スクリプトの終わり", commands: []string{"left", "drop", "right", "drop"}, expected: EndScriptEnded},
+		{name: "中央に積み続けると出現位置が塞がる", commands: repeat("drop", 100), expected: "block-out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Games: 1, Workers: 1, NewDriver: func() Driver { return NewScriptDriver(tt.commands) }}
+			result, err := Play(config, 0)
+			if err != nil {
+				t.Fatalf("Play() error = %v", err)
+			}
+			if result.End != tt.expected {
+				t.Errorf("End = %s, want %s", result.End, tt.expected)
+			}
+		})
+	}
+}
+
+func repeat(command string, count int) []string {
+	commands := make([]string, count)
+	for i := range commands {
+		commands[i] = command
+	}
+	return commands
+}
+
+func TestReport_Output(t *testing.T) {
+	report, err := Run(aiConfig(3, 2, 10))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var csvOutput bytes.Buffer
+	if err := report.WriteCSV(&csvOutput); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(&csvOutput).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll() error = %v", err)
+	}
+	if len(records) != 4 || len(records[0]) != 7+len(model.StandardPieces) || records[0][0] != "game" {
+		t.Errorf("CSV = %v", records)
+	}
+
+	var jsonOutput bytes.Buffer
+	if err := report.WriteJSON(&jsonOutput); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(jsonOutput.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, report) {
+		t.Errorf("JSON round trip = %+v, want %+v", decoded, report)
+	}
+}
+
+func TestReport_CSVPieceColumns(t *testing.T) {
+	// 標準以外のピースも、ピースの種類の順に列へ並べる
+	report := Report{Results: []GameResult{
+		{Counts: map[string]int{"I5": 1, "T": 0}},
+		{Counts: map[string]int{"I": 2, "T": 3}},
+	}}
+
+	var output bytes.Buffer
+	if err := report.WriteCSV(&output); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(&output).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll() error = %v", err)
+	}
+	want := [][]string{
+		{"game", "seed", "pieces", "lines", "score", "level", "end", "I", "T", "I5"},
+		{"0", "0", "0", "0", "0", "0", "", "0", "0", "1"},
+		{"0", "0", "0", "0", "0", "0", "", "2", "3", "0"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV = %v, want %v", records, want)
+	}
+}
@@ -0,0 +1,103 @@
+package sim
+
+import (
+	"sort"
+)
+
+const scoreBuckets = 10
+
+type Bucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+type Summary struct {
+	Games             int            `json:"games"`
+	MeanLines         float64        `json:"meanLines"`
+	MedianLines       float64        `json:"medianLines"`
+	MinLines          int            `json:"minLines"`
+	MaxLines          int            `json:"maxLines"`
+	MeanScore         float64        `json:"meanScore"`
+	MedianScore       float64        `json:"medianScore"`
+	MaxScore          int            `json:"maxScore"`
+	ScoreDistribution []Bucket       `json:"scoreDistribution"`
+	MeanPieces        float64        `json:"meanPieces"`
+	PieceDistribution map[string]int `json:"pieceDistribution"`
+	EndReasons        map[string]int `json:"endReasons"`
+	TopOutRate        float64        `json:"topOutRate"`
+}
+
+// Summarize はゲームごとの結果からライン数・スコアの代表値、スコアの度数分布、ピースの出現数、終了理由を集計する
+func Summarize(results []GameResult) Summary {
+	summary := Summary{
+		Games:             len(results),
+		PieceDistribution: make(map[string]int),
+		EndReasons:        make(map[string]int),
+	}
+	if len(results) == 0 {
+		return summary
+	}
+
+	lines := make([]int, len(results))
+	scores := make([]int, len(results))
+	topOuts := 0
+	for i, result := range results {
+		lines[i] = result.Lines
+		scores[i] = result.Score
+		summary.MeanPieces += float64(result.Pieces)
+		summary.EndReasons[result.End]++
+		if result.End != EndPieceLimit && result.End != EndScriptEnded {
+			topOuts++
+		}
+		for name, count := range result.Counts {
+			summary.PieceDistribution[name] += count
+		}
+	}
+	sort.Ints(lines)
+	sort.Ints(scores)
+
+	summary.MeanLines = mean(lines)
+	summary.MedianLines = median(lines)
+	summary.MinLines = lines[0]
+	summary.MaxLines = lines[len(lines)-1]
+	summary.MeanScore = mean(scores)
+	summary.MedianScore = median(scores)
+	summary.MaxScore = scores[len(scores)-1]
+	summary.ScoreDistribution = histogram(scores)
+	summary.MeanPieces /= float64(len(results))
+	summary.TopOutRate = float64(topOuts) / float64(len(results))
+
+	return summary
+}
+
+func mean(sorted []int) float64 {
+	total := 0
+	for _, value := range sorted {
+		total += value
+	}
+	return float64(total) / float64(len(sorted))
+}
+
+func median(sorted []int) float64 {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[middle])
+	}
+	return float64(sorted[middle-1]+sorted[middle]) / 2
+}
+
+// histogram は0から最大スコアまでを等幅の区間に分けて数える
+func histogram(sorted []int) []Bucket {
+	maximum := sorted[len(sorted)-1]
+	width := maximum/scoreBuckets + 1
+
+	buckets := make([]Bucket, scoreBuckets)
+	for i := range buckets {
+		buckets[i] = Bucket{Min: i * width, Max: (i+1)*width - 1}
+	}
+	for _, value := range sorted {
+		buckets[value/width].Count++
+	}
+	return buckets
+}
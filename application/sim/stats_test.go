@@ -0,0 +1,59 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	results := []GameResult{
+		{Lines: 10, Score: 1000, Pieces: 30, End: EndPieceLimit, Counts: map[string]int{"I": 2, "T": 1}},
+		{Lines: 4, Score: 0, Pieces: 12, End: "block-out", Counts: map[string]int{"I": 1}},
+		{Lines: 7, Score: 450, Pieces: 20, End: "lock-out", Counts: map[string]int{"O": 3}},
+		{Lines: 1, Score: 99, Pieces: 6, End: "block-out", Counts: map[string]int{}},
+	}
+
+	summary := Summarize(results)
+
+	tests := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+	}{
+		{name: "ゲーム数", actual: summary.Games, expected: 4},
+		{name: "平均ライン", actual: summary.MeanLines, expected: 5.5},
+		{name: "ライン中央値", actual: summary.MedianLines, expected: 5.5},
+		{name: "最小ライン", actual: summary.MinLines, expected: 1},
+		{name: "最大ライン", actual: summary.MaxLines, expected: 10},
+		{name: "平均スコア", actual: summary.MeanScore, expected: 387.25},
+		{name: "スコア中央値", actual: summary.MedianScore, expected: 274.5},
+		{name: "最大スコア", actual: summary.MaxScore, expected: 1000},
+		{name: "平均ピース数", actual: summary.MeanPieces, expected: 17.0},
+		{name: "ピース分布", actual: summary.PieceDistribution, expected: map[string]int{"I": 3, "T": 1, "O": 3}},
+		{name: "終了理由", actual: summary.EndReasons, expected: map[string]int{EndPieceLimit: 1, "block-out": 2, "lock-out": 1}},
+		{name: "トップアウト率", actual: summary.TopOutRate, expected: 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.actual, tt.expected) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.actual, tt.expected)
+			}
+		})
+	}
+
+	// 0〜1000を幅101の10区間に分ける
+	counts := make([]int, len(summary.ScoreDistribution))
+	for i, bucket := range summary.ScoreDistribution {
+		counts[i] = bucket.Count
+	}
+	if want := []int{2, 0, 0, 0, 1, 0, 0, 0, 0, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("ScoreDistribution counts = %v, want %v", counts, want)
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	if summary := Summarize(nil); summary.Games != 0 || summary.ScoreDistribution != nil {
+		t.Errorf("Summarize(nil) = %+v", summary)
+	}
+}
@@ -0,0 +1,242 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"tetris/domain/model"
+)
+
+const (
+	SnapshotEmptyCell  = '.'
+	SnapshotFilledCell = '#'
+)
+
+var ErrInvalidSnapshot = errors.New("無効なスナップショットです")
+
+type PieceSnapshot struct {
+	Type     model.TetrominoType `json:"type"`
+	X        int                 `json:"x"`
+	Y        int                 `json:"y"`
+	Rotation int                 `json:"rotation"`
+	Blocks   []model.Point       `json:"blocks,omitempty"`
+}
+
+type GameSnapshot struct {
+	Width    int            `json:"width"`
+	Height   int            `json:"height"`
+	Rows     []string       `json:"rows"`
+	Piece    *PieceSnapshot `json:"piece,omitempty"`
+	Next     *PieceSnapshot `json:"next,omitempty"`
+	Score    int            `json:"score"`
+	Lines    int            `json:"lines"`
+	Level    int            `json:"level"`
+	Garbage  int            `json:"garbage"`
+	GameOver bool           `json:"gameOver"`
+	Status   ModeStatus     `json:"status"`
+}
+
+type SnapshotDelta struct {
+	Width    int            `json:"width,omitempty"`
+	Height   int            `json:"height,omitempty"`
+	Rows     map[int]string `json:"rows,omitempty"`
+	Piece    *PieceSnapshot `json:"piece,omitempty"`
+	Next     *PieceSnapshot `json:"next,omitempty"`
+	Score    int            `json:"score"`
+	Lines    int            `json:"lines"`
+	Level    int            `json:"level"`
+	Garbage  int            `json:"garbage"`
+	GameOver bool           `json:"gameOver"`
+	Status   ModeStatus     `json:"status"`
+}
+
+func NewGameSnapshot(state GameState) GameSnapshot {
+	snapshot := GameSnapshot{
+		Piece:    newPieceSnapshot(state.CurrentPiece),
+		Next:     newPieceSnapshot(state.NextPiece),
+		Score:    state.Score,
+		Lines:    state.Lines,
+		Level:    state.Level,
+		Garbage:  state.Garbage,
+		GameOver: state.GameOver,
+		Status:   state.Status,
+	}
+
+	if state.Board != nil {
+		snapshot.Width = state.Board.Width
+		snapshot.Height = state.Board.Height
+		snapshot.Rows = make([]string, state.Board.Height)
+		for y, row := range state.Board.Grid {
+			var line strings.Builder
+			for _, filled := range row {
+				if filled {
+					line.WriteByte(SnapshotFilledCell)
+				} else {
+					line.WriteByte(SnapshotEmptyCell)
+				}
+			}
+			snapshot.Rows[y] = line.String()
+		}
+	}
+
+	return snapshot
+}
+
+func newPieceSnapshot(piece *model.Tetromino) *PieceSnapshot {
+	if piece == nil {
+		return nil
+	}
+	return &PieceSnapshot{
+		Type:     piece.Type,
+		X:        piece.Position.X,
+		Y:        piece.Position.Y,
+		Rotation: piece.Rotation(),
+		Blocks:   piece.GetBlocks(),
+	}
+}
+
+func (s GameSnapshot) ToGameState() (GameState, error) {
+	board, err := model.NewBoard(s.Width, s.Height)
+	if err != nil {
+		return GameState{}, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if len(s.Rows) != s.Height {
+		return GameState{}, fmt.Errorf("%w: 行数=%d, 高さ=%d", ErrInvalidSnapshot, len(s.Rows), s.Height)
+	}
+
+	for y, row := range s.Rows {
+		if len(row) != s.Width {
+			return GameState{}, fmt.Errorf("%w: %d行目の幅=%d", ErrInvalidSnapshot, y, len(row))
+		}
+		for x := 0; x < s.Width; x++ {
+			board.Grid[y][x] = row[x] == SnapshotFilledCell
+		}
+	}
+
+	currentPiece, err := s.Piece.toTetromino()
+	if err != nil {
+		return GameState{}, err
+	}
+	nextPiece, err := s.Next.toTetromino()
+	if err != nil {
+		return GameState{}, err
+	}
+
+	return GameState{
+		Board:        board,
+		CurrentPiece: currentPiece,
+		NextPiece:    nextPiece,
+		Score:        s.Score,
+		Lines:        s.Lines,
+		Level:        s.Level,
+		GameOver:     s.GameOver,
+		Garbage:      s.Garbage,
+		Status:       s.Status,
+	}, nil
+}
+
+func (p *PieceSnapshot) toTetromino() (*model.Tetromino, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	piece, err := model.NewTetromino(p.Type, model.Point{X: p.X, Y: p.Y})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	for i := 0; i < p.Rotation; i++ {
+		if err := piece.Rotate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+	}
+	return piece, nil
+}
+
+func DiffSnapshots(previous, current GameSnapshot) SnapshotDelta {
+	delta := SnapshotDelta{
+		Piece:    current.Piece,
+		Next:     current.Next,
+		Score:    current.Score,
+		Lines:    current.Lines,
+		Level:    current.Level,
+		Garbage:  current.Garbage,
+		GameOver: current.GameOver,
+		Status:   current.Status,
+	}
+
+	resized := previous.Width != current.Width || previous.Height != current.Height
+	if resized {
+		delta.Width = current.Width
+		delta.Height = current.Height
+	}
+
+	for y, row := range current.Rows {
+		if resized || y >= len(previous.Rows) || previous.Rows[y] != row {
+			if delta.Rows == nil {
+				delta.Rows = make(map[int]string)
+			}
+			delta.Rows[y] = row
+		}
+	}
+
+	return delta
+}
+
+func (s GameSnapshot) Apply(delta SnapshotDelta) (GameSnapshot, error) {
+	next := s
+	if delta.Width > 0 || delta.Height > 0 {
+		next.Width = delta.Width
+		next.Height = delta.Height
+		next.Rows = make([]string, delta.Height)
+	} else {
+		next.Rows = make([]string, len(s.Rows))
+		copy(next.Rows, s.Rows)
+	}
+
+	for y, row := range delta.Rows {
+		if y < 0 || y >= len(next.Rows) {
+			return s, fmt.Errorf("%w: 行番号=%d", ErrInvalidSnapshot, y)
+		}
+		next.Rows[y] = row
+	}
+
+	next.Piece = delta.Piece
+	next.Next = delta.Next
+	next.Score = delta.Score
+	next.Lines = delta.Lines
+	next.Level = delta.Level
+	next.Garbage = delta.Garbage
+	next.GameOver = delta.GameOver
+	next.Status = delta.Status
+
+	return next, nil
+}
+
+func (s GameSnapshot) Equal(other GameSnapshot) bool {
+	if len(s.Rows) != len(other.Rows) {
+		return false
+	}
+	for y := range s.Rows {
+		if s.Rows[y] != other.Rows[y] {
+			return false
+		}
+	}
+
+	return s.Width == other.Width &&
+		s.Height == other.Height &&
+		equalPieces(s.Piece, other.Piece) &&
+		equalPieces(s.Next, other.Next) &&
+		s.Score == other.Score &&
+		s.Lines == other.Lines &&
+		s.Level == other.Level &&
+		s.Garbage == other.Garbage &&
+		s.GameOver == other.GameOver &&
+		s.Status == other.Status
+}
+
+func equalPieces(a, b *PieceSnapshot) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Type == b.Type && a.X == b.X && a.Y == b.Y && a.Rotation == b.Rotation
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"tetris/domain/model"
+)
+
+func TestGameSnapshot_RoundTrip(t *testing.T) {
+	controller, err := NewGameControllerWithConfig(GameConfig{Clock: newFakeClock(), Seed: 21})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	if err := controller.HandleInput("rotate"); err != nil {
+		t.Fatalf("HandleInput() error = %v", err)
+	}
+	if err := controller.HandleInput("drop"); err != nil {
+		t.Fatalf("HandleInput() error = %v", err)
+	}
+
+	original := controller.GetGameState()
+	snapshot := NewGameSnapshot(original)
+
+	restored, err := snapshot.ToGameState()
+	if err != nil {
+		t.Fatalf("GameSnapshot.ToGameState() error = %v", err)
+	}
+
+	for y := range original.Board.Grid {
+		for x := range original.Board.Grid[y] {
+			if original.Board.Grid[y][x] != restored.Board.Grid[y][x] {
+				t.Fatalf("restored cell (%d, %d) = %v, want %v", x, y, restored.Board.Grid[y][x], original.Board.Grid[y][x])
+			}
+		}
+	}
+
+	originalBlocks := original.CurrentPiece.GetBlocks()
+	restoredBlocks := restored.CurrentPiece.GetBlocks()
+	for i := range originalBlocks {
+		if originalBlocks[i] != restoredBlocks[i] {
+			t.Errorf("restored piece blocks = %v, want %v", restoredBlocks, originalBlocks)
+			break
+		}
+	}
+
+	if !NewGameSnapshot(restored).Equal(snapshot) {
+		t.Error("snapshot of restored state should equal the original snapshot")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	board, err := model.NewBoard(4, 3)
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	previous := NewGameSnapshot(GameState{Board: board, Level: 1})
+
+	board.Grid[2][1] = true
+	current := NewGameSnapshot(GameState{Board: board, Score: 100, Level: 1})
+
+	tests := []struct {
+		name        string
+		previous    GameSnapshot
+		expectedRow []int
+	}{
+		{
+			name:        "変更された行だけが含まれる",
+			previous:    previous,
+			expectedRow: []int{2},
+		},
+		{
+			name:        "空のスナップショットからは全行",
+			previous:    GameSnapshot{},
+			expectedRow: []int{0, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := DiffSnapshots(tt.previous, current)
+
+			if len(delta.Rows) != len(tt.expectedRow) {
+				t.Fatalf("DiffSnapshots() rows = %v, want rows %v", delta.Rows, tt.expectedRow)
+			}
+			for _, y := range tt.expectedRow {
+				if _, exists := delta.Rows[y]; !exists {
+					t.Errorf("DiffSnapshots() missing row %d", y)
+				}
+			}
+
+			applied, err := tt.previous.Apply(delta)
+			if err != nil {
+				t.Fatalf("GameSnapshot.Apply() error = %v", err)
+			}
+			if !applied.Equal(current) {
+				t.Errorf("GameSnapshot.Apply() = %+v, want %+v", applied, current)
+			}
+		})
+	}
+}
+
+func TestGameSnapshot_ApplyInvalidRow(t *testing.T) {
+	snapshot := GameSnapshot{Width: 2, Height: 1, Rows: []string{".."}}
+
+	_, err := snapshot.Apply(SnapshotDelta{Rows: map[int]string{5: "##"}})
+	if !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("GameSnapshot.Apply() error = %v, wantErr %v", err, ErrInvalidSnapshot)
+	}
+}
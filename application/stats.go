@@ -0,0 +1,92 @@
+package application
+
+import (
+	"maps"
+	"tetris/domain/model"
+	"tetris/domain/service"
+	"time"
+)
+
+// PlayStats はプレイ内容の統計。攻撃量は対戦と同じ攻撃テーブルで、実際に送る相手がいなくても数える
+type PlayStats struct {
+	Elapsed     time.Duration
+	Pieces      int
+	Keys        int
+	Attack      int
+	MaxCombo    int
+	PieceCounts map[model.TetrominoType]int
+	ClearCounts map[service.ClearType]int
+}
+
+// PPS は1秒あたりに固定したピース数
+func (s PlayStats) PPS() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Pieces) / s.Elapsed.Seconds()
+}
+
+// APM は1分あたりの攻撃量
+func (s PlayStats) APM() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Attack) / s.Elapsed.Minutes()
+}
+
+// KPP はピース1個あたりのキー入力数
+func (s PlayStats) KPP() float64 {
+	if s.Pieces == 0 {
+		return 0
+	}
+	return float64(s.Keys) / float64(s.Pieces)
+}
+
+type statsCollector struct {
+	stats       PlayStats
+	attackTable AttackTable
+}
+
+func (c *statsCollector) reset() {
+	c.attackTable = DefaultAttackTable()
+	c.stats = PlayStats{
+		PieceCounts: make(map[model.TetrominoType]int),
+		ClearCounts: make(map[service.ClearType]int),
+	}
+}
+
+func (c *statsCollector) press() {
+	c.stats.Keys++
+}
+
+// handle はGameServiceの出来事を購読し、ピースの固定ごとに集計する
+func (c *statsCollector) handle(event service.Event) {
+	if locked, ok := event.(service.PieceLocked); ok {
+		c.lock(locked.Piece.Type, locked.Clear)
+	}
+}
+
+func (c *statsCollector) lock(pieceType model.TetrominoType, clear service.ClearInfo) {
+	c.stats.Pieces++
+	c.stats.PieceCounts[pieceType]++
+	if clear.Type != service.ClearNone {
+		c.stats.ClearCounts[clear.Type]++
+	}
+	c.stats.MaxCombo = max(c.stats.MaxCombo, clear.Combo)
+	c.stats.Attack += c.attackTable.Attack(clear)
+}
+
+// snapshot は集計中のマップを共有しないよう複製した統計を返す
+func (c *statsCollector) snapshot(elapsed time.Duration) PlayStats {
+	stats := c.stats
+	stats.Elapsed = elapsed
+	stats.PieceCounts = maps.Clone(c.stats.PieceCounts)
+	stats.ClearCounts = maps.Clone(c.stats.ClearCounts)
+	return stats
+}
+
+func (c *statsCollector) restore(stats PlayStats) {
+	c.stats = stats
+	c.stats.PieceCounts = maps.Clone(stats.PieceCounts)
+	c.stats.ClearCounts = maps.Clone(stats.ClearCounts)
+}
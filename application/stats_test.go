@@ -0,0 +1,144 @@
+package application
+
+import (
+	"testing"
+	"tetris/domain/model"
+	"tetris/domain/service"
+	"time"
+)
+
+func TestPlayStats_Rates(t *testing.T) {
+	tests := []struct {
+		name    string
+		stats   PlayStats
+		wantPPS float64
+		wantAPM float64
+		wantKPP float64
+	}{
+		{name: "開始直後", stats: PlayStats{}},
+		{
+			name:    "1分間のプレイ",
+			stats:   PlayStats{Elapsed: time.Minute, Pieces: 90, Keys: 270, Attack: 30},
+			wantPPS: 1.5,
+			wantAPM: 30,
+			wantKPP: 3,
+		},
+		{
+			name:    "30秒のプレイ",
+			stats:   PlayStats{Elapsed: 30 * time.Second, Pieces: 60, Keys: 150, Attack: 12},
+			wantPPS: 2,
+			wantAPM: 24,
+			wantKPP: 2.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.PPS(); got != tt.wantPPS {
+				t.Errorf("PPS() = %v, want %v", got, tt.wantPPS)
+			}
+			if got := tt.stats.APM(); got != tt.wantAPM {
+				t.Errorf("APM() = %v, want %v", got, tt.wantAPM)
+			}
+			if got := tt.stats.KPP(); got != tt.wantKPP {
+				t.Errorf("KPP() = %v, want %v", got, tt.wantKPP)
+			}
+		})
+	}
+}
+
+func TestStatsCollector_Lock(t *testing.T) {
+	var collector statsCollector
+	collector.reset()
+
+	locks := []struct {
+		pieceType model.TetrominoType
+		clear     service.ClearInfo
+	}{
+		{model.I, service.ClearInfo{Type: service.ClearNone, Combo: -1}},
+		{model.I, service.ClearInfo{Type: service.ClearTetris, Lines: 4, Combo: 0}},
+		{model.T, service.ClearInfo{Type: service.ClearTSpinDouble, Lines: 2, TSpin: true, Combo: 1, BackToBack: true}},
+		{model.O, service.ClearInfo{Type: service.ClearSingle, Lines: 1, Combo: 2}},
+		{model.T, service.ClearInfo{Type: service.ClearTSpin, TSpin: true, Combo: -1}},
+	}
+	for _, lock := range locks {
+		collector.lock(lock.pieceType, lock.clear)
+	}
+
+	stats := collector.snapshot(time.Minute)
+	if stats.Pieces != 5 {
+		t.Errorf("Pieces = %d, want 5", stats.Pieces)
+	}
+	if stats.MaxCombo != 2 {
+		t.Errorf("MaxCombo = %d, want 2", stats.MaxCombo)
+	}
+	// テトリス4 + TSD4 + B2B1 + 1REN0 + シングル0 + 2REN1
+	if stats.Attack != 10 {
+		t.Errorf("Attack = %d, want 10", stats.Attack)
+	}
+
+	wantPieces := map[model.TetrominoType]int{model.I: 2, model.T: 2, model.O: 1}
+	for pieceType, want := range wantPieces {
+		if got := stats.PieceCounts[pieceType]; got != want {
+			t.Errorf("PieceCounts[%d] = %d, want %d", pieceType, got, want)
+		}
+	}
+	wantClears := map[service.ClearType]int{
+		service.ClearNone:        0,
+		service.ClearSingle:      1,
+		service.ClearTetris:      1,
+		service.ClearTSpin:       1,
+		service.ClearTSpinDouble: 1,
+	}
+	for clearType, want := range wantClears {
+		if got := stats.ClearCounts[clearType]; got != want {
+			t.Errorf("ClearCounts[%d] = %d, want %d", clearType, got, want)
+		}
+	}
+
+	stats.PieceCounts[model.I] = 100
+	if collector.stats.PieceCounts[model.I] != 2 {
+		t.Error("snapshot() shares PieceCounts with the collector")
+	}
+}
+
+func TestGameController_Stats(t *testing.T) {
+	clock := newFakeClock()
+	controller, err := NewGameControllerWithConfig(GameConfig{Clock: clock, Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+
+	inputs := []string{"left", "rotate", "drop", "down", "drop", "drop", "pause", "pause"}
+	for _, input := range inputs {
+		if err := controller.HandleInput(input); err != nil {
+			t.Fatalf("HandleInput(%q) error = %v", input, err)
+		}
+		clock.Advance(time.Second)
+	}
+
+	stats := controller.GetGameState().Stats
+	if stats.Pieces != 3 {
+		t.Errorf("Pieces = %d, want 3", stats.Pieces)
+	}
+	if stats.Keys != 6 {
+		t.Errorf("Keys = %d, want 6", stats.Keys)
+	}
+	total := 0
+	for _, count := range stats.PieceCounts {
+		total += count
+	}
+	if total != 3 {
+		t.Errorf("PieceCounts total = %d, want 3", total)
+	}
+	if stats.Elapsed != 7*time.Second {
+		t.Errorf("Elapsed = %v, want %v", stats.Elapsed, 7*time.Second)
+	}
+
+	if err := controller.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if stats := controller.GetGameState().Stats; stats.Pieces != 0 || stats.Keys != 0 || len(stats.PieceCounts) != 0 {
+		t.Errorf("Stats after Reset() = %+v, want zero", stats)
+	}
+}
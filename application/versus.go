@@ -0,0 +1,233 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"tetris/domain/service"
+)
+
+const (
+	ModeVersus    = "versus"
+	VersusPlayers = 2
+)
+
+const NoWinner = -1
+
+var ErrInvalidPlayer = errors.New("無効なプレイヤーです")
+
+type AttackTable struct {
+	Clears     map[service.ClearType]int
+	Combo      []int
+	BackToBack int
+}
+
+func DefaultAttackTable() AttackTable {
+	return AttackTable{
+		Clears: map[service.ClearType]int{
+			service.ClearSingle:      0,
+			service.ClearDouble:      1,
+			service.ClearTriple:      2,
+			service.ClearTetris:      4,
+			service.ClearTSpinSingle: 2,
+			service.ClearTSpinDouble: 4,
+			service.ClearTSpinTriple: 6,
+		},
+		Combo:      []int{0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 4, 5},
+		BackToBack: 1,
+	}
+}
+
+func (t AttackTable) Attack(clear service.ClearInfo) int {
+	if clear.Lines == 0 {
+		return 0
+	}
+
+	attack := t.Clears[clear.Type]
+	if clear.BackToBack {
+		attack += t.BackToBack
+	}
+	if clear.Combo > 0 && len(t.Combo) > 0 {
+		attack += t.Combo[min(clear.Combo, len(t.Combo)-1)]
+	}
+	return attack
+}
+
+type VersusPlayerState struct {
+	Game           GameState
+	PendingGarbage int
+	Sent           int
+}
+
+type VersusState struct {
+	Players  [VersusPlayers]VersusPlayerState
+	Paused   bool
+	Finished bool
+	Winner   int
+}
+
+type versusPlayer struct {
+	controller   *GameController
+	pending      int
+	sent         int
+	piecesLocked int
+}
+
+type VersusMatch struct {
+	players     [VersusPlayers]*versusPlayer
+	attackTable AttackTable
+	finished    bool
+	winner      int
+}
+
+func NewVersusMatch(config GameConfig) (*VersusMatch, error) {
+	match := &VersusMatch{
+		attackTable: DefaultAttackTable(),
+		winner:      NoWinner,
+	}
+
+	for i := range match.players {
+		playerConfig := config
+		playerConfig.Mode = NewEndlessMode()
+		playerConfig.Records = nil
+
+		controller, err := NewGameControllerWithConfig(playerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("プレイヤー%d初期化エラー: %w", i+1, err)
+		}
+		match.players[i] = &versusPlayer{controller: controller}
+	}
+
+	return match, nil
+}
+
+func (m *VersusMatch) GetState() VersusState {
+	state := VersusState{
+		Paused:   m.players[0].controller.IsPaused(),
+		Finished: m.finished,
+		Winner:   m.winner,
+	}
+
+	for i, player := range m.players {
+		state.Players[i] = VersusPlayerState{
+			Game:           player.controller.GetGameState(),
+			PendingGarbage: player.pending,
+			Sent:           player.sent,
+		}
+	}
+
+	return state
+}
+
+func (m *VersusMatch) Update() error {
+	if m.finished {
+		return nil
+	}
+
+	for i, player := range m.players {
+		if err := player.controller.Update(); err != nil {
+			return fmt.Errorf("プレイヤー%d更新エラー: %w", i+1, err)
+		}
+		if err := m.settle(i); err != nil {
+			return err
+		}
+	}
+
+	m.checkWinner()
+	return nil
+}
+
+func (m *VersusMatch) HandleInput(player int, input string) error {
+	if player < 0 || player >= VersusPlayers {
+		return fmt.Errorf("%w: %d", ErrInvalidPlayer, player)
+	}
+	if m.finished {
+		return nil
+	}
+
+	if input == "pause" {
+		for _, p := range m.players {
+			p.controller.togglePause()
+		}
+		return nil
+	}
+
+	if m.players[player].controller.IsPaused() {
+		return nil
+	}
+
+	if err := m.players[player].controller.HandleInput(input); err != nil {
+		return fmt.Errorf("プレイヤー%d入力エラー: %w", player+1, err)
+	}
+	if err := m.settle(player); err != nil {
+		return err
+	}
+
+	m.checkWinner()
+	return nil
+}
+
+func (m *VersusMatch) Reset() error {
+	for i, player := range m.players {
+		if err := player.controller.Reset(); err != nil {
+			return fmt.Errorf("プレイヤー%dリセットエラー: %w", i+1, err)
+		}
+		player.pending = 0
+		player.sent = 0
+		player.piecesLocked = 0
+	}
+
+	m.finished = false
+	m.winner = NoWinner
+	return nil
+}
+
+func (m *VersusMatch) settle(index int) error {
+	player := m.players[index]
+	opponent := m.players[(index+1)%VersusPlayers]
+
+	state := player.controller.GetGameState()
+	if state.PiecesLocked == player.piecesLocked {
+		return nil
+	}
+	player.piecesLocked = state.PiecesLocked
+
+	attack := m.attackTable.Attack(state.LastClear)
+	if attack > 0 {
+		cancelled := min(attack, player.pending)
+		player.pending -= cancelled
+		attack -= cancelled
+
+		opponent.pending += attack
+		player.sent += attack
+		return nil
+	}
+
+	if state.LastClear.Lines == 0 && player.pending > 0 {
+		lines := player.pending
+		player.pending = 0
+		if err := player.controller.ReceiveGarbage(lines); err != nil {
+			return fmt.Errorf("プレイヤー%dおじゃまライン受信エラー: %w", index+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *VersusMatch) checkWinner() {
+	toppedOut := 0
+	for i, player := range m.players {
+		if player.controller.IsFinished() {
+			toppedOut++
+			m.winner = (i + 1) % VersusPlayers
+		}
+	}
+
+	if toppedOut == 0 {
+		return
+	}
+
+	m.finished = true
+	if toppedOut == VersusPlayers {
+		m.winner = NoWinner
+	}
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"tetris/domain/service"
+)
+
+func TestAttackTable_Attack(t *testing.T) {
+	table := DefaultAttackTable()
+
+	tests := []struct {
+		name     string
+		clear    service.ClearInfo
+		expected int
+	}{
+		{
+			name:     "消去なし",
+			clear:    service.ClearInfo{Type: service.ClearNone, Combo: -1},
+			expected: 0,
+		},
+		{
+			name:     "シングル",
+			clear:    service.ClearInfo{Type: service.ClearSingle, Lines: 1},
+			expected: 0,
+		},
+		{
+			name:     "ダブル",
+			clear:    service.ClearInfo{Type: service.ClearDouble, Lines: 2},
+			expected: 1,
+		},
+		{
+			name:     "テトリス",
+			clear:    service.ClearInfo{Type: service.ClearTetris, Lines: 4},
+			expected: 4,
+		},
+		{
+			name:     "Back-to-Backテトリス",
+			clear:    service.ClearInfo{Type: service.ClearTetris, Lines: 4, BackToBack: true},
+			expected: 5,
+		},
+		{
+			name:     "Tスピンダブル",
+			clear:    service.ClearInfo{Type: service.ClearTSpinDouble, Lines: 2, TSpin: true},
+			expected: 4,
+		},
+		{
+			name:     "4コンボのシングル",
+			clear:    service.ClearInfo{Type: service.ClearSingle, Lines: 1, Combo: 4},
+			expected: 2,
+		},
+		{
+			name:     "コンボ表の上限を超える",
+			clear:    service.ClearInfo{Type: service.ClearSingle, Lines: 1, Combo: 20},
+			expected: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if attack := table.Attack(tt.clear); attack != tt.expected {
+				t.Errorf("AttackTable.Attack() = %d, want %d", attack, tt.expected)
+			}
+		})
+	}
+}
+
+func fillBottomRows(controller *GameController, rows int) {
+	board := controller.GetGameState().Board
+	for y := board.Height - rows; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			board.Grid[y][x] = true
+		}
+	}
+}
+
+func TestVersusMatch_GarbageExchange(t *testing.T) {
+	match, err := NewVersusMatch(GameConfig{Clock: newFakeClock(), Seed: 9})
+	if err != nil {
+		t.Fatalf("NewVersusMatch() error = %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		player          int
+		setup           func()
+		expectedPending [VersusPlayers]int
+		expectedGarbage [VersusPlayers]int
+	}{
+		{
+			name:            "テトリスで4ライン送る",
+			player:          0,
+			setup:           func() { fillBottomRows(match.players[0].controller, 4) },
+			expectedPending: [VersusPlayers]int{0, 4},
+		},
+		{
+			name:            "ダブルで1ライン相殺",
+			player:          1,
+			setup:           func() { fillBottomRows(match.players[1].controller, 2) },
+			expectedPending: [VersusPlayers]int{0, 3},
+		},
+		{
+			name:            "消去なしで溜まったおじゃまラインを受ける",
+			player:          1,
+			setup:           func() {},
+			expectedPending: [VersusPlayers]int{0, 0},
+			expectedGarbage: [VersusPlayers]int{0, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			if err := match.HandleInput(tt.player, "drop"); err != nil {
+				t.Fatalf("VersusMatch.HandleInput() error = %v", err)
+			}
+
+			state := match.GetState()
+			for i, player := range state.Players {
+				if player.PendingGarbage != tt.expectedPending[i] {
+					t.Errorf("player %d pending = %d, want %d", i+1, player.PendingGarbage, tt.expectedPending[i])
+				}
+				if player.Game.Garbage != tt.expectedGarbage[i] {
+					t.Errorf("player %d garbage = %d, want %d", i+1, player.Game.Garbage, tt.expectedGarbage[i])
+				}
+			}
+		})
+	}
+
+	if sent := match.GetState().Players[0].Sent; sent != 4 {
+		t.Errorf("player 1 sent = %d, want 4", sent)
+	}
+}
+
+func TestVersusMatch_Winner(t *testing.T) {
+	match, err := NewVersusMatch(GameConfig{Clock: newFakeClock(), Seed: 9})
+	if err != nil {
+		t.Fatalf("NewVersusMatch() error = %v", err)
+	}
+
+	if err := match.players[0].controller.ReceiveGarbage(25); err != nil {
+		t.Fatalf("ReceiveGarbage() error = %v", err)
+	}
+	if err := match.Update(); err != nil {
+		t.Fatalf("VersusMatch.Update() error = %v", err)
+	}
+
+	state := match.GetState()
+	if !state.Finished || state.Winner != 1 {
+		t.Errorf("VersusState finished = %v winner = %d, want finished with winner 1", state.Finished, state.Winner)
+	}
+
+	if err := match.Reset(); err != nil {
+		t.Fatalf("VersusMatch.Reset() error = %v", err)
+	}
+	if state := match.GetState(); state.Finished || state.Winner != NoWinner {
+		t.Errorf("VersusState after reset = %+v, want unfinished", state)
+	}
+}
+
+func TestVersusMatch_InvalidPlayer(t *testing.T) {
+	match, err := NewVersusMatch(GameConfig{Clock: newFakeClock()})
+	if err != nil {
+		t.Fatalf("NewVersusMatch() error = %v", err)
+	}
+
+	if err := match.HandleInput(2, "left"); !errors.Is(err, ErrInvalidPlayer) {
+		t.Errorf("VersusMatch.HandleInput() error = %v, wantErr %v", err, ErrInvalidPlayer)
+	}
+}
@@ -3,6 +3,8 @@ package model
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 const (
@@ -10,16 +12,34 @@ const (
 	BoardHeight = 20
 )
 
+// ゲームで遊べるボードの大きさの範囲。幅はテトロミノが出現できる4列から、コンソールの枠に収まる20列まで
+const (
+	MinBoardWidth  = 4
+	MaxBoardWidth  = 20
+	MinBoardHeight = 4
+	MaxBoardHeight = 40
+)
+
 var (
 	ErrOutOfBounds      = errors.New("ボード範囲外です")
 	ErrInvalidBoardSize = errors.New("無効なボードサイズです")
 	ErrBlockOccupied    = errors.New("ブロックが既に配置されています")
+	ErrTopOut           = errors.New("ブロックがボード上端を超えました")
+	ErrInvalidNotation  = errors.New("無効なボード表記です")
+)
+
+// ボード表記のセル。ピースで置いたブロックはピースの種類の文字（"T"など）で表す
+const (
+	EmptyCell   = '.'
+	GarbageCell = 'X'
 )
 
 type Board struct {
 	Grid   [][]bool
 	Width  int
 	Height int
+	// cells はブロックを置いたピースの種類の表記。Gridだけを書き換えたブロックはおじゃまブロックとして表記する
+	cells [][]byte
 }
 
 func NewBoard(width, height int) (*Board, error) {
@@ -28,17 +48,131 @@ func NewBoard(width, height int) (*Board, error) {
 	}
 
 	grid := make([][]bool, height)
+	cells := make([][]byte, height)
 	for i := range grid {
 		grid[i] = make([]bool, width)
+		cells[i] = make([]byte, width)
 	}
 
 	return &Board{
 		Grid:   grid,
 		Width:  width,
 		Height: height,
+		cells:  cells,
 	}, nil
 }
 
+// ValidateBoardSize はゲームで遊べるボードの大きさかを検証する
+func ValidateBoardSize(width, height int) error {
+	if width < MinBoardWidth || width > MaxBoardWidth || height < MinBoardHeight || height > MaxBoardHeight {
+		return fmt.Errorf("%w: %dx%d（幅%d〜%d、高さ%d〜%d）", ErrInvalidBoardSize, width, height,
+			MinBoardWidth, MaxBoardWidth, MinBoardHeight, MaxBoardHeight)
+	}
+	return nil
+}
+
+// ParseBoard は1行ずつ改行で区切ったボード表記を読み込む。'.'が空きマス、'X'がおじゃまブロック、
+// "I"や"T"などの登録済みのピースの表記の文字がそのピースで置いたブロックを表す。各行の前後の空白と前後の空行は無視する
+func ParseBoard(notation string) (*Board, error) {
+	notation = strings.TrimSpace(notation)
+	if notation == "" {
+		return nil, fmt.Errorf("%w: 空の表記です", ErrInvalidNotation)
+	}
+
+	rows := strings.Split(notation, "\n")
+	width := len(strings.TrimSpace(rows[0]))
+	board, err := NewBoard(width, len(rows))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNotation, err)
+	}
+
+	for y, row := range rows {
+		row = strings.TrimSpace(row)
+		if len(row) != width {
+			return nil, fmt.Errorf("%w: %d行目の幅%dが%dではありません", ErrInvalidNotation, y+1, len(row), width)
+		}
+		for x := 0; x < width; x++ {
+			cell := row[x]
+			switch {
+			case cell == EmptyCell:
+			case cell == GarbageCell:
+				board.Grid[y][x] = true
+			case isPieceCell(cell):
+				board.Grid[y][x] = true
+				board.cells[y][x] = cell
+			default:
+				return nil, fmt.Errorf("%w: %d行目に不明なセル %q があります", ErrInvalidNotation, y+1, cell)
+			}
+		}
+	}
+	return board, nil
+}
+
+// ParseBoardRows は下詰めの行を width x height のボードとして読み込む。足りない上側の行は空きマスで埋める
+func ParseBoardRows(width, height int, rows ...string) (*Board, error) {
+	if width <= 0 || height <= 0 || len(rows) > height {
+		return nil, fmt.Errorf("%w: %d行を幅%d、高さ%dのボードに収められません", ErrInvalidNotation, len(rows), width, height)
+	}
+
+	notation := make([]string, 0, height)
+	for len(notation) < height-len(rows) {
+		notation = append(notation, strings.Repeat(string(EmptyCell), width))
+	}
+	board, err := ParseBoard(strings.Join(append(notation, rows...), "\n"))
+	if err != nil {
+		return nil, err
+	}
+	if board.Width != width || board.Height != height {
+		return nil, fmt.Errorf("%w: 幅%dの行は幅%dのボードに収められません", ErrInvalidNotation, board.Width, width)
+	}
+	return board, nil
+}
+
+// String はボードを ParseBoard で読み込める表記にする。ParseBoard で読み込んだ表記は同じ文字列に戻る
+func (b *Board) String() string {
+	var builder strings.Builder
+	for y := 0; y < b.Height; y++ {
+		if y > 0 {
+			builder.WriteByte('\n')
+		}
+		for x := 0; x < b.Width; x++ {
+			builder.WriteByte(b.cell(x, y))
+		}
+	}
+	return builder.String()
+}
+
+func (b *Board) cell(x, y int) byte {
+	switch {
+	case !b.Grid[y][x]:
+		return EmptyCell
+	case b.cells != nil && b.cells[y][x] != 0:
+		return b.cells[y][x]
+	default:
+		return GarbageCell
+	}
+}
+
+func (b *Board) Clone() *Board {
+	grid := make([][]bool, b.Height)
+	cells := make([][]byte, b.Height)
+	for y := range grid {
+		grid[y] = make([]bool, b.Width)
+		copy(grid[y], b.Grid[y])
+		cells[y] = make([]byte, b.Width)
+		if b.cells != nil {
+			copy(cells[y], b.cells[y])
+		}
+	}
+
+	return &Board{
+		Grid:   grid,
+		Width:  b.Width,
+		Height: b.Height,
+		cells:  cells,
+	}
+}
+
 func (b *Board) IsValidPosition(point Point) bool {
 	return point.X >= 0 && point.X < b.Width && point.Y >= 0 && point.Y < b.Height
 }
@@ -55,6 +189,9 @@ func (b *Board) SetBlock(point Point, occupied bool) error {
 		return fmt.Errorf("%w: 座標(%d, %d)", ErrOutOfBounds, point.X, point.Y)
 	}
 	b.Grid[point.Y][point.X] = occupied
+	if b.cells != nil {
+		b.cells[point.Y][point.X] = 0
+	}
 	return nil
 }
 
@@ -89,6 +226,9 @@ func (b *Board) PlaceTetromino(tetromino *Tetromino) error {
 		if err := b.SetBlock(block, true); err != nil {
 			return fmt.Errorf("ブロック配置エラー: %w", err)
 		}
+		if b.cells != nil {
+			b.cells[block.Y][block.X] = tetromino.Cell()
+		}
 	}
 	return nil
 }
@@ -126,18 +266,89 @@ func (b *Board) ClearLines(lines []int) error {
 		}
 	}
 
-	for i := len(lines) - 1; i >= 0; i-- {
-		lineIndex := lines[i]
+	sortedLines := make([]int, len(lines))
+	copy(sortedLines, lines)
+	sort.Ints(sortedLines)
+
+	for _, lineIndex := range sortedLines {
 		for y := lineIndex; y > 0; y-- {
 			copy(b.Grid[y], b.Grid[y-1])
+			if b.cells != nil {
+				copy(b.cells[y], b.cells[y-1])
+			}
 		}
 		for x := 0; x < b.Width; x++ {
 			b.Grid[0][x] = false
+			if b.cells != nil {
+				b.cells[0][x] = 0
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Board) InsertGarbageLines(holes []int) error {
+	if len(holes) == 0 {
+		return nil
+	}
+
+	for _, hole := range holes {
+		if hole < 0 || hole >= b.Width {
+			return fmt.Errorf("%w: 穴の位置=%d", ErrOutOfBounds, hole)
+		}
+	}
+
+	count := min(len(holes), b.Height)
+	toppedOut := false
+	for y := 0; y < count; y++ {
+		if !b.isLineEmpty(y) {
+			toppedOut = true
+			break
+		}
+	}
+
+	for y := 0; y < b.Height-count; y++ {
+		copy(b.Grid[y], b.Grid[y+count])
+		if b.cells != nil {
+			copy(b.cells[y], b.cells[y+count])
 		}
 	}
+
+	for i := 0; i < count; i++ {
+		row := b.Grid[b.Height-count+i]
+		for x := range row {
+			row[x] = x != holes[len(holes)-count+i]
+			if b.cells != nil {
+				b.cells[b.Height-count+i][x] = 0
+			}
+		}
+	}
+
+	if toppedOut || len(holes) > b.Height {
+		return fmt.Errorf("%w: せり上がり%dライン", ErrTopOut, len(holes))
+	}
 	return nil
 }
 
+func (b *Board) isLineEmpty(y int) bool {
+	for x := 0; x < b.Width; x++ {
+		if b.Grid[y][x] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty はボードにブロックが1つもないかを返す
+func (b *Board) IsEmpty() bool {
+	for y := 0; y < b.Height; y++ {
+		if !b.isLineEmpty(y) {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *Board) IsGameOver() bool {
 	for x := 0; x < b.Width; x++ {
 		if b.Grid[0][x] {
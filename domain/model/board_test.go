@@ -366,58 +366,80 @@ func TestBoard_GetCompletedLines(t *testing.T) {
 func TestBoard_ClearLines(t *testing.T) {
 	tests := []struct {
 		name         string
-		setupBoard   func(*Board)
+		board        string
 		linesToClear []int
 		expectError  bool
 		errorType    error
-		checkResult  func(*Board) bool
+		want         string
 	}{
 		{
 			name: "1つのライン消去",
-			setupBoard: func(b *Board) {
-				for x := 0; x < b.Width; x++ {
-					b.SetBlock(Point{X: x, Y: 19}, true)
-				}
-				b.SetBlock(Point{X: 0, Y: 18}, true)
-			},
-			linesToClear: []int{19},
-			expectError:  false,
-			checkResult: func(b *Board) bool {
-				occupied, _ := b.IsOccupied(Point{X: 0, Y: 19})
-				return occupied
-			},
+			board: `
+				..........
+				..........
+				TTT.......
+				IIIIXXXXXX`,
+			linesToClear: []int{3},
+			want: `
+				..........
+				..........
+				..........
+				TTT.......`,
+		},
+		{
+			name: "隣接する複数ラインの消去",
+			board: `
+				..........
+				....L.....
+				XXXXXXXXXX
+				XXXXXXXXXX
+				XXXXXXXXXX
+				XXXXXXXXXX`,
+			linesToClear: []int{2, 3, 4, 5},
+			want: `
+				..........
+				..........
+				..........
+				..........
+				..........
+				....L.....`,
+		},
+		{
+			name: "離れたラインを順不同で消去",
+			board: `
+				..S.......
+				XXXXXXXXXX
+				...J......
+				XXXXXXXXXX`,
+			linesToClear: []int{3, 1},
+			want: `
+				..........
+				..........
+				..S.......
+				...J......`,
 		},
 		{
 			name:         "範囲外のライン消去",
-			setupBoard:   func(b *Board) {},
+			board:        "..........",
 			linesToClear: []int{25},
 			expectError:  true,
 			errorType:    ErrOutOfBounds,
 		},
 		{
 			name:         "空のライン配列",
-			setupBoard:   func(b *Board) {},
+			board:        "....O.....\nX..OOXXXXX",
 			linesToClear: []int{},
-			expectError:  false,
+			want:         "....O.....\nX..OOXXXXX",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			board, err := NewBoard(10, 20)
-			if err != nil {
-				t.Fatalf("NewBoard() error = %v", err)
-			}
-
-			tt.setupBoard(board)
+			board := mustParseBoard(t, tt.board)
 
-			err = board.ClearLines(tt.linesToClear)
+			err := board.ClearLines(tt.linesToClear)
 
 			if tt.expectError {
-				if err == nil {
-					t.Errorf("Board.ClearLines() error = nil, wantErr %v", tt.errorType)
-					return
-				}
 				if !errors.Is(err, tt.errorType) {
 					t.Errorf("Board.ClearLines() error = %v, wantErr %v", err, tt.errorType)
 				}
@@ -425,12 +447,10 @@ func TestBoard_ClearLines(t *testing.T) {
 			}
 
 			if err != nil {
-				t.Errorf("Board.ClearLines() unexpected error = %v", err)
-				return
+				t.Fatalf("Board.ClearLines() unexpected error = %v", err)
 			}
-
-			if tt.checkResult != nil && !tt.checkResult(board) {
-				t.Error("Board.ClearLines() result check failed")
+			if want := mustParseBoard(t, tt.want).String(); board.String() != want {
+				t.Errorf("Board.ClearLines() board =\n%s\nwant\n%s", board, want)
 			}
 		})
 	}
@@ -488,3 +508,371 @@ func TestBoard_IsGameOver(t *testing.T) {
 		})
 	}
 }
+
+func TestBoard_IsEmpty(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupBoard func(*Board)
+		expected   bool
+	}{
+		{
+			name:       "空のボード",
+			setupBoard: func(b *Board) {},
+			expected:   true,
+		},
+		{
+			name: "最下行にブロックあり",
+			setupBoard: func(b *Board) {
+				b.SetBlock(Point{X: 9, Y: 19}, true)
+			},
+			expected: false,
+		},
+		{
+			name: "最上行にブロックあり",
+			setupBoard: func(b *Board) {
+				b.SetBlock(Point{X: 0, Y: 0}, true)
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := NewBoard(10, 20)
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+
+			tt.setupBoard(board)
+
+			result := board.IsEmpty()
+			if result != tt.expected {
+				t.Errorf("Board.IsEmpty() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBoard_InsertGarbageLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		board       string
+		holes       []int
+		expectError bool
+		errorType   error
+		want        string
+	}{
+		{
+			name: "空のボードに2ライン追加",
+			board: `
+				..........
+				..........
+				..........
+				..........`,
+			holes: []int{3, 7},
+			want: `
+				..........
+				..........
+				XXX.XXXXXX
+				XXXXXXX.XX`,
+		},
+		{
+			name: "既存ブロックが押し上げられる",
+			board: `
+				..........
+				..........
+				.....J....
+				.....JJJ..`,
+			holes: []int{0},
+			want: `
+				..........
+				.....J....
+				.....JJJ..
+				.XXXXXXXXX`,
+		},
+		{
+			name:        "上端のブロックが押し出されるとトップアウト",
+			board:       "..X.......\n..........",
+			holes:       []int{0},
+			expectError: true,
+			errorType:   ErrTopOut,
+		},
+		{
+			name:        "範囲外の穴",
+			board:       "..........",
+			holes:       []int{10},
+			expectError: true,
+			errorType:   ErrOutOfBounds,
+		},
+		{
+			name:  "空の穴配列",
+			board: "..........\n..S.......",
+			holes: []int{},
+			want:  "..........\n..S.......",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := mustParseBoard(t, tt.board)
+
+			err := board.InsertGarbageLines(tt.holes)
+
+			if tt.expectError {
+				if !errors.Is(err, tt.errorType) {
+					t.Errorf("Board.InsertGarbageLines() error = %v, wantErr %v", err, tt.errorType)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Board.InsertGarbageLines() unexpected error = %v", err)
+			}
+			if want := mustParseBoard(t, tt.want).String(); board.String() != want {
+				t.Errorf("Board.InsertGarbageLines() board =\n%s\nwant\n%s", board, want)
+			}
+		})
+	}
+}
+
+func TestBoard_Clone(t *testing.T) {
+	board, _ := NewBoard(4, 3)
+	_ = board.SetBlock(Point{X: 1, Y: 2}, true)
+
+	clone := board.Clone()
+	if clone.Width != board.Width || clone.Height != board.Height {
+		t.Fatalf("Clone() size = %dx%d, want %dx%d", clone.Width, clone.Height, board.Width, board.Height)
+	}
+	if !clone.Grid[2][1] {
+		t.Error("Clone() should copy occupied cells")
+	}
+
+	_ = clone.SetBlock(Point{X: 0, Y: 0}, true)
+	if board.Grid[0][0] {
+		t.Error("modifying the clone should not affect the original board")
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	tests := []struct {
+		name       string
+		notation   string
+		wantWidth  int
+		wantHeight int
+		wantCells  []Point
+		wantErr    bool
+	}{
+		{
+			name:       "字下げと前後の空行を無視する",
+			notation:   "\n\t\t....\n\t\tXT..\n",
+			wantWidth:  4,
+			wantHeight: 2,
+			wantCells:  []Point{{X: 0, Y: 1}, {X: 1, Y: 1}},
+		},
+		{
+			name:       "すべてのピースの文字",
+			notation:   "IOTSZJL.X",
+			wantWidth:  9,
+			wantHeight: 1,
+			wantCells: []Point{
+				{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0},
+				{X: 4, Y: 0}, {X: 5, Y: 0}, {X: 6, Y: 0}, {X: 8, Y: 0},
+			},
+		},
+		{name: "空文字列", notation: "", wantErr: true},
+		{name: "空の表記", notation: " \n ", wantErr: true},
+		{name: "行の幅が揃っていない", notation: "....\n...", wantErr: true},
+		{name: "不明なセル", notation: "..#.", wantErr: true},
+		{name: "登録されていない文字", notation: "..q.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := ParseBoard(tt.notation)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNotation) {
+					t.Errorf("ParseBoard() error = %v, want %v", err, ErrInvalidNotation)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBoard() error = %v", err)
+			}
+			if board.Width != tt.wantWidth || board.Height != tt.wantHeight {
+				t.Fatalf("ParseBoard() size = %dx%d, want %dx%d",
+					board.Width, board.Height, tt.wantWidth, tt.wantHeight)
+			}
+			filled := 0
+			for y := 0; y < board.Height; y++ {
+				for x := 0; x < board.Width; x++ {
+					if board.Grid[y][x] {
+						filled++
+					}
+				}
+			}
+			if filled != len(tt.wantCells) {
+				t.Errorf("ParseBoard() filled cells = %d, want %d", filled, len(tt.wantCells))
+			}
+			for _, cell := range tt.wantCells {
+				if !board.Grid[cell.Y][cell.X] {
+					t.Errorf("ParseBoard() cell %v is empty, want filled", cell)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBoardRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		height  int
+		rows    []string
+		want    string
+		wantErr bool
+	}{
+		{name: "上を空きマスで埋める", width: 3, height: 3, rows: []string{"XT."}, want: "...\n...\nXT."},
+		{name: "高さぴったりの行", width: 2, height: 2, rows: []string{"X.", ".I"}, want: "X.\n.I"},
+		{name: "行なし", width: 2, height: 2, want: "..\n.."},
+		{name: "行が多すぎる", width: 1, height: 1, rows: []string{".", "X"}, wantErr: true},
+		{name: "行の幅が違う", width: 3, height: 2, rows: []string{"..", ".."}, wantErr: true},
+		{name: "不明なセル", width: 2, height: 2, rows: []string{"#."}, wantErr: true},
+		{name: "幅が0", width: 0, height: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := ParseBoardRows(tt.width, tt.height, tt.rows...)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNotation) {
+					t.Errorf("ParseBoardRows() error = %v, want %v", err, ErrInvalidNotation)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBoardRows() error = %v", err)
+			}
+			if got := board.String(); got != tt.want {
+				t.Errorf("ParseBoardRows() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoard_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*Board)
+		want  string
+	}{
+		{
+			name:  "空のボード",
+			setup: func(b *Board) {},
+			want:  "....\n....\n....",
+		},
+		{
+			name: "置いたピースは種類の文字になる",
+			setup: func(b *Board) {
+				piece, _ := NewTetromino(T, Point{X: 0, Y: 0})
+				_ = b.PlaceTetromino(piece)
+			},
+			want: "....\n.T..\nTTT.",
+		},
+		{
+			name: "SetBlockで置いたブロックはおじゃまブロックになる",
+			setup: func(b *Board) {
+				piece, _ := NewTetromino(T, Point{X: 0, Y: 0})
+				_ = b.PlaceTetromino(piece)
+				_ = b.SetBlock(Point{X: 1, Y: 1}, true)
+				_ = b.SetBlock(Point{X: 3, Y: 0}, true)
+			},
+			want: "...X\n.X..\nTTT.",
+		},
+		{
+			name: "Gridを直接書き換えたブロック",
+			setup: func(b *Board) {
+				b.Grid[2][3] = true
+			},
+			want: "....\n....\n...X",
+		},
+		{
+			name: "消去で空いた上端の行にGridで置いたブロック",
+			setup: func(b *Board) {
+				piece, _ := NewTetromino(T, Point{X: 0, Y: -1})
+				_ = b.PlaceTetromino(piece)
+				for x := range b.Grid[2] {
+					b.Grid[2][x] = true
+				}
+				_ = b.ClearLines([]int{2})
+				b.Grid[0][1] = true
+			},
+			want: ".X..\n.T..\nTTT.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, _ := NewBoard(4, 3)
+			tt.setup(board)
+			if got := board.String(); got != tt.want {
+				t.Errorf("Board.String() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoard_NotationRoundTrip(t *testing.T) {
+	notations := []string{
+		"..........\n....T.....\n...TTT.XXX\nIIIIXXXXX.",
+		"LLLJJJ\nL..J..\nOOSSZZ",
+		"X",
+	}
+
+	for _, notation := range notations {
+		board := mustParseBoard(t, notation)
+		if got := board.String(); got != notation {
+			t.Errorf("ParseBoard(%q).String() = %q", notation, got)
+		}
+		if got := board.Clone().String(); got != notation {
+			t.Errorf("ParseBoard(%q).Clone().String() = %q", notation, got)
+		}
+	}
+}
+
+func mustParseBoard(t *testing.T, notation string) *Board {
+	t.Helper()
+	board, err := ParseBoard(notation)
+	if err != nil {
+		t.Fatalf("ParseBoard(%q) error = %v", notation, err)
+	}
+	return board
+}
+
+func TestValidateBoardSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		height  int
+		wantErr bool
+	}{
+		{name: "標準", width: BoardWidth, height: BoardHeight},
+		{name: "最も狭い", width: MinBoardWidth, height: MinBoardHeight},
+		{name: "最も広い", width: MaxBoardWidth, height: MaxBoardHeight},
+		{name: "狭すぎる", width: MinBoardWidth - 1, height: BoardHeight, wantErr: true},
+		{name: "広すぎる", width: MaxBoardWidth + 1, height: BoardHeight, wantErr: true},
+		{name: "低すぎる", width: BoardWidth, height: MinBoardHeight - 1, wantErr: true},
+		{name: "高すぎる", width: BoardWidth, height: MaxBoardHeight + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBoardSize(tt.width, tt.height)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateBoardSize(%d, %d) error = %v, wantErr %v", tt.width, tt.height, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidBoardSize) {
+				t.Errorf("ValidateBoardSize() error = %v, want %v", err, ErrInvalidBoardSize)
+			}
+		})
+	}
+}
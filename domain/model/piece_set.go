@@ -0,0 +1,370 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidPieceDefinition = errors.New("無効なピース定義です")
+
+// PieceDefinition はピースの種類の定義。Rotations は時計回りに並べた回転状態で、すべて同じ大きさの正方形にする。
+// Spawn はボード中央に揃えた出現位置からのずれ、Kicks は回転でぶつかったときに順に試す位置のずれ（空ならキックなし）。
+// KickTable は回転前の状態ごとのずれで、KickTable[i] は状態iから時計回りに回転するときに試す。
+// 空の状態（KickTable 自体が空の場合も）には Kicks を使う。
+// Cell はボード表記でこのピースのブロックを表す文字（0ならおじゃまブロックと同じ'X'）
+type PieceDefinition struct {
+	Name      string
+	Cell      byte
+	Rotations [][][]bool
+	Spawn     Point
+	Kicks     []Point
+	KickTable [][]Point
+}
+
+// standardKicks は標準のピースの回転で試す位置のずれ。その場でしか回転せず、ぶつかる回転はできない
+var standardKicks = []Point{{X: 0, Y: 0}}
+
+// wallKicks はペントミノとビッグピースの回転で試す位置のずれ。先頭ほど優先される
+var wallKicks = []Point{
+	{X: 0, Y: 0},
+	{X: -1, Y: 0},
+	{X: 1, Y: 0},
+	{X: 0, Y: 1},
+	{X: -1, Y: 1},
+	{X: 1, Y: 1},
+}
+
+// pieceRegistry は登録済みのピースの定義。TetrominoType は登録順の番号で、一度登録した定義は変更しない
+type pieceRegistry struct {
+	mu          sync.RWMutex
+	definitions []*PieceDefinition
+}
+
+var pieces = newStandardRegistry()
+
+// 組み込みのピースセット。標準の7種類、12種類のペントミノ、標準のピースを2倍に拡大したビッグピース
+var (
+	StandardPieces  = []TetrominoType{I, O, T, S, Z, J, L}
+	PentominoPieces = mustRegisterPieces(pentominoDefinitions())
+	BigPieces       = mustRegisterPieces(bigDefinitions())
+)
+
+var pieceSets = map[string][]TetrominoType{
+	"standard":  StandardPieces,
+	"pentomino": PentominoPieces,
+	"big":       BigPieces,
+}
+
+func newStandardRegistry() *pieceRegistry {
+	registry := &pieceRegistry{}
+	for _, tetrominoType := range []TetrominoType{I, O, T, S, Z, J, L} {
+		name := tetrominoNames[tetrominoType]
+		registry.definitions = append(registry.definitions, &PieceDefinition{
+			Name:      name,
+			Cell:      name[0],
+			Rotations: tetrominoShapes[tetrominoType],
+			Kicks:     standardKicks,
+		})
+	}
+	return registry
+}
+
+// RegisterPiece はピースの定義を登録し、その種類を返す。同じ名前で同じ定義が登録済みならその種類を返す
+func RegisterPiece(definition PieceDefinition) (TetrominoType, error) {
+	if err := definition.validate(); err != nil {
+		return 0, err
+	}
+	registered := definition.clone()
+	if len(registered.Kicks) == 0 {
+		registered.Kicks = standardKicks
+	}
+
+	pieces.mu.Lock()
+	defer pieces.mu.Unlock()
+	for i, existing := range pieces.definitions {
+		if !strings.EqualFold(existing.Name, registered.Name) {
+			continue
+		}
+		candidate := *registered
+		candidate.Name = existing.Name
+		if reflect.DeepEqual(existing, &candidate) {
+			return TetrominoType(i), nil
+		}
+		return 0, fmt.Errorf("%w: %sは別の定義で登録済みです", ErrInvalidPieceDefinition, registered.Name)
+	}
+	pieces.definitions = append(pieces.definitions, registered)
+	return TetrominoType(len(pieces.definitions) - 1), nil
+}
+
+func mustRegisterPieces(definitions []PieceDefinition) []TetrominoType {
+	types := make([]TetrominoType, len(definitions))
+	for i, definition := range definitions {
+		tetrominoType, err := RegisterPiece(definition)
+		if err != nil {
+			panic(err)
+		}
+		types[i] = tetrominoType
+	}
+	return types
+}
+
+func (d PieceDefinition) validate() error {
+	if d.Name == "" || strings.ContainsAny(d.Name, " \t\r\n") {
+		return fmt.Errorf("%w: 名前%qは使えません", ErrInvalidPieceDefinition, d.Name)
+	}
+	if d.Cell != 0 && (d.Cell <= ' ' || d.Cell > '~' || d.Cell == EmptyCell) {
+		return fmt.Errorf("%w: %s: 表記の文字%qは使えません", ErrInvalidPieceDefinition, d.Name, d.Cell)
+	}
+	if len(d.Rotations) == 0 {
+		return fmt.Errorf("%w: %s: 回転状態がありません", ErrInvalidPieceDefinition, d.Name)
+	}
+	if len(d.KickTable) > 0 && len(d.KickTable) != len(d.Rotations) {
+		return fmt.Errorf("%w: %s: キックの表が%d状態分ですが回転状態は%dです",
+			ErrInvalidPieceDefinition, d.Name, len(d.KickTable), len(d.Rotations))
+	}
+
+	size, blocks := len(d.Rotations[0]), countBlocks(d.Rotations[0])
+	for i, shape := range d.Rotations {
+		if len(shape) != size || size == 0 {
+			return fmt.Errorf("%w: %s: 回転状態%dの大きさが揃っていません", ErrInvalidPieceDefinition, d.Name, i)
+		}
+		for _, row := range shape {
+			if len(row) != size {
+				return fmt.Errorf("%w: %s: 回転状態%dが正方形ではありません", ErrInvalidPieceDefinition, d.Name, i)
+			}
+		}
+		if count := countBlocks(shape); count == 0 || count != blocks {
+			return fmt.Errorf("%w: %s: 回転状態%dのブロック数が%dです", ErrInvalidPieceDefinition, d.Name, i, count)
+		}
+		for j := 0; j < i; j++ {
+			if reflect.DeepEqual(d.Rotations[j], shape) {
+				return fmt.Errorf("%w: %s: 回転状態%dと%dが同じ形です", ErrInvalidPieceDefinition, d.Name, j, i)
+			}
+		}
+	}
+	return nil
+}
+
+func (d PieceDefinition) clone() *PieceDefinition {
+	rotations := make([][][]bool, len(d.Rotations))
+	for i, shape := range d.Rotations {
+		rotations[i] = cloneShape(shape)
+	}
+	var kickTable [][]Point
+	for _, kicks := range d.KickTable {
+		kickTable = append(kickTable, append([]Point(nil), kicks...))
+	}
+	return &PieceDefinition{
+		Name:      d.Name,
+		Cell:      d.Cell,
+		Rotations: rotations,
+		Spawn:     d.Spawn,
+		Kicks:     append([]Point(nil), d.Kicks...),
+		KickTable: kickTable,
+	}
+}
+
+func countBlocks(shape [][]bool) int {
+	count := 0
+	for _, row := range shape {
+		for _, filled := range row {
+			if filled {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func cloneShape(shape [][]bool) [][]bool {
+	cloned := make([][]bool, len(shape))
+	for i := range shape {
+		cloned[i] = make([]bool, len(shape[i]))
+		copy(cloned[i], shape[i])
+	}
+	return cloned
+}
+
+// lookup は登録済みの定義を返す。定義は登録後に変更されないため、ロックの外で読んでよい
+func (t TetrominoType) lookup() (*PieceDefinition, bool) {
+	pieces.mu.RLock()
+	defer pieces.mu.RUnlock()
+	if t < 0 || int(t) >= len(pieces.definitions) {
+		return nil, false
+	}
+	return pieces.definitions[t], true
+}
+
+// IsValid はピースの種類が登録済みかを返す
+func (t TetrominoType) IsValid() bool {
+	_, ok := t.lookup()
+	return ok
+}
+
+func isPieceCell(cell byte) bool {
+	pieces.mu.RLock()
+	defer pieces.mu.RUnlock()
+	for _, definition := range pieces.definitions {
+		if definition.Cell == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePieceSet は"standard+pentomino"のように+でつないだ組み込みのピースセット名を、ピースの種類の一覧に変換する
+func ParsePieceSet(name string) ([]TetrominoType, error) {
+	var types []TetrominoType
+	seen := make(map[TetrominoType]bool)
+	for _, part := range strings.Split(name, "+") {
+		set, exists := pieceSets[strings.ToLower(strings.TrimSpace(part))]
+		if !exists {
+			return nil, fmt.Errorf("%w: ピースセット%q（%s）", ErrInvalidPieceDefinition, part,
+				strings.Join(PieceSetNames(), ", "))
+		}
+		for _, tetrominoType := range set {
+			if !seen[tetrominoType] {
+				seen[tetrominoType] = true
+				types = append(types, tetrominoType)
+			}
+		}
+	}
+	return types, nil
+}
+
+// PieceSetNames は組み込みのピースセット名を名前の順に返す
+func PieceSetNames() []string {
+	names := make([]string, 0, len(pieceSets))
+	for name := range pieceSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RotationsOf は形を時計回りに回転させた状態を、元の形に戻るまで並べる。正方形でない形は回転させない
+func RotationsOf(shape [][]bool) [][][]bool {
+	rotations := [][][]bool{cloneShape(shape)}
+	for _, row := range shape {
+		if len(row) != len(shape) {
+			return rotations
+		}
+	}
+	for {
+		next := rotateClockwise(rotations[len(rotations)-1])
+		if reflect.DeepEqual(next, rotations[0]) || len(rotations) == 4 {
+			return rotations
+		}
+		rotations = append(rotations, next)
+	}
+}
+
+func rotateClockwise(shape [][]bool) [][]bool {
+	size := len(shape)
+	rotated := make([][]bool, size)
+	for y := range rotated {
+		rotated[y] = make([]bool, size)
+		for x := range rotated[y] {
+			rotated[y][x] = shape[size-1-x][y]
+		}
+	}
+	return rotated
+}
+
+// ParseShape は"#"をブロック、"."を空きマスとする行の並びを形に変換する
+func ParseShape(rows []string) ([][]bool, error) {
+	shape := make([][]bool, len(rows))
+	for y, row := range rows {
+		shape[y] = make([]bool, len(row))
+		for x, cell := range row {
+			switch cell {
+			case '#':
+				shape[y][x] = true
+			case EmptyCell:
+			default:
+				return nil, fmt.Errorf("%w: 形の%d行目に不明な文字%qがあります", ErrInvalidPieceDefinition, y+1, cell)
+			}
+		}
+	}
+	return shape, nil
+}
+
+func mustParseShape(rows ...string) [][]bool {
+	shape, err := ParseShape(rows)
+	if err != nil {
+		panic(err)
+	}
+	return shape
+}
+
+// pentominoDefinitions は5マスのピース。名前は一般的な呼び名に5を付け、表記には小文字を使う
+func pentominoDefinitions() []PieceDefinition {
+	shapes := []struct {
+		name string
+		rows []string
+	}{
+		{"F5", []string{".....", "..##.", ".##..", "..#..", "....."}},
+		{"I5", []string{".....", ".....", "#####", ".....", "....."}},
+		{"L5", []string{".....", "....#", ".####", ".....", "....."}},
+		{"N5", []string{".....", "...##", ".###.", ".....", "....."}},
+		{"P5", []string{".....", "..##.", "..##.", "..#..", "....."}},
+		{"T5", []string{".....", ".###.", "..#..", "..#..", "....."}},
+		{"U5", []string{".....", ".#.#.", ".###.", ".....", "....."}},
+		{"V5", []string{".....", ".#...", ".#...", ".###.", "....."}},
+		{"W5", []string{".....", ".#...", ".##..", "..##.", "....."}},
+		{"X5", []string{".....", "..#..", ".###.", "..#..", "....."}},
+		{"Y5", []string{".....", "..#..", ".####", ".....", "....."}},
+		{"Z5", []string{".....", ".##..", "..#..", "..##.", "....."}},
+	}
+	kicks := append(append([]Point(nil), wallKicks...), Point{X: -2, Y: 0}, Point{X: 2, Y: 0})
+
+	definitions := make([]PieceDefinition, len(shapes))
+	for i, shape := range shapes {
+		definitions[i] = PieceDefinition{
+			Name:      shape.name,
+			Cell:      strings.ToLower(shape.name)[0],
+			Rotations: RotationsOf(mustParseShape(shape.rows...)),
+			Kicks:     kicks,
+		}
+	}
+	return definitions
+}
+
+// bigDefinitions は標準のピースを縦横2倍にしたピース。キックも2倍の距離で試す
+func bigDefinitions() []PieceDefinition {
+	kicks := make([]Point, len(wallKicks))
+	for i, kick := range wallKicks {
+		kicks[i] = Point{X: kick.X * 2, Y: kick.Y * 2}
+	}
+
+	definitions := make([]PieceDefinition, 0, len(StandardPieces))
+	for _, tetrominoType := range []TetrominoType{I, O, T, S, Z, J, L} {
+		name := tetrominoNames[tetrominoType]
+		rotations := make([][][]bool, len(tetrominoShapes[tetrominoType]))
+		for i, shape := range tetrominoShapes[tetrominoType] {
+			rotations[i] = scaleShape(shape, 2)
+		}
+		definitions = append(definitions, PieceDefinition{
+			Name:      "Big" + name,
+			Cell:      name[0],
+			Rotations: rotations,
+			Kicks:     kicks,
+		})
+	}
+	return definitions
+}
+
+func scaleShape(shape [][]bool, scale int) [][]bool {
+	scaled := make([][]bool, len(shape)*scale)
+	for y := range scaled {
+		scaled[y] = make([]bool, len(shape)*scale)
+		for x := range scaled[y] {
+			scaled[y][x] = shape[y/scale][x/scale]
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterPiece(t *testing.T) {
+	domino := mustParseShape("##", "..")
+	tests := []struct {
+		name       string
+		definition PieceDefinition
+		wantErr    bool
+	}{
+		{
+			name:       "回転状態を生成した定義",
+			definition: PieceDefinition{Name: "TestDomino", Cell: 'd', Rotations: RotationsOf(domino)},
+		},
+		{
+			name:       "同じ定義の再登録",
+			definition: PieceDefinition{Name: "testdomino", Cell: 'd', Rotations: RotationsOf(domino)},
+		},
+		{
+			name:       "同じ名前で別の定義",
+			definition: PieceDefinition{Name: "TestDomino", Cell: 'e', Rotations: RotationsOf(domino)},
+			wantErr:    true,
+		},
+		{
+			name:       "標準のピースと同じ名前",
+			definition: PieceDefinition{Name: "T", Rotations: RotationsOf(domino)},
+			wantErr:    true,
+		},
+		{name: "名前がない", definition: PieceDefinition{Rotations: RotationsOf(domino)}, wantErr: true},
+		{name: "回転状態がない", definition: PieceDefinition{Name: "TestEmpty"}, wantErr: true},
+		{
+			name:       "正方形でない",
+			definition: PieceDefinition{Name: "TestWide", Rotations: [][][]bool{mustParseShape("##.", "...")}},
+			wantErr:    true,
+		},
+		{
+			name: "回転状態でブロック数が違う",
+			definition: PieceDefinition{Name: "TestGrow", Rotations: [][][]bool{
+				mustParseShape("#.", ".."), mustParseShape("##", ".."),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "同じ回転状態が2つある",
+			definition: PieceDefinition{Name: "TestRepeat", Rotations: [][][]bool{
+				mustParseShape("#.", ".."), mustParseShape("#.", ".."),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "キックの表が回転状態の数と違う",
+			definition: PieceDefinition{
+				Name: "TestKickTable", Rotations: RotationsOf(domino), KickTable: [][]Point{{{X: 1}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "表記に空きマスの文字",
+			definition: PieceDefinition{Name: "TestDot", Cell: EmptyCell, Rotations: RotationsOf(domino)},
+			wantErr:    true,
+		},
+	}
+
+	var first TetrominoType
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RegisterPiece(tt.definition)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPieceDefinition) {
+					t.Errorf("RegisterPiece() error = %v, want %v", err, ErrInvalidPieceDefinition)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RegisterPiece() error = %v", err)
+			}
+			if i == 0 {
+				first = got
+			} else if got != first {
+				t.Errorf("RegisterPiece() = %v, want registered %v", got, first)
+			}
+
+			piece, err := NewTetromino(got, Point{})
+			if err != nil {
+				t.Fatalf("NewTetromino() error = %v", err)
+			}
+			if piece.RotationCount() != 4 || len(piece.GetBlocks()) != 2 {
+				t.Errorf("rotations = %d, blocks = %d, want 4 and 2", piece.RotationCount(), len(piece.GetBlocks()))
+			}
+			if !reflect.DeepEqual(piece.Kicks(), standardKicks) {
+				t.Errorf("Kicks() = %v, want standard kicks", piece.Kicks())
+			}
+		})
+	}
+}
+
+func TestTetromino_KickTable(t *testing.T) {
+	// 回転前の状態ごとにずれを変え、状態1だけは表を空にして Kicks を使う
+	kicks := []Point{{X: 0}, {X: 1}}
+	table := [][]Point{{{X: -1}}, nil, {{X: 2}, {X: -2}}, {{Y: 1}}}
+	tetrominoType, err := RegisterPiece(PieceDefinition{
+		Name:      "TestKickTableL",
+		Rotations: RotationsOf(mustParseShape(".#.", ".##", "...")),
+		Kicks:     kicks,
+		KickTable: table,
+	})
+	if err != nil {
+		t.Fatalf("RegisterPiece() error = %v", err)
+	}
+
+	piece, err := NewTetromino(tetrominoType, Point{})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	want := [][]Point{table[0], kicks, table[2], table[3]}
+	for rotation, expected := range want {
+		if got := piece.Kicks(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("rotation %d: Kicks() = %v, want %v", rotation, got, expected)
+		}
+		if err := piece.Rotate(); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+	}
+}
+
+func TestBuiltinPieceSets(t *testing.T) {
+	tests := []struct {
+		name       string
+		pieces     []TetrominoType
+		wantBlocks int
+	}{
+		{name: "標準", pieces: StandardPieces, wantBlocks: 4},
+		{name: "ペントミノ", pieces: PentominoPieces, wantBlocks: 5},
+		{name: "ビッグ", pieces: BigPieces, wantBlocks: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, _ := NewBoard(BoardWidth, BoardHeight)
+			for _, pieceType := range tt.pieces {
+				piece, err := SpawnTetromino(pieceType, BoardWidth)
+				if err != nil {
+					t.Fatalf("SpawnTetromino(%v) error = %v", pieceType, err)
+				}
+				if len(piece.GetBlocks()) != tt.wantBlocks {
+					t.Errorf("%v blocks = %d, want %d", pieceType, len(piece.GetBlocks()), tt.wantBlocks)
+				}
+				for i := 0; i < piece.RotationCount(); i++ {
+					if !board.CanPlaceTetromino(piece) {
+						t.Errorf("%v rotation %d does not fit at spawn: %v", pieceType, i, piece.GetBlocks())
+					}
+					if err := piece.Rotate(); err != nil {
+						t.Fatalf("Rotate() error = %v", err)
+					}
+				}
+				if piece.Rotation() != 0 {
+					t.Errorf("%v rotation after a full turn = %d, want 0", pieceType, piece.Rotation())
+				}
+			}
+		})
+	}
+}
+
+func TestParsePieceSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "標準", input: "standard", want: 7},
+		{name: "組み合わせ", input: "standard+Pentomino", want: 19},
+		{name: "重複は1回だけ", input: "big+big", want: 7},
+		{name: "不明なセット", input: "standard+hexomino", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePieceSet(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPieceDefinition) {
+					t.Errorf("ParsePieceSet(%q) error = %v, want %v", tt.input, err, ErrInvalidPieceDefinition)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePieceSet(%q) error = %v", tt.input, err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParsePieceSet(%q) = %d pieces, want %d", tt.input, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestRotationsOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape []string
+		want  int
+	}{
+		{name: "点対称でない形は4状態", shape: []string{".#.", "###", "..."}, want: 4},
+		{name: "棒は2状態", shape: []string{"...", "###", "..."}, want: 2},
+		{name: "十字は1状態", shape: []string{".#.", "###", ".#."}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rotations := RotationsOf(mustParseShape(tt.shape...))
+			if len(rotations) != tt.want {
+				t.Errorf("RotationsOf() = %d states, want %d", len(rotations), tt.want)
+			}
+		})
+	}
+}
+
+func TestBoard_NotationWithPieceSets(t *testing.T) {
+	board, _ := NewBoard(8, 4)
+	pentomino, _ := NewTetromino(PentominoPieces[9], Point{X: -1, Y: -1})
+	big, _ := NewTetromino(BigPieces[1], Point{X: 2, Y: -2})
+	for _, piece := range []*Tetromino{pentomino, big} {
+		if err := board.PlaceTetromino(piece); err != nil {
+			t.Fatalf("PlaceTetromino(%v) error = %v", piece.Type, err)
+		}
+	}
+
+	want := ".x..OOOO\nxxx.OOOO\n.x..OOOO\n....OOOO"
+	if board.String() != want {
+		t.Fatalf("Board.String() =\n%s\nwant\n%s", board, want)
+	}
+	if got := mustParseBoard(t, want).String(); got != want {
+		t.Errorf("ParseBoard().String() = %q, want %q", got, want)
+	}
+}
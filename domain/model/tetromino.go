@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 type TetrominoType int
@@ -24,10 +25,11 @@ var (
 )
 
 type Tetromino struct {
-	Type     TetrominoType
-	Shape    [][]bool
-	Position Point
-	size     int
+	Type       TetrominoType
+	Shape      [][]bool
+	Position   Point
+	size       int
+	definition *PieceDefinition
 }
 
 var tetrominoShapes = map[TetrominoType][][][]bool{
@@ -161,28 +163,68 @@ var tetrominoShapes = map[TetrominoType][][][]bool{
 	},
 }
 
+var tetrominoNames = [...]string{I: "I", O: "O", T: "T", S: "S", Z: "Z", J: "J", L: "L"}
+
+func (t TetrominoType) String() string {
+	definition, ok := t.lookup()
+	if !ok {
+		return fmt.Sprintf("TetrominoType(%d)", int(t))
+	}
+	return definition.Name
+}
+
+// ParseTetrominoType は"T"のような登録済みのピース名を型に変換する。大文字と小文字は区別しない
+func ParseTetrominoType(name string) (TetrominoType, error) {
+	pieces.mu.RLock()
+	defer pieces.mu.RUnlock()
+	for t, definition := range pieces.definitions {
+		if strings.EqualFold(name, definition.Name) {
+			return TetrominoType(t), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidTetrominoType, name)
+}
+
 func NewTetromino(tetrominoType TetrominoType, position Point) (*Tetromino, error) {
-	if tetrominoType < I || tetrominoType > L {
+	definition, ok := tetrominoType.lookup()
+	if !ok {
 		return nil, fmt.Errorf("%w: %d", ErrInvalidTetrominoType, tetrominoType)
 	}
 
-	shapes, exists := tetrominoShapes[tetrominoType]
-	if !exists || len(shapes) == 0 {
-		return nil, fmt.Errorf("%w: テトロミノ形状が見つかりません", ErrInvalidTetrominoType)
+	shape := cloneShape(definition.Rotations[0])
+	return &Tetromino{
+		Type:       tetrominoType,
+		Shape:      shape,
+		Position:   position,
+		size:       len(shape),
+		definition: definition,
+	}, nil
+}
+
+// SpawnTetromino は幅boardWidthのボードの上端中央に、定義の出現位置のずれを加えてピースを出現させる
+func SpawnTetromino(tetrominoType TetrominoType, boardWidth int) (*Tetromino, error) {
+	definition, ok := tetrominoType.lookup()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidTetrominoType, tetrominoType)
 	}
+	size := len(definition.Rotations[0])
+	return NewTetromino(tetrominoType, Point{X: boardWidth/2 - size/2, Y: 0}.Add(definition.Spawn))
+}
 
-	shape := make([][]bool, len(shapes[0]))
+func (t *Tetromino) Clone() *Tetromino {
+	shape := make([][]bool, len(t.Shape))
 	for i := range shape {
-		shape[i] = make([]bool, len(shapes[0][i]))
-		copy(shape[i], shapes[0][i])
+		shape[i] = make([]bool, len(t.Shape[i]))
+		copy(shape[i], t.Shape[i])
 	}
 
 	return &Tetromino{
-		Type:     tetrominoType,
-		Shape:    shape,
-		Position: position,
-		size:     4,
-	}, nil
+		Type:       t.Type,
+		Shape:      shape,
+		Position:   t.Position,
+		size:       t.size,
+		definition: t.definition,
+	}
 }
 
 func (t *Tetromino) GetBlocks() []Point {
@@ -207,8 +249,8 @@ func (t *Tetromino) Move(delta Point) error {
 }
 
 func (t *Tetromino) Rotate() error {
-	shapes, exists := tetrominoShapes[t.Type]
-	if !exists {
+	shapes := t.rotations()
+	if shapes == nil {
 		return fmt.Errorf("%w: テトロミノタイプが無効です", ErrRotationFailed)
 	}
 
@@ -232,6 +274,55 @@ func (t *Tetromino) Rotate() error {
 	return fmt.Errorf("%w: 現在の形状が見つかりません", ErrRotationFailed)
 }
 
+func (t *Tetromino) Rotation() int {
+	for i, shape := range t.rotations() {
+		if t.shapeEquals(shape) {
+			return i
+		}
+	}
+	return 0
+}
+
+func (t *Tetromino) RotationCount() int {
+	return len(t.rotations())
+}
+
+// Kicks は現在の回転状態から時計回りに回転してぶつかったときに、順に試す位置のずれを返す
+func (t *Tetromino) Kicks() []Point {
+	definition := t.pieceDefinition()
+	if definition == nil {
+		return nil
+	}
+	if rotation := t.Rotation(); rotation < len(definition.KickTable) && len(definition.KickTable[rotation]) > 0 {
+		return definition.KickTable[rotation]
+	}
+	return definition.Kicks
+}
+
+// Cell はボード表記でこのピースのブロックを表す文字を返す
+func (t *Tetromino) Cell() byte {
+	if definition := t.pieceDefinition(); definition != nil {
+		return definition.Cell
+	}
+	return 0
+}
+
+func (t *Tetromino) rotations() [][][]bool {
+	if definition := t.pieceDefinition(); definition != nil {
+		return definition.Rotations
+	}
+	return nil
+}
+
+// pieceDefinition は NewTetromino を使わずに作ったピースでも定義を引けるよう、なければ種類から探す
+func (t *Tetromino) pieceDefinition() *PieceDefinition {
+	if t.definition != nil {
+		return t.definition
+	}
+	definition, _ := t.Type.lookup()
+	return definition
+}
+
 func (t *Tetromino) shapeEquals(shape [][]bool) bool {
 	if len(t.Shape) != len(shape) {
 		return false
@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -39,8 +40,8 @@ func TestNewTetromino(t *testing.T) {
 			errorType:     ErrInvalidTetrominoType,
 		},
 		{
-			name:          "無効なテトロミノタイプ（範囲外の値）",
-			tetrominoType: TetrominoType(10),
+			name:          "無効なテトロミノタイプ（未登録の値）",
+			tetrominoType: TetrominoType(1000),
 			position:      Point{X: 0, Y: 0},
 			expectError:   true,
 			errorType:     ErrInvalidTetrominoType,
@@ -262,3 +263,95 @@ func TestTetromino_Rotate(t *testing.T) {
 		})
 	}
 }
+
+func TestTetromino_Rotation(t *testing.T) {
+	tests := []struct {
+		name          string
+		tetrominoType TetrominoType
+		rotations     int
+		expected      int
+		expectedCount int
+	}{
+		{name: "T初期状態", tetrominoType: T, rotations: 0, expected: 0, expectedCount: 4},
+		{name: "Tを3回転", tetrominoType: T, rotations: 3, expected: 3, expectedCount: 4},
+		{name: "Tを4回転で元に戻る", tetrominoType: T, rotations: 4, expected: 0, expectedCount: 4},
+		{name: "Iを1回転", tetrominoType: I, rotations: 1, expected: 1, expectedCount: 2},
+		{name: "Oは常に0", tetrominoType: O, rotations: 2, expected: 0, expectedCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tetromino, err := NewTetromino(tt.tetrominoType, Point{X: 0, Y: 0})
+			if err != nil {
+				t.Fatalf("NewTetromino() error = %v", err)
+			}
+
+			for i := 0; i < tt.rotations; i++ {
+				if err := tetromino.Rotate(); err != nil {
+					t.Fatalf("Rotate() error = %v", err)
+				}
+			}
+
+			if rotation := tetromino.Rotation(); rotation != tt.expected {
+				t.Errorf("Tetromino.Rotation() = %d, want %d", rotation, tt.expected)
+			}
+
+			if count := tetromino.RotationCount(); count != tt.expectedCount {
+				t.Errorf("Tetromino.RotationCount() = %d, want %d", count, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestTetromino_Clone(t *testing.T) {
+	piece, _ := NewTetromino(T, Point{X: 3, Y: 0})
+	_ = piece.Rotate()
+
+	clone := piece.Clone()
+	if clone.Type != piece.Type || clone.Position != piece.Position || clone.Rotation() != piece.Rotation() {
+		t.Fatalf("Clone() = %+v, want %+v", clone, piece)
+	}
+
+	_ = clone.Rotate()
+	_ = clone.Move(Point{X: 1, Y: 0})
+	if piece.Rotation() != 1 || piece.Position.X != 3 {
+		t.Error("modifying the clone should not affect the original piece")
+	}
+}
+
+func TestParseTetrominoType(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    TetrominoType
+		wantErr bool
+	}{
+		{name: "大文字", input: "T", want: T},
+		{name: "小文字", input: "i", want: I},
+		{name: "最後の種類", input: "L", want: L},
+		{name: "ペントミノ", input: "X5", want: PentominoPieces[9]},
+		{name: "不明な名前", input: "X", wantErr: true},
+		{name: "空文字", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTetrominoType(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTetrominoType) {
+					t.Errorf("ParseTetrominoType(%q) error = %v, want %v", tt.input, err, ErrInvalidTetrominoType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTetrominoType(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTetrominoType(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if got.String() != strings.ToUpper(tt.input) {
+				t.Errorf("String() = %q, want %q", got.String(), strings.ToUpper(tt.input))
+			}
+		})
+	}
+}
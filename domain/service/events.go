@@ -0,0 +1,114 @@
+package service
+
+import "tetris/domain/model"
+
+// Event はGameServiceで起きた出来事。具体的な型で判別する
+type Event interface {
+	EventName() string
+}
+
+// PieceSpawned は新しいピースが出現した。最初のピースはGameServiceの作成時に出現するため通知されない
+type PieceSpawned struct {
+	Type     model.TetrominoType
+	Position model.Point
+}
+
+// PieceMoved はピースが移動した。ハードドロップは落下先までの1回の移動として通知される
+type PieceMoved struct {
+	Type     model.TetrominoType
+	From     model.Point
+	To       model.Point
+	HardDrop bool
+}
+
+// PieceRotated はピースが回転した。FromとToが異なる場合はウォールキックで位置がずれた
+type PieceRotated struct {
+	Type     model.TetrominoType
+	Rotation int
+	From     model.Point
+	To       model.Point
+}
+
+// PieceLocked はピースが固定された。Pieceは固定された位置のピースの複製で、Clearはこの固定による消去の判定
+type PieceLocked struct {
+	Piece *model.Tetromino
+	Clear ClearInfo
+}
+
+// LinesCleared はラインが消去された。Rowsは消去前のボードでの行番号
+type LinesCleared struct {
+	Rows  []int
+	Clear ClearInfo
+}
+
+type LevelUp struct {
+	Level int
+}
+
+// HoldUsed はホールドを使った。Heldはホールドしたピース、Currentは代わりに出現したピース
+type HoldUsed struct {
+	Held    model.TetrominoType
+	Current model.TetrominoType
+}
+
+type GameOver struct {
+	Cause TopOutCause
+}
+
+func (PieceSpawned) EventName() string { return "piece-spawned" }
+func (PieceMoved) EventName() string   { return "piece-moved" }
+func (PieceRotated) EventName() string { return "piece-rotated" }
+func (PieceLocked) EventName() string  { return "piece-locked" }
+func (LinesCleared) EventName() string { return "lines-cleared" }
+func (LevelUp) EventName() string      { return "level-up" }
+func (HoldUsed) EventName() string     { return "hold-used" }
+func (GameOver) EventName() string     { return "game-over" }
+
+type EventHandler func(event Event)
+
+type subscription struct {
+	id      int
+	handler EventHandler
+}
+
+// EventBus は出来事を購読者に配送する。操作の途中で起きた出来事はためておき、操作が終わってから
+// 起きた順に配送するため、購読者はゲッターで操作後の状態を読める。GameServiceと同じく並行には使えない
+type EventBus struct {
+	subscriptions []subscription
+	nextID        int
+	pending       []Event
+}
+
+// Subscribe は購読者を登録し、登録を解除する関数を返す
+func (b *EventBus) Subscribe(handler EventHandler) func() {
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, subscription{id: id, handler: handler})
+
+	return func() {
+		for i, s := range b.subscriptions {
+			if s.id == id {
+				b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (b *EventBus) record(event Event) {
+	if len(b.subscriptions) == 0 {
+		return
+	}
+	b.pending = append(b.pending, event)
+}
+
+func (b *EventBus) flush() {
+	for len(b.pending) > 0 {
+		event := b.pending[0]
+		b.pending = b.pending[1:]
+		for _, s := range b.subscriptions {
+			s.handler(event)
+		}
+	}
+	b.pending = nil
+}
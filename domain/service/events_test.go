@@ -0,0 +1,176 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"tetris/domain/model"
+)
+
+// prepareLineClear は現在のピースを落とすと最下段がちょうど1ライン消えるように、最下段の残りを埋める
+func prepareLineClear(t *testing.T, g *GameService) {
+	t.Helper()
+
+	landed := g.currentPiece.Clone()
+	for g.board.CanPlaceTetromino(landed) {
+		landed.Position.Y++
+	}
+	landed.Position.Y--
+
+	holes := make(map[int]bool)
+	for _, block := range landed.GetBlocks() {
+		if block.Y == g.board.Height-1 {
+			holes[block.X] = true
+		}
+	}
+	for x := 0; x < g.board.Width; x++ {
+		if !holes[x] {
+			if err := g.board.SetBlock(model.Point{X: x, Y: g.board.Height - 1}, true); err != nil {
+				t.Fatalf("SetBlock() error = %v", err)
+			}
+		}
+	}
+}
+
+func TestGameService_Events(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, g *GameService)
+		action  func(g *GameService) error
+		want    []string
+	}{
+		{
+			name:   "左移動",
+			action: func(g *GameService) error { return g.MovePiece(model.Point{X: -1}) },
+			want:   []string{"piece-moved"},
+		},
+		{
+			name:   "回転",
+			action: func(g *GameService) error { return g.RotatePiece() },
+			want:   []string{"piece-rotated"},
+		},
+		{
+			name:   "自然落下",
+			action: func(g *GameService) error { return g.Update() },
+			want:   []string{"piece-moved"},
+		},
+		{
+			name:   "ハードドロップ",
+			action: func(g *GameService) error { return g.DropPiece() },
+			want:   []string{"piece-moved", "piece-locked", "piece-spawned"},
+		},
+		{
+			name:    "ライン消去",
+			prepare: prepareLineClear,
+			action:  func(g *GameService) error { return g.DropPiece() },
+			want:    []string{"piece-moved", "piece-locked", "lines-cleared", "piece-spawned"},
+		},
+		{
+			name: "レベルアップ",
+			prepare: func(t *testing.T, g *GameService) {
+				g.lines = LinesPerLevel - 1
+				prepareLineClear(t, g)
+			},
+			action: func(g *GameService) error { return g.DropPiece() },
+			want:   []string{"piece-moved", "piece-locked", "lines-cleared", "level-up", "piece-spawned"},
+		},
+		{
+			name:   "おじゃまラインでゲームオーバー",
+			action: func(g *GameService) error { return g.AddGarbage(model.BoardHeight) },
+			want:   []string{"game-over"},
+		},
+		{
+			name: "壁で動けない移動は通知しない",
+			prepare: func(_ *testing.T, g *GameService) {
+				for i := 0; i < g.board.Width; i++ {
+					_ = g.MovePiece(model.Point{X: -1})
+				}
+			},
+			action: func(g *GameService) error {
+				_ = g.MovePiece(model.Point{X: -1})
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(GameOptions{Seed: 1})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			if tt.prepare != nil {
+				tt.prepare(t, g)
+			}
+
+			var got []string
+			g.Subscribe(func(event Event) {
+				got = append(got, event.EventName())
+			})
+			if err := tt.action(g); err != nil {
+				t.Fatalf("action error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameService_EventsAfterOperation(t *testing.T) {
+	g, err := NewGameServiceWithOptions(GameOptions{Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+	prepareLineClear(t, g)
+	dropped := g.currentPiece.Type
+
+	var locked *PieceLocked
+	var cleared *LinesCleared
+	g.Subscribe(func(event Event) {
+		switch e := event.(type) {
+		case PieceLocked:
+			locked = &e
+			// 購読者は操作が終わった後の状態を読める
+			if g.GetPiecesLocked() != 1 || g.GetLines() != 1 {
+				t.Errorf("state in handler: pieces=%d lines=%d, want 1 and 1", g.GetPiecesLocked(), g.GetLines())
+			}
+		case LinesCleared:
+			cleared = &e
+		}
+	})
+
+	if err := g.DropPiece(); err != nil {
+		t.Fatalf("DropPiece() error = %v", err)
+	}
+
+	if locked == nil || locked.Piece.Type != dropped || locked.Clear.Type != ClearSingle {
+		t.Errorf("PieceLocked = %+v, want %v single", locked, dropped)
+	}
+	if cleared == nil || !reflect.DeepEqual(cleared.Rows, []int{model.BoardHeight - 1}) {
+		t.Errorf("LinesCleared = %+v, want row %d", cleared, model.BoardHeight-1)
+	}
+}
+
+func TestGameService_Unsubscribe(t *testing.T) {
+	g, err := NewGameServiceWithOptions(GameOptions{Seed: 1})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+
+	first, second := 0, 0
+	unsubscribe := g.Subscribe(func(Event) { first++ })
+	g.Subscribe(func(Event) { second++ })
+
+	if err := g.Update(); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	unsubscribe()
+	if err := g.Update(); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if first != 1 || second != 2 {
+		t.Errorf("handler calls = %d, %d, want 1, 2", first, second)
+	}
+}
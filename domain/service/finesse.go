@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"tetris/domain/model"
+)
+
+var ErrNoFinessePath = errors.New("最終位置までの入力手順が見つかりません")
+
+type finesseState struct {
+	x, y, rotation int
+}
+
+// MinimalInputs は空のフィールドで、出現直後のピースを最終位置と同じ列・向きへ運ぶのに必要な
+// 左右移動と回転の最小回数を返す。高さは問わないため、ハードドロップとソフトドロップは数えない
+func MinimalInputs(width, height int, spawn, final *model.Tetromino) (int, error) {
+	board, err := model.NewBoard(width, height)
+	if err != nil {
+		return 0, fmt.Errorf("フィネス計算用ボード作成エラー: %w", err)
+	}
+
+	target := footprint(final)
+	start := spawn.Clone()
+	if !board.CanPlaceTetromino(start) {
+		return 0, fmt.Errorf("%w: 出現位置に置けません", ErrNoFinessePath)
+	}
+
+	visited := map[finesseState]bool{stateOfPiece(start): true}
+	queue := []*model.Tetromino{start}
+	distances := map[finesseState]int{stateOfPiece(start): 0}
+
+	for len(queue) > 0 {
+		piece := queue[0]
+		queue = queue[1:]
+		distance := distances[stateOfPiece(piece)]
+
+		if footprint(piece) == target {
+			return distance, nil
+		}
+
+		for _, next := range finesseMoves(board, piece) {
+			state := stateOfPiece(next)
+			if visited[state] {
+				continue
+			}
+			visited[state] = true
+			distances[state] = distance + 1
+			queue = append(queue, next)
+		}
+	}
+
+	return 0, ErrNoFinessePath
+}
+
+func finesseMoves(board *model.Board, piece *model.Tetromino) []*model.Tetromino {
+	var moves []*model.Tetromino
+	for _, dx := range []int{-1, 1} {
+		shifted := piece.Clone()
+		shifted.Position.X += dx
+		if board.CanPlaceTetromino(shifted) {
+			moves = append(moves, shifted)
+		}
+	}
+
+	rotated := piece.Clone()
+	if ok, err := TryRotate(board, rotated); err == nil && ok {
+		moves = append(moves, rotated)
+	}
+	return moves
+}
+
+func stateOfPiece(piece *model.Tetromino) finesseState {
+	return finesseState{x: piece.Position.X, y: piece.Position.Y, rotation: piece.Rotation()}
+}
+
+// footprint は高さを除いたブロックの形と列を表す。向きが違っても同じ形になる配置は同一視する
+func footprint(piece *model.Tetromino) string {
+	blocks := piece.GetBlocks()
+	top, bottom := blocks[0].Y, blocks[0].Y
+	for _, block := range blocks {
+		top = min(top, block.Y)
+		bottom = max(bottom, block.Y)
+	}
+
+	grid := make([][]int, bottom-top+1)
+	for _, block := range blocks {
+		row := block.Y - top
+		grid[row] = append(grid[row], block.X)
+	}
+	return fmt.Sprint(grid)
+}
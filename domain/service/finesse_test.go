@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"tetris/domain/model"
+)
+
+func TestMinimalInputs(t *testing.T) {
+	tests := []struct {
+		name      string
+		pieceType model.TetrominoType
+		rotations int
+		dx        int
+		dy        int
+		want      int
+	}{
+		{name: "出現位置のまま落とす", pieceType: model.O, want: 0},
+		{name: "高さは問わない", pieceType: model.T, dy: 15, want: 0},
+		{name: "左へ3マス", pieceType: model.O, dx: -3, want: 3},
+		{name: "右へ2マス", pieceType: model.L, dx: 2, want: 2},
+		{name: "時計回りに3回", pieceType: model.T, rotations: 3, want: 3},
+		{name: "回転と移動", pieceType: model.J, rotations: 1, dx: 3, dy: 10, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spawn, err := model.NewTetromino(tt.pieceType, model.Point{X: 3})
+			if err != nil {
+				t.Fatalf("NewTetromino() error = %v", err)
+			}
+			final := spawn.Clone()
+			for i := 0; i < tt.rotations; i++ {
+				if err := final.Rotate(); err != nil {
+					t.Fatalf("Rotate() error = %v", err)
+				}
+			}
+			final.Position.X += tt.dx
+			final.Position.Y += tt.dy
+
+			got, err := MinimalInputs(10, 20, spawn, final)
+			if err != nil {
+				t.Fatalf("MinimalInputs() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MinimalInputs() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinimalInputs_LargePieces(t *testing.T) {
+	tests := []struct {
+		name      string
+		piece     string
+		width     int
+		rotations int
+		dx        int
+		want      int
+	}{
+		{name: "X5は回転しても同じ形", piece: "X5", width: 10, rotations: 1, want: 0},
+		{name: "縦のI5を左端へ", piece: "I5", width: 10, rotations: 1, dx: -5, want: 6},
+		{name: "V5を180度回して右へ", piece: "V5", width: 10, rotations: 2, dx: 2, want: 4},
+		{name: "BigOを右へ2マス", piece: "BigO", width: 20, dx: 2, want: 2},
+		{name: "縦のBigIを左へ", piece: "BigI", width: 20, rotations: 1, dx: -3, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pieceType, err := model.ParseTetrominoType(tt.piece)
+			if err != nil {
+				t.Fatalf("ParseTetrominoType() error = %v", err)
+			}
+			spawn, err := model.SpawnTetromino(pieceType, tt.width)
+			if err != nil {
+				t.Fatalf("SpawnTetromino() error = %v", err)
+			}
+			final := spawn.Clone()
+			for i := 0; i < tt.rotations; i++ {
+				if err := final.Rotate(); err != nil {
+					t.Fatalf("Rotate() error = %v", err)
+				}
+			}
+			final.Position.X += tt.dx
+			final.Position.Y += 10
+
+			got, err := MinimalInputs(tt.width, 40, spawn, final)
+			if err != nil {
+				t.Fatalf("MinimalInputs() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MinimalInputs() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinimalInputs_Unreachable(t *testing.T) {
+	spawn, err := model.NewTetromino(model.O, model.Point{X: 3})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	final := spawn.Clone()
+	final.Position.X = 20
+
+	if _, err := MinimalInputs(10, 20, spawn, final); !errors.Is(err, ErrNoFinessePath) {
+		t.Errorf("MinimalInputs() error = %v, want %v", err, ErrNoFinessePath)
+	}
+}
+
+func TestFootprint_TallPiece(t *testing.T) {
+	piece, err := model.NewTetromino(model.BigPieces[0], model.Point{X: 0, Y: 3})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	if err := piece.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	// 縦向きのBigIは8段あり、4段目より下のブロックも形に含める
+	want := "[[4 5] [4 5] [4 5] [4 5] [4 5] [4 5] [4 5] [4 5]]"
+	if got := footprint(piece); got != want {
+		t.Errorf("footprint() = %s, want %s", got, want)
+	}
+}
@@ -4,50 +4,240 @@ import (
 	"errors"
 	"fmt"
 	"math/rand/v2"
+	"slices"
 	"tetris/domain/model"
 )
 
 var (
-	ErrGameOver    = errors.New("ゲームが終了しています")
-	ErrInvalidMove = errors.New("無効な移動です")
-	ErrNoPiece     = errors.New("アクティブなピースがありません")
+	ErrGameOver      = errors.New("ゲームが終了しています")
+	ErrInvalidMove   = errors.New("無効な移動です")
+	ErrNoPiece       = errors.New("アクティブなピースがありません")
+	ErrInvalidOption = errors.New("無効なゲームオプションです")
+	// ErrHoldUnavailable はホールドが無効か、このピースで既にホールドを使った
+	ErrHoldUnavailable = errors.New("ホールドできません")
 )
 
+const LinesPerLevel = 10
+
+type ClearType int
+
+const (
+	ClearNone ClearType = iota
+	ClearSingle
+	ClearDouble
+	ClearTriple
+	ClearTetris
+	ClearTSpin
+	ClearTSpinSingle
+	ClearTSpinDouble
+	ClearTSpinTriple
+)
+
+// TopOutCause はゲームオーバーになった原因
+type TopOutCause int
+
+const (
+	TopOutNone TopOutCause = iota
+	// TopOutLockOut は固定したブロックが最上段に残った
+	TopOutLockOut
+	// TopOutBlockOut は次のピースの出現位置が塞がれていた
+	TopOutBlockOut
+	// TopOutGarbage はおじゃまラインのせり上がりで押し出された
+	TopOutGarbage
+)
+
+func (c TopOutCause) String() string {
+	switch c {
+	case TopOutLockOut:
+		return "lock-out"
+	case TopOutBlockOut:
+		return "block-out"
+	case TopOutGarbage:
+		return "garbage"
+	default:
+		return "none"
+	}
+}
+
+type ClearInfo struct {
+	Type       ClearType
+	Lines      int
+	TSpin      bool
+	Combo      int
+	BackToBack bool
+}
+
+var tSpinCorners = []model.Point{
+	{X: 0, Y: 1},
+	{X: 2, Y: 1},
+	{X: 0, Y: 3},
+	{X: 2, Y: 3},
+}
+
+type GameOptions struct {
+	StartLevel int
+	MaxLevel   int
+	Seed       uint64
+	// Width と Height はボードの大きさ。0なら Board の大きさ、Board もなければ標準の10x20になる
+	Width  int
+	Height int
+	// Board は開始時のボード。nilなら空のボードから始める
+	Board *model.Board
+	// Sequence を指定すると、ピースはランダムではなくこの順番で出現し、使い切ると出現しなくなる
+	Sequence []model.TetrominoType
+	// Hold はホールド操作を有効にする。InitialHoldは開始時にホールドしているピース
+	Hold        bool
+	InitialHold *model.TetrominoType
+	// Pieces はランダムに出現するピースの種類。空なら標準の7種類
+	Pieces []model.TetrominoType
+	// Preview は先に引いておくネクストの数。0なら1つ
+	Preview int
+}
+
 type GameService struct {
 	board        *model.Board
 	currentPiece *model.Tetromino
-	nextPiece    *model.Tetromino
+	queue        []*model.Tetromino
+	preview      int
 	score        int
 	lines        int
 	level        int
+	startLevel   int
+	maxLevel     int
+	garbageLines int
 	gameOver     bool
+	rng          *rand.Rand
+	pcg          *rand.PCG
+	lastRotated  bool
+	combo        int
+	backToBack   bool
+	piecesLocked int
+	lastClear    ClearInfo
+	topOutCause  TopOutCause
+	events       EventBus
+	sequence     []model.TetrominoType
+	drawn        int
+	holdEnabled  bool
+	holdPiece    *model.Tetromino
+	holdUsed     bool
+	pieces       []model.TetrominoType
 }
 
 func NewGameService() (*GameService, error) {
-	board, err := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	return NewGameServiceWithOptions(GameOptions{})
+}
+
+func NewGameServiceWithOptions(options GameOptions) (*GameService, error) {
+	if options.StartLevel == 0 {
+		options.StartLevel = 1
+	}
+	if options.StartLevel < 1 || options.MaxLevel < 0 {
+		return nil, fmt.Errorf("%w: 開始レベル=%d, 最大レベル=%d", ErrInvalidOption, options.StartLevel, options.MaxLevel)
+	}
+	if options.MaxLevel > 0 && options.StartLevel > options.MaxLevel {
+		return nil, fmt.Errorf("%w: 開始レベル%dが最大レベル%dを超えています", ErrInvalidOption, options.StartLevel, options.MaxLevel)
+	}
+	if err := options.validatePieces(); err != nil {
+		return nil, err
+	}
+
+	board, err := options.newBoard()
 	if err != nil {
-		return nil, fmt.Errorf("ボード作成エラー: %w", err)
+		return nil, err
 	}
 
 	service := &GameService{
-		board:    board,
-		score:    0,
-		lines:    0,
-		level:    1,
-		gameOver: false,
+		board:       board,
+		score:       0,
+		lines:       0,
+		level:       options.StartLevel,
+		startLevel:  options.StartLevel,
+		maxLevel:    options.MaxLevel,
+		gameOver:    false,
+		combo:       -1,
+		sequence:    options.Sequence,
+		holdEnabled: options.Hold,
+		pieces:      options.Pieces,
+		preview:     max(options.Preview, 1),
+	}
+	if len(service.pieces) == 0 {
+		service.pieces = model.StandardPieces
+	}
+
+	service.pcg, service.rng = newRandom(options.Seed)
+
+	if options.InitialHold != nil {
+		if service.holdPiece, err = service.spawnPiece(*options.InitialHold); err != nil {
+			return nil, fmt.Errorf("ホールドピース生成エラー: %w", err)
+		}
 	}
 
 	if err := service.spawnNewPiece(); err != nil {
 		return nil, fmt.Errorf("初期ピース生成エラー: %w", err)
 	}
 
-	if err := service.generateNextPiece(); err != nil {
+	if err := service.fillQueue(); err != nil {
 		return nil, fmt.Errorf("次ピース生成エラー: %w", err)
 	}
 
+	if !board.CanPlaceTetromino(service.currentPiece) {
+		service.topOut(TopOutBlockOut)
+	}
+
 	return service, nil
 }
 
+func (o GameOptions) validatePieces() error {
+	for i, pieceType := range o.Sequence {
+		if !pieceType.IsValid() {
+			return fmt.Errorf("%w: %d番目のピース%d", ErrInvalidOption, i+1, pieceType)
+		}
+	}
+	for _, pieceType := range o.Pieces {
+		if !pieceType.IsValid() {
+			return fmt.Errorf("%w: 出現するピース%d", ErrInvalidOption, pieceType)
+		}
+	}
+	if o.InitialHold != nil {
+		if !o.Hold {
+			return fmt.Errorf("%w: ホールドが無効なのにホールドピースが指定されています", ErrInvalidOption)
+		}
+		if !o.InitialHold.IsValid() {
+			return fmt.Errorf("%w: ホールドピース%d", ErrInvalidOption, *o.InitialHold)
+		}
+	}
+	return nil
+}
+
+func (o GameOptions) newBoard() (*model.Board, error) {
+	width, height := o.Width, o.Height
+	if o.Board != nil {
+		if (width != 0 && width != o.Board.Width) || (height != 0 && height != o.Board.Height) {
+			return nil, fmt.Errorf("%w: ボードの大きさ%dx%dが指定の%dx%dと違います",
+				ErrInvalidOption, o.Board.Width, o.Board.Height, width, height)
+		}
+		width, height = o.Board.Width, o.Board.Height
+	}
+	if width == 0 {
+		width = model.BoardWidth
+	}
+	if height == 0 {
+		height = model.BoardHeight
+	}
+	if err := model.ValidateBoardSize(width, height); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOption, err)
+	}
+
+	if o.Board != nil {
+		return o.Board.Clone(), nil
+	}
+	board, err := model.NewBoard(width, height)
+	if err != nil {
+		return nil, fmt.Errorf("ボード作成エラー: %w", err)
+	}
+	return board, nil
+}
+
 func (g *GameService) GetBoard() *model.Board {
 	return g.board
 }
@@ -57,7 +247,19 @@ func (g *GameService) GetCurrentPiece() *model.Tetromino {
 }
 
 func (g *GameService) GetNextPiece() *model.Tetromino {
-	return g.nextPiece
+	if len(g.queue) == 0 {
+		return nil
+	}
+	return g.queue[0]
+}
+
+// GetQueue は出現する順に並べたネクストの種類を返す
+func (g *GameService) GetQueue() []model.TetrominoType {
+	types := make([]model.TetrominoType, len(g.queue))
+	for i, piece := range g.queue {
+		types[i] = piece.Type
+	}
+	return types
 }
 
 func (g *GameService) GetScore() int {
@@ -76,7 +278,116 @@ func (g *GameService) IsGameOver() bool {
 	return g.gameOver
 }
 
+func (g *GameService) GetTopOutCause() TopOutCause {
+	return g.topOutCause
+}
+
+func (g *GameService) GetLastClear() ClearInfo {
+	return g.lastClear
+}
+
+func (g *GameService) GetPiecesLocked() int {
+	return g.piecesLocked
+}
+
+// GetHoldPiece はホールドしているピースを返す。ホールドしていなければnil
+func (g *GameService) GetHoldPiece() *model.Tetromino {
+	return g.holdPiece
+}
+
+// GetPieces はランダムに出現するピースの種類を返す
+func (g *GameService) GetPieces() []model.TetrominoType {
+	return slices.Clone(g.pieces)
+}
+
+func (g *GameService) HoldEnabled() bool {
+	return g.holdEnabled
+}
+
+// HoldAvailable は現在のピースでホールドを使えるかを返す
+func (g *GameService) HoldAvailable() bool {
+	return g.holdEnabled && !g.holdUsed && !g.gameOver && g.currentPiece != nil &&
+		(g.holdPiece != nil || len(g.queue) > 0)
+}
+
+func (g *GameService) GetGarbageLines() int {
+	return g.garbageLines
+}
+
+// Subscribe はゲーム中の出来事の購読者を登録し、登録を解除する関数を返す。
+// 購読者は操作が終わってから呼ばれるため、ゲッターで操作後の状態を読める
+func (g *GameService) Subscribe(handler EventHandler) func() {
+	return g.events.Subscribe(handler)
+}
+
+func (g *GameService) AddGarbage(lines int) error {
+	defer g.events.flush()
+	if g.gameOver {
+		return ErrGameOver
+	}
+	if lines <= 0 {
+		return nil
+	}
+
+	holes := make([]int, lines)
+	for i := range holes {
+		holes[i] = g.rng.IntN(g.board.Width)
+	}
+
+	err := g.board.InsertGarbageLines(holes)
+	if err != nil && !errors.Is(err, model.ErrTopOut) {
+		return fmt.Errorf("おじゃまライン追加エラー: %w", err)
+	}
+
+	g.garbageLines = min(g.garbageLines+lines, g.board.Height)
+	if err != nil {
+		g.topOut(TopOutGarbage)
+		return nil
+	}
+
+	g.liftCurrentPiece(lines)
+	return nil
+}
+
+func (g *GameService) liftCurrentPiece(lines int) {
+	if g.currentPiece == nil {
+		return
+	}
+
+	for i := 0; i < lines && !g.board.CanPlaceTetromino(g.currentPiece); i++ {
+		g.currentPiece.Position.Y--
+	}
+
+	if !g.board.CanPlaceTetromino(g.currentPiece) {
+		g.topOut(TopOutGarbage)
+	}
+}
+
+func (g *GameService) topOut(cause TopOutCause) {
+	g.gameOver = true
+	g.topOutCause = cause
+	g.events.record(GameOver{Cause: cause})
+}
+
 func (g *GameService) MovePiece(delta model.Point) error {
+	defer g.events.flush()
+	from := g.piecePosition()
+	if err := g.movePiece(delta); err != nil {
+		return err
+	}
+
+	g.events.record(PieceMoved{Type: g.currentPiece.Type, From: from, To: g.currentPiece.Position})
+	return nil
+}
+
+func (g *GameService) piecePosition() model.Point {
+	if g.currentPiece == nil {
+		return model.Point{}
+	}
+	return g.currentPiece.Position
+}
+
+func (g *GameService) movePiece(delta model.Point) error {
 	if g.gameOver {
 		return ErrGameOver
 	}
@@ -94,10 +405,12 @@ func (g *GameService) MovePiece(delta model.Point) error {
 		return ErrInvalidMove
 	}
 
+	g.lastRotated = false
 	return nil
 }
 
 func (g *GameService) RotatePiece() error {
+	defer g.events.flush()
 	if g.gameOver {
 		return ErrGameOver
 	}
@@ -105,31 +418,55 @@ func (g *GameService) RotatePiece() error {
 		return ErrNoPiece
 	}
 
-	originalShape := make([][]bool, len(g.currentPiece.Shape))
-	for i := range originalShape {
-		originalShape[i] = make([]bool, len(g.currentPiece.Shape[i]))
-		copy(originalShape[i], g.currentPiece.Shape[i])
+	from := g.currentPiece.Position
+	rotated, err := TryRotate(g.board, g.currentPiece)
+	if err != nil {
+		return err
+	}
+	if !rotated {
+		return ErrInvalidMove
 	}
 
-	if err := g.currentPiece.Rotate(); err != nil {
-		return fmt.Errorf("ピース回転エラー: %w", err)
+	g.lastRotated = true
+	g.events.record(PieceRotated{
+		Type:     g.currentPiece.Type,
+		Rotation: g.currentPiece.Rotation(),
+		From:     from,
+		To:       g.currentPiece.Position,
+	})
+	return nil
+}
+
+// TryRotate はウォールキックを順に試してピースを回転させる。回転できない場合はピースを元に戻す
+func TryRotate(board *model.Board, piece *model.Tetromino) (bool, error) {
+	original := piece.Clone()
+	kicks := piece.Kicks()
+	if err := piece.Rotate(); err != nil {
+		return false, fmt.Errorf("ピース回転エラー: %w", err)
 	}
 
-	if !g.board.CanPlaceTetromino(g.currentPiece) {
-		g.currentPiece.Shape = originalShape
-		return ErrInvalidMove
+	rotatedPosition := piece.Position
+	for _, kick := range kicks {
+		piece.Position = rotatedPosition.Add(kick)
+		if board.CanPlaceTetromino(piece) {
+			return true, nil
+		}
 	}
 
-	return nil
+	piece.Position = original.Position
+	piece.Shape = original.Shape
+	return false, nil
 }
 
 func (g *GameService) DropPiece() error {
+	defer g.events.flush()
 	if g.gameOver {
 		return ErrGameOver
 	}
 
+	from := g.piecePosition()
 	for {
-		err := g.MovePiece(model.Point{X: 0, Y: 1})
+		err := g.movePiece(model.Point{X: 0, Y: 1})
 		if err != nil {
 			if errors.Is(err, ErrInvalidMove) {
 				break
@@ -138,15 +475,20 @@ func (g *GameService) DropPiece() error {
 		}
 	}
 
+	if to := g.currentPiece.Position; to != from {
+		g.events.record(PieceMoved{Type: g.currentPiece.Type, From: from, To: to, HardDrop: true})
+	}
 	return g.lockPiece()
 }
 
 func (g *GameService) Update() error {
+	defer g.events.flush()
 	if g.gameOver {
 		return ErrGameOver
 	}
 
-	err := g.MovePiece(model.Point{X: 0, Y: 1})
+	from := g.piecePosition()
+	err := g.movePiece(model.Point{X: 0, Y: 1})
 	if err != nil {
 		if errors.Is(err, ErrInvalidMove) {
 			return g.lockPiece()
@@ -154,6 +496,7 @@ func (g *GameService) Update() error {
 		return err
 	}
 
+	g.events.record(PieceMoved{Type: g.currentPiece.Type, From: from, To: g.currentPiece.Position})
 	return nil
 }
 
@@ -162,40 +505,113 @@ func (g *GameService) lockPiece() error {
 		return ErrNoPiece
 	}
 
+	tSpin := g.isTSpin()
 	if err := g.board.PlaceTetromino(g.currentPiece); err != nil {
 		return fmt.Errorf("ピース配置エラー: %w", err)
 	}
+	g.piecesLocked++
+	g.lastRotated = false
+	g.holdUsed = false
+	locked := g.currentPiece.Clone()
+	level := g.level
 
 	completedLines := g.board.GetCompletedLines()
+	g.countClearedGarbage(completedLines)
 	if len(completedLines) > 0 {
 		if err := g.board.ClearLines(completedLines); err != nil {
 			return fmt.Errorf("ライン消去エラー: %w", err)
 		}
 		g.updateScore(len(completedLines))
 	}
+	g.lastClear = g.classifyClear(len(completedLines), tSpin)
+
+	g.events.record(PieceLocked{Piece: locked, Clear: g.lastClear})
+	if len(completedLines) > 0 {
+		g.events.record(LinesCleared{Rows: completedLines, Clear: g.lastClear})
+	}
+	if g.level != level {
+		g.events.record(LevelUp{Level: g.level})
+	}
 
 	if g.board.IsGameOver() {
-		g.gameOver = true
+		g.topOut(TopOutLockOut)
 		return nil
 	}
 
-	g.currentPiece = g.nextPiece
-	if err := g.generateNextPiece(); err != nil {
+	if err := g.advanceQueue(); err != nil {
 		return fmt.Errorf("次ピース生成エラー: %w", err)
 	}
+	// 固定の順番を使い切った場合は次のピースが出現しない
+	if g.currentPiece == nil {
+		return nil
+	}
 
 	if !g.board.CanPlaceTetromino(g.currentPiece) {
-		g.gameOver = true
+		g.topOut(TopOutBlockOut)
+		return nil
 	}
 
+	g.events.record(PieceSpawned{Type: g.currentPiece.Type, Position: g.currentPiece.Position})
 	return nil
 }
 
-func (g *GameService) spawnNewPiece() error {
-	tetrominoType := model.TetrominoType(rand.IntN(7))
-	position := model.Point{X: model.BoardWidth/2 - 2, Y: 0}
+func (g *GameService) isTSpin() bool {
+	if g.currentPiece.Type != model.T || !g.lastRotated {
+		return false
+	}
+
+	filledCorners := 0
+	for _, corner := range tSpinCorners {
+		point := g.currentPiece.Position.Add(corner)
+		occupied, err := g.board.IsOccupied(point)
+		if err != nil || occupied {
+			filledCorners++
+		}
+	}
+	return filledCorners >= 3
+}
+
+func (g *GameService) classifyClear(lines int, tSpin bool) ClearInfo {
+	clear := ClearInfo{Lines: lines, TSpin: tSpin}
+
+	switch {
+	case tSpin:
+		clear.Type = ClearTSpin + ClearType(lines)
+	case lines > 0:
+		clear.Type = ClearType(lines)
+	default:
+		clear.Type = ClearNone
+	}
+
+	if lines == 0 {
+		g.combo = -1
+		clear.Combo = -1
+		return clear
+	}
 
-	piece, err := model.NewTetromino(tetrominoType, position)
+	g.combo++
+	clear.Combo = g.combo
+
+	difficult := tSpin || lines >= 4
+	clear.BackToBack = difficult && g.backToBack
+	g.backToBack = difficult
+
+	return clear
+}
+
+func (g *GameService) countClearedGarbage(completedLines []int) {
+	garbageTop := g.board.Height - g.garbageLines
+	cleared := 0
+	for _, line := range completedLines {
+		if line >= garbageTop {
+			cleared++
+		}
+	}
+	g.garbageLines -= cleared
+}
+
+func (g *GameService) spawnNewPiece() error {
+	piece, err := g.drawPiece()
 	if err != nil {
 		return fmt.Errorf("テトロミノ生成エラー: %w", err)
 	}
@@ -204,22 +620,92 @@ func (g *GameService) spawnNewPiece() error {
 	return nil
 }
 
-func (g *GameService) generateNextPiece() error {
-	tetrominoType := model.TetrominoType(rand.IntN(7))
-	position := model.Point{X: model.BoardWidth/2 - 2, Y: 0}
+// advanceQueue はネクストの先頭を現在のピースにして、ネクストを引き足す。固定の順番を使い切ってネクストがなければ
+// ホールドしているピースを出し、それもなければ現在のピースはnilになる
+func (g *GameService) advanceQueue() error {
+	g.currentPiece = nil
+	switch {
+	case len(g.queue) > 0:
+		g.currentPiece = g.queue[0]
+		g.queue = g.queue[1:]
+	case g.holdPiece != nil:
+		g.currentPiece = g.holdPiece
+		g.holdPiece = nil
+	}
+	return g.fillQueue()
+}
+
+// fillQueue はネクストが Preview の数になるまでピースを引く。固定の順番を使い切った場合はそこで止める
+func (g *GameService) fillQueue() error {
+	for len(g.queue) < g.preview {
+		piece, err := g.drawPiece()
+		if err != nil {
+			return fmt.Errorf("次テトロミノ生成エラー: %w", err)
+		}
+		if piece == nil {
+			return nil
+		}
+		g.queue = append(g.queue, piece)
+	}
+	return nil
+}
+
+// drawPiece は次に出現するピースを引く。固定の順番を使い切った場合はnilを返す
+func (g *GameService) drawPiece() (*model.Tetromino, error) {
+	if len(g.sequence) == 0 {
+		return g.spawnPiece(g.pieces[g.rng.IntN(len(g.pieces))])
+	}
+	if g.drawn >= len(g.sequence) {
+		return nil, nil
+	}
+
+	pieceType := g.sequence[g.drawn]
+	g.drawn++
+	return g.spawnPiece(pieceType)
+}
 
-	piece, err := model.NewTetromino(tetrominoType, position)
+func (g *GameService) spawnPiece(tetrominoType model.TetrominoType) (*model.Tetromino, error) {
+	return model.SpawnTetromino(tetrominoType, g.board.Width)
+}
+
+// HoldPiece は現在のピースをホールドし、ホールドしていたピース（なければ次のピース）を出現させる。
+// ホールドはピースを固定するまでに1回だけ使える
+func (g *GameService) HoldPiece() error {
+	defer g.events.flush()
+	if g.gameOver {
+		return ErrGameOver
+	}
+	if g.currentPiece == nil {
+		return ErrNoPiece
+	}
+	if !g.holdEnabled || g.holdUsed || (g.holdPiece == nil && len(g.queue) == 0) {
+		return ErrHoldUnavailable
+	}
+
+	held, err := g.spawnPiece(g.currentPiece.Type)
 	if err != nil {
-		return fmt.Errorf("次テトロミノ生成エラー: %w", err)
+		return fmt.Errorf("ホールドピース生成エラー: %w", err)
+	}
+
+	if g.holdPiece != nil {
+		g.currentPiece = g.holdPiece
+	} else if err = g.advanceQueue(); err != nil {
+		return fmt.Errorf("次ピース生成エラー: %w", err)
 	}
+	g.holdPiece = held
+	g.holdUsed = true
+	g.lastRotated = false
 
-	g.nextPiece = piece
+	g.events.record(HoldUsed{Held: held.Type, Current: g.currentPiece.Type})
+	if !g.board.CanPlaceTetromino(g.currentPiece) {
+		g.topOut(TopOutBlockOut)
+	}
 	return nil
 }
 
 func (g *GameService) updateScore(linesCleared int) {
 	g.lines += linesCleared
-	g.level = (g.lines / 10) + 1
+	g.level = g.calculateLevel()
 
 	baseScore := map[int]int{
 		1: 100,
@@ -232,3 +718,27 @@ func (g *GameService) updateScore(linesCleared int) {
 		g.score += score * g.level
 	}
 }
+
+// 開始レベルが1より大きい場合、NES版と同様に最初のレベルアップを遅らせる
+func (g *GameService) calculateLevel() int {
+	base := (g.startLevel - 1) * LinesPerLevel
+	firstLevelUp := min(base+LinesPerLevel, max(100, base-50))
+
+	level := g.startLevel
+	if g.lines >= firstLevelUp {
+		level += 1 + (g.lines-firstLevelUp)/LinesPerLevel
+	}
+
+	if g.maxLevel > 0 && level > g.maxLevel {
+		return g.maxLevel
+	}
+	return level
+}
+
+func newRandom(seed uint64) (*rand.PCG, *rand.Rand) {
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	pcg := rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)
+	return pcg, rand.New(pcg)
+}
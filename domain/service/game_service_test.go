@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 	"tetris/domain/model"
 )
@@ -353,3 +354,838 @@ func TestGameService_Getters(t *testing.T) {
 		t.Error("GetNextPiece() returned nil")
 	}
 }
+
+func TestNewGameServiceWithOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       GameOptions
+		expectError   bool
+		expectedLevel int
+	}{
+		{
+			name:          "デフォルトオプション",
+			options:       GameOptions{},
+			expectedLevel: 1,
+		},
+		{
+			name:          "開始レベル指定",
+			options:       GameOptions{StartLevel: 8, MaxLevel: 15},
+			expectedLevel: 8,
+		},
+		{
+			name:        "負の開始レベル",
+			options:     GameOptions{StartLevel: -1},
+			expectError: true,
+		},
+		{
+			name:        "最大レベルを超える開始レベル",
+			options:     GameOptions{StartLevel: 16, MaxLevel: 15},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameServiceWithOptions(tt.options)
+
+			if tt.expectError {
+				if !errors.Is(err, ErrInvalidOption) {
+					t.Errorf("NewGameServiceWithOptions() error = %v, wantErr %v", err, ErrInvalidOption)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() unexpected error = %v", err)
+			}
+
+			if gameService.GetLevel() != tt.expectedLevel {
+				t.Errorf("NewGameServiceWithOptions() level = %d, want %d", gameService.GetLevel(), tt.expectedLevel)
+			}
+		})
+	}
+}
+
+func TestGameService_CalculateLevel(t *testing.T) {
+	tests := []struct {
+		name          string
+		startLevel    int
+		maxLevel      int
+		lines         int
+		expectedLevel int
+	}{
+		{name: "レベル1開始 0ライン", startLevel: 1, lines: 0, expectedLevel: 1},
+		{name: "レベル1開始 9ライン", startLevel: 1, lines: 9, expectedLevel: 1},
+		{name: "レベル1開始 10ライン", startLevel: 1, lines: 10, expectedLevel: 2},
+		{name: "レベル1開始 25ライン", startLevel: 1, lines: 25, expectedLevel: 3},
+		{name: "レベル5開始 49ライン", startLevel: 5, lines: 49, expectedLevel: 5},
+		{name: "レベル5開始 50ラインで最初のレベルアップ", startLevel: 5, lines: 50, expectedLevel: 6},
+		{name: "レベル5開始 60ライン", startLevel: 5, lines: 60, expectedLevel: 7},
+		{name: "レベル10開始 99ライン", startLevel: 10, lines: 99, expectedLevel: 10},
+		{name: "レベル10開始 100ラインで最初のレベルアップ", startLevel: 10, lines: 100, expectedLevel: 11},
+		{name: "レベル16開始 最初のレベルアップは100ライン", startLevel: 16, lines: 100, expectedLevel: 17},
+		{name: "最大レベルで頭打ち", startLevel: 1, maxLevel: 15, lines: 200, expectedLevel: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameServiceWithOptions(GameOptions{StartLevel: tt.startLevel, MaxLevel: tt.maxLevel})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+
+			gameService.lines = tt.lines
+
+			if level := gameService.calculateLevel(); level != tt.expectedLevel {
+				t.Errorf("GameService.calculateLevel() = %d, want %d", level, tt.expectedLevel)
+			}
+		})
+	}
+}
+
+func TestGameService_Seed(t *testing.T) {
+	first, err := NewGameServiceWithOptions(GameOptions{Seed: 42})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+	second, err := NewGameServiceWithOptions(GameOptions{Seed: 42})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		if first.GetNextPiece().Type != second.GetNextPiece().Type {
+			t.Fatalf("piece %d: type = %v, want %v", i, second.GetNextPiece().Type, first.GetNextPiece().Type)
+		}
+		if err := first.advanceQueue(); err != nil {
+			t.Fatalf("advanceQueue() error = %v", err)
+		}
+		if err := second.advanceQueue(); err != nil {
+			t.Fatalf("advanceQueue() error = %v", err)
+		}
+	}
+}
+
+func TestGameService_Preview(t *testing.T) {
+	single, err := NewGameServiceWithOptions(GameOptions{Seed: 42})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+	preview, err := NewGameServiceWithOptions(GameOptions{Seed: 42, Preview: 5})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+
+	// ネクストの数を変えても出現する順番は変わらない
+	queue := preview.GetQueue()
+	if len(queue) != 5 {
+		t.Fatalf("len(GetQueue()) = %d, want 5", len(queue))
+	}
+	for i, want := range queue {
+		if got := single.GetQueue(); len(got) != 1 || got[0] != want {
+			t.Fatalf("piece %d: GetQueue() = %v, want [%v]", i, got, want)
+		}
+		if err := single.advanceQueue(); err != nil {
+			t.Fatalf("advanceQueue() error = %v", err)
+		}
+	}
+
+	sequence, err := NewGameServiceWithOptions(GameOptions{
+		Sequence: []model.TetrominoType{model.T, model.O, model.I},
+		Preview:  5,
+	})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+	if got := sequence.GetQueue(); !reflect.DeepEqual(got, []model.TetrominoType{model.O, model.I}) {
+		t.Errorf("GetQueue() = %v, want the rest of the sequence", got)
+	}
+}
+
+func TestGameService_AddGarbage(t *testing.T) {
+	tests := []struct {
+		name             string
+		setupGame        func(*GameService)
+		lines            int
+		expectError      bool
+		errorType        error
+		expectedGarbage  int
+		expectedGameOver bool
+	}{
+		{
+			name:            "おじゃまライン5段",
+			setupGame:       func(g *GameService) {},
+			lines:           5,
+			expectedGarbage: 5,
+		},
+		{
+			name:            "0段は何もしない",
+			setupGame:       func(g *GameService) {},
+			lines:           0,
+			expectedGarbage: 0,
+		},
+		{
+			name: "上端まで押し上げられるとゲームオーバー",
+			setupGame: func(g *GameService) {
+				g.board.SetBlock(model.Point{X: 0, Y: 0}, true)
+			},
+			lines:            1,
+			expectedGarbage:  1,
+			expectedGameOver: true,
+		},
+		{
+			name: "ゲームオーバー時は追加できない",
+			setupGame: func(g *GameService) {
+				g.gameOver = true
+			},
+			lines:       1,
+			expectError: true,
+			errorType:   ErrGameOver,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameServiceWithOptions(GameOptions{Seed: 1})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+
+			tt.setupGame(gameService)
+
+			err = gameService.AddGarbage(tt.lines)
+
+			if tt.expectError {
+				if !errors.Is(err, tt.errorType) {
+					t.Errorf("GameService.AddGarbage() error = %v, wantErr %v", err, tt.errorType)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GameService.AddGarbage() unexpected error = %v", err)
+			}
+
+			if gameService.GetGarbageLines() != tt.expectedGarbage {
+				t.Errorf("GetGarbageLines() = %d, want %d", gameService.GetGarbageLines(), tt.expectedGarbage)
+			}
+
+			if gameService.IsGameOver() != tt.expectedGameOver {
+				t.Errorf("IsGameOver() = %v, want %v", gameService.IsGameOver(), tt.expectedGameOver)
+			}
+		})
+	}
+}
+
+func TestGameService_ClearGarbageLines(t *testing.T) {
+	gameService, err := NewGameServiceWithOptions(GameOptions{Seed: 7})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+
+	if err := gameService.AddGarbage(3); err != nil {
+		t.Fatalf("AddGarbage() error = %v", err)
+	}
+
+	board := gameService.GetBoard()
+	for x := 0; x < board.Width; x++ {
+		board.SetBlock(model.Point{X: x, Y: board.Height - 1}, true)
+		board.SetBlock(model.Point{X: x, Y: board.Height - 4}, true)
+	}
+
+	gameService.currentPiece.Position = model.Point{X: 0, Y: 0}
+	if err := gameService.lockPiece(); err != nil {
+		t.Fatalf("lockPiece() error = %v", err)
+	}
+
+	if gameService.GetLines() != 2 {
+		t.Errorf("GetLines() = %d, want 2", gameService.GetLines())
+	}
+
+	if gameService.GetGarbageLines() != 2 {
+		t.Errorf("GetGarbageLines() = %d, want 2 (only garbage rows count)", gameService.GetGarbageLines())
+	}
+}
+
+func TestGameService_ClassifyClear(t *testing.T) {
+	type lock struct {
+		lines int
+		tSpin bool
+	}
+
+	tests := []struct {
+		name     string
+		locks    []lock
+		expected ClearInfo
+	}{
+		{
+			name:     "ライン消去なし",
+			locks:    []lock{{lines: 0}},
+			expected: ClearInfo{Type: ClearNone, Combo: -1},
+		},
+		{
+			name:     "シングル",
+			locks:    []lock{{lines: 1}},
+			expected: ClearInfo{Type: ClearSingle, Lines: 1, Combo: 0},
+		},
+		{
+			name:     "テトリス",
+			locks:    []lock{{lines: 4}},
+			expected: ClearInfo{Type: ClearTetris, Lines: 4, Combo: 0},
+		},
+		{
+			name:     "Tスピンダブル",
+			locks:    []lock{{lines: 2, tSpin: true}},
+			expected: ClearInfo{Type: ClearTSpinDouble, Lines: 2, TSpin: true, Combo: 0},
+		},
+		{
+			name:     "連続消去でコンボ",
+			locks:    []lock{{lines: 1}, {lines: 1}, {lines: 2}},
+			expected: ClearInfo{Type: ClearDouble, Lines: 2, Combo: 2},
+		},
+		{
+			name:     "コンボは消去なしで途切れる",
+			locks:    []lock{{lines: 1}, {lines: 0}, {lines: 1}},
+			expected: ClearInfo{Type: ClearSingle, Lines: 1, Combo: 0},
+		},
+		{
+			name:     "テトリス後のテトリスでBack-to-Back",
+			locks:    []lock{{lines: 4}, {lines: 0}, {lines: 4}},
+			expected: ClearInfo{Type: ClearTetris, Lines: 4, Combo: 0, BackToBack: true},
+		},
+		{
+			name:     "テトリス後のTスピンでBack-to-Back",
+			locks:    []lock{{lines: 4}, {lines: 1, tSpin: true}},
+			expected: ClearInfo{Type: ClearTSpinSingle, Lines: 1, TSpin: true, Combo: 1, BackToBack: true},
+		},
+		{
+			name:     "通常消去でBack-to-Backが途切れる",
+			locks:    []lock{{lines: 4}, {lines: 1}, {lines: 4}},
+			expected: ClearInfo{Type: ClearTetris, Lines: 4, Combo: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameService()
+			if err != nil {
+				t.Fatalf("NewGameService() error = %v", err)
+			}
+
+			var clear ClearInfo
+			for _, l := range tt.locks {
+				clear = gameService.classifyClear(l.lines, l.tSpin)
+			}
+
+			if clear != tt.expected {
+				t.Errorf("GameService.classifyClear() = %+v, want %+v", clear, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGameService_TSpinDetection(t *testing.T) {
+	// 下端の4段は16〜19段目。Tは16段目のX=4に突起、17段目のX=3〜5に並び、回転中心は(4, 17)にある
+	tests := []struct {
+		name          string
+		bottomRows    []string
+		lastRotated   bool
+		expectedTSpin bool
+	}{
+		{
+			name: "3つの角が埋まっている回転後のT",
+			bottomRows: []string{
+				"...X......",
+				"..........",
+				"...X.X....",
+				"..........",
+			},
+			lastRotated:   true,
+			expectedTSpin: true,
+		},
+		{
+			name: "回転していない場合はTスピンではない",
+			bottomRows: []string{
+				"...X......",
+				"..........",
+				"...X.X....",
+				"..........",
+			},
+			lastRotated:   false,
+			expectedTSpin: false,
+		},
+		{
+			name: "角が2つだけ",
+			bottomRows: []string{
+				"..........",
+				"..........",
+				"...X.X....",
+				"..........",
+			},
+			lastRotated:   true,
+			expectedTSpin: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameService()
+			if err != nil {
+				t.Fatalf("NewGameService() error = %v", err)
+			}
+
+			piece, err := model.NewTetromino(model.T, model.Point{X: 3, Y: 15})
+			if err != nil {
+				t.Fatalf("NewTetromino() error = %v", err)
+			}
+			gameService.board, err = model.ParseBoardRows(model.BoardWidth, model.BoardHeight, tt.bottomRows...)
+			if err != nil {
+				t.Fatalf("ParseBoardRows() error = %v", err)
+			}
+			gameService.currentPiece = piece
+			gameService.lastRotated = tt.lastRotated
+
+			if tSpin := gameService.isTSpin(); tSpin != tt.expectedTSpin {
+				t.Errorf("GameService.isTSpin() = %v, want %v", tSpin, tt.expectedTSpin)
+			}
+		})
+	}
+}
+
+func TestGameService_RotationKicks(t *testing.T) {
+	i5, err := model.ParseTetrominoType("I5")
+	if err != nil {
+		t.Fatalf("ParseTetrominoType() error = %v", err)
+	}
+
+	// 縦から横へ戻るときだけ左へずらす I3
+	shape, err := model.ParseShape([]string{"...", "###", "..."})
+	if err != nil {
+		t.Fatalf("ParseShape() error = %v", err)
+	}
+	i3, err := model.RegisterPiece(model.PieceDefinition{
+		Name:      "TestKickI3",
+		Rotations: model.RotationsOf(shape),
+		KickTable: [][]model.Point{{{X: 0}}, {{X: 0}, {X: -1}}},
+	})
+	if err != nil {
+		t.Fatalf("RegisterPiece() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		pieceType model.TetrominoType
+		rotations int
+		position  model.Point
+		wantErr   error
+		wantX     int
+		wantY     int
+	}{
+		{name: "その場で回転", pieceType: model.I, position: model.Point{X: 3, Y: 5}, wantX: 3, wantY: 5},
+		// 標準のピースはキックしないため、壁や床にぶつかる回転はできない
+		{name: "壁際ではずらさない", pieceType: model.I, rotations: 1, position: model.Point{X: 7, Y: 5},
+			wantErr: ErrInvalidMove, wantX: 7, wantY: 5},
+		{name: "床の上では持ち上げない", pieceType: model.T, position: model.Point{X: 3, Y: 17},
+			wantErr: ErrInvalidMove, wantX: 3, wantY: 17},
+		{name: "キックのあるピースは壁から離す", pieceType: i5, rotations: 1, position: model.Point{X: -2, Y: 5},
+			wantX: 0, wantY: 5},
+		{name: "回転前の状態のキックを使う", pieceType: i3, rotations: 1, position: model.Point{X: 8, Y: 5},
+			wantX: 7, wantY: 5},
+		{name: "ずれのない状態では床から持ち上げない", pieceType: i3, position: model.Point{X: 3, Y: 18},
+			wantErr: ErrInvalidMove, wantX: 3, wantY: 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameService()
+			if err != nil {
+				t.Fatalf("NewGameService() error = %v", err)
+			}
+			piece, err := model.NewTetromino(tt.pieceType, tt.position)
+			if err != nil {
+				t.Fatalf("NewTetromino() error = %v", err)
+			}
+			for i := 0; i < tt.rotations; i++ {
+				if err := piece.Rotate(); err != nil {
+					t.Fatalf("Rotate() error = %v", err)
+				}
+			}
+			gameService.currentPiece = piece
+
+			if err := gameService.RotatePiece(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GameService.RotatePiece() error = %v, want %v", err, tt.wantErr)
+			}
+			got := gameService.GetCurrentPiece().Position
+			if got.X != tt.wantX || got.Y != tt.wantY {
+				t.Errorf("position = (%d, %d), want (%d, %d)", got.X, got.Y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestGameService_TopOutCause(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*GameService)
+		action   func(*GameService) error
+		expected TopOutCause
+	}{
+		{
+			name:     "プレイ中は原因なし",
+			setup:    func(g *GameService) {},
+			action:   func(g *GameService) error { return g.DropPiece() },
+			expected: TopOutNone,
+		},
+		{
+			name: "最上段で固定されるとロックアウト",
+			setup: func(g *GameService) {
+				g.currentPiece, _ = model.NewTetromino(model.O, model.Point{X: 3, Y: -1})
+				for y := 2; y < g.board.Height; y++ {
+					_ = g.board.SetBlock(model.Point{X: 4, Y: y}, true)
+				}
+			},
+			action:   func(g *GameService) error { return g.DropPiece() },
+			expected: TopOutLockOut,
+		},
+		{
+			name: "出現位置が塞がれるとブロックアウト",
+			setup: func(g *GameService) {
+				g.currentPiece, _ = model.NewTetromino(model.O, model.Point{X: -1, Y: 0})
+				for x := 3; x <= 6; x++ {
+					_ = g.board.SetBlock(model.Point{X: x, Y: 1}, true)
+					_ = g.board.SetBlock(model.Point{X: x, Y: 2}, true)
+				}
+			},
+			action:   func(g *GameService) error { return g.DropPiece() },
+			expected: TopOutBlockOut,
+		},
+		{
+			name: "おじゃまラインで押し出される",
+			setup: func(g *GameService) {
+				_ = g.board.SetBlock(model.Point{X: 0, Y: 0}, true)
+			},
+			action:   func(g *GameService) error { return g.AddGarbage(1) },
+			expected: TopOutGarbage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService, err := NewGameServiceWithOptions(GameOptions{Seed: 1})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			tt.setup(gameService)
+
+			if err := tt.action(gameService); err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+
+			if cause := gameService.GetTopOutCause(); cause != tt.expected {
+				t.Errorf("GetTopOutCause() = %v, want %v", cause, tt.expected)
+			}
+			if gameService.IsGameOver() != (tt.expected != TopOutNone) {
+				t.Errorf("IsGameOver() = %v", gameService.IsGameOver())
+			}
+		})
+	}
+}
+
+func TestGameService_Sequence(t *testing.T) {
+	sequence := []model.TetrominoType{model.T, model.I, model.O}
+	g, err := NewGameServiceWithOptions(GameOptions{Sequence: sequence})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+
+	var got []model.TetrominoType
+	for g.GetCurrentPiece() != nil {
+		got = append(got, g.GetCurrentPiece().Type)
+		if err := g.DropPiece(); err != nil {
+			t.Fatalf("DropPiece() error = %v", err)
+		}
+	}
+
+	if len(got) != len(sequence) {
+		t.Fatalf("pieces = %v, want %v", got, sequence)
+	}
+	for i := range sequence {
+		if got[i] != sequence[i] {
+			t.Errorf("piece %d = %v, want %v", i, got[i], sequence[i])
+		}
+	}
+	if g.IsGameOver() {
+		t.Error("IsGameOver() = true, want false after the sequence runs out")
+	}
+	if err := g.MovePiece(model.Point{X: 1}); !errors.Is(err, ErrNoPiece) {
+		t.Errorf("MovePiece() error = %v, want %v", err, ErrNoPiece)
+	}
+}
+
+func TestNewGameServiceWithOptions_Pieces(t *testing.T) {
+	hold := model.T
+	invalid := model.TetrominoType(1000)
+	wide, _ := model.NewBoard(model.MaxBoardWidth+1, model.BoardHeight)
+	filled, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < model.BoardWidth; x++ {
+			_ = filled.SetBlock(model.Point{X: x, Y: y}, true)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		options      GameOptions
+		wantErr      bool
+		wantGameOver bool
+	}{
+		{name: "初期ホールド", options: GameOptions{Hold: true, InitialHold: &hold}},
+		{name: "ホールド無効で初期ホールド", options: GameOptions{InitialHold: &hold}, wantErr: true},
+		{name: "不正なピース", options: GameOptions{Sequence: []model.TetrominoType{model.T, invalid}}, wantErr: true},
+		{name: "不正な出現ピース", options: GameOptions{Pieces: []model.TetrominoType{invalid}}, wantErr: true},
+		{name: "大きすぎるボード", options: GameOptions{Board: wide}, wantErr: true},
+		{name: "出現位置が塞がったボード", options: GameOptions{Board: filled}, wantGameOver: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(tt.options)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOption) {
+					t.Errorf("NewGameServiceWithOptions() error = %v, want %v", err, ErrInvalidOption)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			if g.IsGameOver() != tt.wantGameOver {
+				t.Errorf("IsGameOver() = %v, want %v", g.IsGameOver(), tt.wantGameOver)
+			}
+			if tt.options.Board != nil && g.GetBoard() == tt.options.Board {
+				t.Error("GetBoard() shares the board passed in options")
+			}
+		})
+	}
+}
+
+func TestGameService_GetPieces(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces []model.TetrominoType
+		want   []model.TetrominoType
+	}{
+		{name: "省略すると標準の7種類", want: model.StandardPieces},
+		{name: "ペントミノ", pieces: model.PentominoPieces, want: model.PentominoPieces},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(GameOptions{Pieces: tt.pieces})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			got := g.GetPieces()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPieces() = %v, want %v", got, tt.want)
+			}
+			got[0] = model.TetrominoType(1000)
+			if g.GetPieces()[0] != tt.want[0] {
+				t.Error("GetPieces() shares the service's piece list")
+			}
+		})
+	}
+}
+
+func TestNewGameServiceWithOptions_BoardSize(t *testing.T) {
+	narrow, _ := model.NewBoard(4, 8)
+	tests := []struct {
+		name       string
+		options    GameOptions
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{name: "標準", options: GameOptions{}, wantWidth: 10, wantHeight: 20},
+		{name: "4列", options: GameOptions{Width: 4}, wantWidth: 4, wantHeight: 20},
+		{name: "20列", options: GameOptions{Width: 20, Height: 24}, wantWidth: 20, wantHeight: 24},
+		{name: "ボードの大きさを使う", options: GameOptions{Board: narrow}, wantWidth: 4, wantHeight: 8},
+		{name: "ボードと指定が違う", options: GameOptions{Width: 10, Board: narrow}, wantErr: true},
+		{name: "狭すぎる", options: GameOptions{Width: model.MinBoardWidth - 1}, wantErr: true},
+		{name: "広すぎる", options: GameOptions{Width: model.MaxBoardWidth + 1}, wantErr: true},
+		{name: "高すぎる", options: GameOptions{Height: model.MaxBoardHeight + 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(tt.options)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidOption) {
+					t.Errorf("NewGameServiceWithOptions() error = %v, want %v", err, ErrInvalidOption)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			board := g.GetBoard()
+			if board.Width != tt.wantWidth || board.Height != tt.wantHeight {
+				t.Fatalf("board = %dx%d, want %dx%d", board.Width, board.Height, tt.wantWidth, tt.wantHeight)
+			}
+
+			// 出現したピースがボードに収まり、ゲームオーバーまで落とし続けられる
+			for i := 0; i < 200 && !g.IsGameOver(); i++ {
+				if !board.CanPlaceTetromino(g.GetCurrentPiece()) {
+					t.Fatalf("piece %d %v does not fit at spawn: %v", i, g.GetCurrentPiece().Type, g.GetCurrentPiece().GetBlocks())
+				}
+				if err := g.DropPiece(); err != nil {
+					t.Fatalf("DropPiece() error = %v", err)
+				}
+				board = g.GetBoard()
+			}
+		})
+	}
+}
+
+func TestGameService_PieceSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces []model.TetrominoType
+	}{
+		{name: "ペントミノ", pieces: model.PentominoPieces},
+		{name: "ビッグ", pieces: model.BigPieces},
+		{name: "1種類だけ", pieces: []model.TetrominoType{model.T}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(GameOptions{Seed: 1, Pieces: tt.pieces})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			active := make(map[model.TetrominoType]bool)
+			for _, pieceType := range tt.pieces {
+				active[pieceType] = true
+			}
+			for i := 0; i < 50; i++ {
+				piece, err := g.drawPiece()
+				if err != nil {
+					t.Fatalf("drawPiece() error = %v", err)
+				}
+				if !active[piece.Type] {
+					t.Fatalf("drawPiece() = %v, want one of %v", piece.Type, tt.pieces)
+				}
+				if !g.board.CanPlaceTetromino(piece) {
+					t.Errorf("%v does not fit at spawn: %v", piece.Type, piece.GetBlocks())
+				}
+			}
+		})
+	}
+}
+
+func TestGameService_HoldPiece(t *testing.T) {
+	sequence := []model.TetrominoType{model.T, model.I, model.O, model.S}
+
+	tests := []struct {
+		name        string
+		options     GameOptions
+		actions     []string
+		wantCurrent model.TetrominoType
+		wantHold    model.TetrominoType
+		wantErr     error
+	}{
+		{
+			name:        "空のホールドには次のピースが出る",
+			options:     GameOptions{Sequence: sequence, Hold: true},
+			actions:     []string{"hold"},
+			wantCurrent: model.I,
+			wantHold:    model.T,
+		},
+		{
+			name:        "ホールドと入れ替える",
+			options:     GameOptions{Sequence: sequence, Hold: true},
+			actions:     []string{"hold", "drop", "hold"},
+			wantCurrent: model.T,
+			wantHold:    model.O,
+		},
+		{
+			name:    "1ピースに1回まで",
+			options: GameOptions{Sequence: sequence, Hold: true},
+			actions: []string{"hold", "hold"},
+			wantErr: ErrHoldUnavailable,
+		},
+		{
+			name:    "ホールド無効",
+			options: GameOptions{Sequence: sequence},
+			actions: []string{"hold"},
+			wantErr: ErrHoldUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(tt.options)
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+
+			var actionErr error
+			for _, action := range tt.actions {
+				if action == "hold" {
+					actionErr = g.HoldPiece()
+				} else {
+					actionErr = g.DropPiece()
+				}
+				if actionErr != nil {
+					break
+				}
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(actionErr, tt.wantErr) {
+					t.Errorf("error = %v, want %v", actionErr, tt.wantErr)
+				}
+				return
+			}
+			if actionErr != nil {
+				t.Fatalf("action error = %v", actionErr)
+			}
+			if got := g.GetCurrentPiece(); got.Type != tt.wantCurrent || got.Position.Y != 0 {
+				t.Errorf("current = %v at %v, want %v at spawn", got.Type, got.Position, tt.wantCurrent)
+			}
+			if got := g.GetHoldPiece(); got == nil || got.Type != tt.wantHold || got.Rotation() != 0 {
+				t.Errorf("hold = %v, want %v in spawn orientation", got, tt.wantHold)
+			}
+		})
+	}
+}
+
+func TestGameService_HeldPieceAfterSequence(t *testing.T) {
+	hold := model.I
+	g, err := NewGameServiceWithOptions(GameOptions{
+		Sequence:    []model.TetrominoType{model.O},
+		Hold:        true,
+		InitialHold: &hold,
+	})
+	if err != nil {
+		t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+	}
+
+	// 固定の順番を使い切ると、ホールドしていたピースが出る
+	if err := g.DropPiece(); err != nil {
+		t.Fatalf("DropPiece() error = %v", err)
+	}
+	if got := g.GetCurrentPiece(); got == nil || got.Type != model.I {
+		t.Fatalf("current = %v, want the held I", got)
+	}
+	if got := g.GetHoldPiece(); got != nil {
+		t.Errorf("hold = %v, want nil", got)
+	}
+
+	if err := g.DropPiece(); err != nil {
+		t.Fatalf("DropPiece() error = %v", err)
+	}
+	if got := g.GetCurrentPiece(); got != nil {
+		t.Errorf("current = %v, want nil after every piece is used", got)
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"tetris/domain/model"
+)
+
+var ErrInvalidSnapshot = errors.New("無効なスナップショットです")
+
+// Snapshot はある時点のゲームの状態。ボードとピースは複製して持つため、後からゲームを進めても変わらない。
+// 乱数と固定の順番の位置も含むため、復元したゲームは保存した時点と同じ順番でピースとおじゃまラインの穴を引く
+type Snapshot struct {
+	board        *model.Board
+	currentPiece *model.Tetromino
+	queue        []*model.Tetromino
+	score        int
+	lines        int
+	level        int
+	garbageLines int
+	gameOver     bool
+	rng          []byte
+	lastRotated  bool
+	combo        int
+	backToBack   bool
+	piecesLocked int
+	lastClear    ClearInfo
+	topOutCause  TopOutCause
+	drawn        int
+	holdPiece    *model.Tetromino
+	holdUsed     bool
+}
+
+// Snapshot は現在の状態を保存する。購読者は状態に含まれない
+func (g *GameService) Snapshot() (Snapshot, error) {
+	rng, err := g.pcg.MarshalBinary()
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("乱数状態の保存エラー: %w", err)
+	}
+
+	return Snapshot{
+		board:        g.board.Clone(),
+		currentPiece: clonePiece(g.currentPiece),
+		queue:        cloneQueue(g.queue),
+		score:        g.score,
+		lines:        g.lines,
+		level:        g.level,
+		garbageLines: g.garbageLines,
+		gameOver:     g.gameOver,
+		rng:          rng,
+		lastRotated:  g.lastRotated,
+		combo:        g.combo,
+		backToBack:   g.backToBack,
+		piecesLocked: g.piecesLocked,
+		lastClear:    g.lastClear,
+		topOutCause:  g.topOutCause,
+		drawn:        g.drawn,
+		holdPiece:    clonePiece(g.holdPiece),
+		holdUsed:     g.holdUsed,
+	}, nil
+}
+
+// Restore は保存した状態に戻す。スナップショットは複製して使うため、同じスナップショットから何度でも戻せる
+func (g *GameService) Restore(snapshot Snapshot) error {
+	if snapshot.board == nil {
+		return ErrInvalidSnapshot
+	}
+	if err := g.pcg.UnmarshalBinary(snapshot.rng); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	g.board = snapshot.board.Clone()
+	g.currentPiece = clonePiece(snapshot.currentPiece)
+	g.queue = cloneQueue(snapshot.queue)
+	g.score = snapshot.score
+	g.lines = snapshot.lines
+	g.level = snapshot.level
+	g.garbageLines = snapshot.garbageLines
+	g.gameOver = snapshot.gameOver
+	g.lastRotated = snapshot.lastRotated
+	g.combo = snapshot.combo
+	g.backToBack = snapshot.backToBack
+	g.piecesLocked = snapshot.piecesLocked
+	g.lastClear = snapshot.lastClear
+	g.topOutCause = snapshot.topOutCause
+	g.drawn = snapshot.drawn
+	g.holdPiece = clonePiece(snapshot.holdPiece)
+	g.holdUsed = snapshot.holdUsed
+	return nil
+}
+
+func cloneQueue(queue []*model.Tetromino) []*model.Tetromino {
+	cloned := make([]*model.Tetromino, len(queue))
+	for i, piece := range queue {
+		cloned[i] = piece.Clone()
+	}
+	return cloned
+}
+
+func clonePiece(piece *model.Tetromino) *model.Tetromino {
+	if piece == nil {
+		return nil
+	}
+	return piece.Clone()
+}
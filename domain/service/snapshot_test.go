@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"tetris/domain/model"
+)
+
+type serviceState struct {
+	Grid         [][]bool
+	Current      model.Tetromino
+	Next         model.Tetromino
+	Score        int
+	Lines        int
+	Level        int
+	Garbage      int
+	GameOver     bool
+	PiecesLocked int
+	LastClear    ClearInfo
+}
+
+func captureState(g *GameService) serviceState {
+	return serviceState{
+		Grid:         g.GetBoard().Clone().Grid,
+		Current:      *g.GetCurrentPiece().Clone(),
+		Next:         *g.GetNextPiece().Clone(),
+		Score:        g.GetScore(),
+		Lines:        g.GetLines(),
+		Level:        g.GetLevel(),
+		Garbage:      g.GetGarbageLines(),
+		GameOver:     g.IsGameOver(),
+		PiecesLocked: g.GetPiecesLocked(),
+		LastClear:    g.GetLastClear(),
+	}
+}
+
+func TestGameService_SnapshotRestore(t *testing.T) {
+	tests := []struct {
+		name   string
+		before func(g *GameService) error
+		after  func(g *GameService) error
+	}{
+		{
+			name:   "ピースを置いた後に戻す",
+			before: func(*GameService) error { return nil },
+			after: func(g *GameService) error {
+				for i := 0; i < 5; i++ {
+					if err := g.DropPiece(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name: "おじゃまラインを受けた後に戻す",
+			before: func(g *GameService) error {
+				return g.DropPiece()
+			},
+			after: func(g *GameService) error {
+				return g.AddGarbage(3)
+			},
+		},
+		{
+			name:   "ゲームオーバーから戻す",
+			before: func(*GameService) error { return nil },
+			after: func(g *GameService) error {
+				return g.AddGarbage(model.BoardHeight)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameServiceWithOptions(GameOptions{Seed: 7})
+			if err != nil {
+				t.Fatalf("NewGameServiceWithOptions() error = %v", err)
+			}
+			if err := tt.before(g); err != nil {
+				t.Fatalf("before error = %v", err)
+			}
+
+			snapshot, err := g.Snapshot()
+			if err != nil {
+				t.Fatalf("Snapshot() error = %v", err)
+			}
+			saved := captureState(g)
+
+			// 保存した時点から進めた結果を記録しておき、戻した後に同じ結果になることを確かめる
+			if err := tt.after(g); err != nil {
+				t.Fatalf("after error = %v", err)
+			}
+			advanced := captureState(g)
+
+			for attempt := 0; attempt < 2; attempt++ {
+				if err := g.Restore(snapshot); err != nil {
+					t.Fatalf("Restore() error = %v", err)
+				}
+				if got := captureState(g); !reflect.DeepEqual(got, saved) {
+					t.Fatalf("restored state = %+v, want %+v", got, saved)
+				}
+
+				if err := tt.after(g); err != nil {
+					t.Fatalf("after error = %v", err)
+				}
+				if got := captureState(g); !reflect.DeepEqual(got, advanced) {
+					t.Errorf("replayed state = %+v, want %+v", got, advanced)
+				}
+			}
+		})
+	}
+}
+
+func TestGameService_RestoreInvalid(t *testing.T) {
+	g, err := NewGameService()
+	if err != nil {
+		t.Fatalf("NewGameService() error = %v", err)
+	}
+
+	if err := g.Restore(Snapshot{}); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Restore() error = %v, want %v", err, ErrInvalidSnapshot)
+	}
+}
@@ -1,3 +1,7 @@
 module tetris
 
 go 1.23.3
+
+require golang.org/x/crypto v0.38.0
+
+require golang.org/x/sys v0.33.0 // indirect
@@ -2,12 +2,15 @@ package console
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 	"tetris/application"
 	"tetris/domain/model"
+	"tetris/domain/service"
+	"time"
 )
 
 const (
@@ -16,28 +19,45 @@ const (
 	WallBlock   = "│"
 	FloorBlock  = "─"
 	CornerBlock = "└"
+
+	ansiClearScreen = "\x1b[H\x1b[2J"
 )
 
+// panelWidth は見出しと情報欄の枠の内側の表示幅。最も広いボードの盤面と同じ幅にする
+const panelWidth = model.MaxBoardWidth * 2
+
 type Display struct {
-	width  int
-	height int
+	out  io.Writer
+	ansi bool
 }
 
 func NewDisplay() *Display {
 	return &Display{
-		width:  model.BoardWidth,
-		height: model.BoardHeight,
+		out: os.Stdout,
+	}
+}
+
+// NewANSIDisplay はリモート端末など任意の出力先にANSIエスケープシーケンスで描画する
+func NewANSIDisplay(out io.Writer) *Display {
+	return &Display{
+		out:  out,
+		ansi: true,
 	}
 }
 
 func (d *Display) ClearScreen() error {
+	if d.ansi {
+		_, err := io.WriteString(d.out, ansiClearScreen)
+		return err
+	}
+
 	var cmd *exec.Cmd
 	if runtime.GOOS == "windows" {
 		cmd = exec.Command("cmd", "/c", "cls")
 	} else {
 		cmd = exec.Command("clear")
 	}
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = d.out
 	return cmd.Run()
 }
 
@@ -48,81 +68,292 @@ func (d *Display) Render(gameState application.GameState) error {
 
 	d.printHeader()
 	d.printGameInfo(gameState)
+	d.printStats(gameState.Stats, gameState.Pieces)
 	d.printBoard(gameState)
 	d.printControls()
 
-	if gameState.GameOver {
+	switch {
+	case gameState.Result != nil && gameState.Mode.Puzzle != nil:
+		d.printPuzzleResult(gameState)
+	case gameState.Result != nil && gameState.Mode.RankByTime:
+		d.printTimeAttackResult(gameState)
+	case gameState.Result != nil && gameState.Mode.Name == application.ModeUltra:
+		d.printUltraResult(gameState)
+	case gameState.Result != nil && gameState.Result.Status == application.ModeCompleted:
+		d.printClearResult(gameState)
+	case gameState.GameOver:
 		d.printGameOver(gameState)
 	}
 
 	return nil
 }
 
+// PrintMessage は描画した画面の下にメッセージを表示する
+func (d *Display) PrintMessage(message string) {
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, message)
+}
+
 func (d *Display) printHeader() {
-	fmt.Println("┌" + strings.Repeat("─", 40) + "┐")
-	fmt.Println("│" + centerText("テトリス", 40) + "│")
-	fmt.Println("├" + strings.Repeat("─", 40) + "┤")
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", panelWidth)+"┐")
+	fmt.Fprintln(d.out, "│"+centerText("テトリス", panelWidth)+"│")
+	fmt.Fprintln(d.out, "├"+strings.Repeat("─", panelWidth)+"┤")
 }
 
 func (d *Display) printGameInfo(gameState application.GameState) {
-	fmt.Printf("│ スコア: %-10d ライン: %-10d │\n", gameState.Score, gameState.Lines)
-	fmt.Printf("│ レベル: %-10d                    │\n", gameState.Level)
-	fmt.Println("├" + strings.Repeat("─", 40) + "┤")
+	fmt.Fprintf(d.out, "│ スコア: %-10d ライン: %-10d │\n", gameState.Score, gameState.Lines)
+	fmt.Fprintf(d.out, "│ レベル: %-10d                    │\n", gameState.Level)
+	fmt.Fprintf(d.out, "│ モード: %-10s タイム: %-10s │\n", gameState.Mode.Name, formatDuration(gameState.Elapsed))
+	if gameState.Mode.TimeLimit > 0 {
+		remaining := max(gameState.Mode.TimeLimit-gameState.Elapsed, 0)
+		fmt.Fprintf(d.out, "│ 残り時間: %-29s │\n", formatDuration(remaining))
+	}
+	if gameState.Mode.NextRise > 0 {
+		untilRise := max(gameState.Mode.NextRise-gameState.Elapsed, 0)
+		fmt.Fprintf(d.out, "│ せり上がりまで: %-23s │\n", formatDuration(untilRise))
+	}
+	if gameState.Mode.Name == application.ModeDig {
+		fmt.Fprintf(d.out, "│ 残りおじゃまライン: %-19d │\n", gameState.Garbage)
+	}
+	if gameState.Mode.LineGoal > 0 {
+		fmt.Fprintf(d.out, "│ 残りライン: %-27d │\n", max(gameState.Mode.LineGoal-gameState.Lines, 0))
+	}
+	for i, split := range gameState.Mode.Splits {
+		fmt.Fprintf(d.out, "│ スプリット %2dライン: %-18s │\n", (i+1)*application.SprintSplitInterval, formatDuration(split))
+	}
+	if gameState.Mode.Puzzle != nil {
+		d.printPuzzleInfo(gameState.Mode.Puzzle)
+	}
+	if gameState.HoldEnabled {
+		fmt.Fprintf(d.out, "│ ホールド: %-27s │\n", holdLabel(gameState.HoldPiece))
+	}
+	if gameState.History.Enabled {
+		fmt.Fprintf(d.out, "│ 取り消し: %-9d やり直し: %-7d │\n", gameState.History.Undo, gameState.History.Redo)
+	}
+	finesse := gameState.Finesse
+	fmt.Fprintf(d.out, "│ フィネス: %-9s 無駄入力: %-7d │\n",
+		fmt.Sprintf("%d/%d", finesse.FaultyPieces, finesse.Pieces), finesse.WastedInputs)
+	fmt.Fprintln(d.out, "├"+strings.Repeat("─", panelWidth)+"┤")
+}
+
+// pieceCountWidth は統計の枠内に収まるピースごとの固定数の行の幅
+const pieceCountWidth = 37
+
+func holdLabel(piece *model.Tetromino) string {
+	if piece == nil {
+		return "-"
+	}
+	return piece.Type.String()
+}
+
+func (d *Display) printStats(stats application.PlayStats, pieces []model.TetrominoType) {
+	fmt.Fprintf(d.out, "│ PPS: %-7.2f APM: %-7.1f KPP: %-6.2f │\n", stats.PPS(), stats.APM(), stats.KPP())
+
+	for _, line := range pieceCountLines(pieces, stats.PieceCounts) {
+		fmt.Fprintf(d.out, "│ %-*s │\n", pieceCountWidth, line)
+	}
+
+	counts := stats.ClearCounts
+	clears := fmt.Sprintf("%d/%d/%d/%d", counts[service.ClearSingle], counts[service.ClearDouble],
+		counts[service.ClearTriple], counts[service.ClearTetris])
+	fmt.Fprintf(d.out, "│ ライン消去 1/2/3/4: %-17s │\n", clears)
+	tSpins := fmt.Sprintf("%d/%d/%d/%d", counts[service.ClearTSpin], counts[service.ClearTSpinSingle],
+		counts[service.ClearTSpinDouble], counts[service.ClearTSpinTriple])
+	fmt.Fprintf(d.out, "│ T-Spin 0/1/2/3: %-21s │\n", tSpins)
+	fmt.Fprintf(d.out, "│ 最大コンボ: %-8d 攻撃: %-10d │\n", stats.MaxCombo, stats.Attack)
+	fmt.Fprintln(d.out, "├"+strings.Repeat("─", panelWidth)+"┤")
+}
+
+// pieceCountLines は出現するピースごとの固定数を、枠の幅で折り返した行にする
+func pieceCountLines(pieces []model.TetrominoType, counts map[model.TetrominoType]int) []string {
+	if len(pieces) == 0 {
+		pieces = model.StandardPieces
+	}
+
+	var lines []string
+	var line strings.Builder
+	for _, pieceType := range pieces {
+		label := pieceType.String()
+		if len(label) > 1 {
+			// "I5"のように数字で終わる名前と個数が続けて読めないよう区切る
+			label += ":"
+		}
+		entry := fmt.Sprintf("%s%-3d", label, counts[pieceType])
+		if line.Len() > 0 && line.Len()+1+len(entry) > pieceCountWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteString(" ")
+		}
+		line.WriteString(entry)
+	}
+	return append(lines, line.String())
 }
 
 func (d *Display) printBoard(gameState application.GameState) {
+	for _, line := range d.boardLines(gameState) {
+		fmt.Fprintln(d.out, line)
+	}
+}
+
+// boardLines はボードの大きさに合わせて、枠を含めた盤面の行を返す
+func (d *Display) boardLines(gameState application.GameState) []string {
 	board := gameState.Board
 	currentPiece := gameState.CurrentPiece
 
-	gameBoard := make([][]bool, d.height)
+	gameBoard := make([][]bool, board.Height)
 	for i := range gameBoard {
-		gameBoard[i] = make([]bool, d.width)
+		gameBoard[i] = make([]bool, board.Width)
 		copy(gameBoard[i], board.Grid[i])
 	}
 
 	if currentPiece != nil {
 		blocks := currentPiece.GetBlocks()
 		for _, block := range blocks {
-			if block.Y >= 0 && block.Y < d.height && block.X >= 0 && block.X < d.width {
+			if board.IsValidPosition(block) {
 				gameBoard[block.Y][block.X] = true
 			}
 		}
 	}
 
-	for y := 0; y < d.height; y++ {
-		fmt.Print("│")
-		for x := 0; x < d.width; x++ {
+	lines := make([]string, 0, board.Height+1)
+	for y := 0; y < board.Height; y++ {
+		var line strings.Builder
+		line.WriteString("│")
+		for x := 0; x < board.Width; x++ {
 			if gameBoard[y][x] {
-				fmt.Print(FilledBlock)
+				line.WriteString(FilledBlock)
 			} else {
-				fmt.Print(EmptyBlock)
+				line.WriteString(EmptyBlock)
 			}
 		}
-		fmt.Println("│")
+		line.WriteString("│")
+		lines = append(lines, line.String())
 	}
 
-	fmt.Println("└" + strings.Repeat("─", d.width*2) + "┘")
+	lines = append(lines, "└"+strings.Repeat("─", board.Width*2)+"┘")
+	return lines
 }
 
 func (d *Display) printControls() {
-	fmt.Println()
-	fmt.Println("操作方法:")
-	fmt.Println("  A/D: 左右移動")
-	fmt.Println("  S: 下移動")
-	fmt.Println("  W: 回転")
-	fmt.Println("  Space: 一気に落下")
-	fmt.Println("  P: 一時停止")
-	fmt.Println("  Q: 終了")
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "操作方法:")
+	fmt.Fprintln(d.out, "  A/D: 左右移動")
+	fmt.Fprintln(d.out, "  S: 下移動")
+	fmt.Fprintln(d.out, "  W: 回転")
+	fmt.Fprintln(d.out, "  Space: 一気に落下")
+	fmt.Fprintln(d.out, "  C: ホールド（パズルで使えるとき）")
+	fmt.Fprintln(d.out, "  P: 一時停止")
+	fmt.Fprintln(d.out, "  Z/Y: 取り消し/やり直し（-undo指定時）")
+	fmt.Fprintln(d.out, "  F: 盤面をfumenで出力")
+	fmt.Fprintln(d.out, "  Q: 終了")
 }
 
 func (d *Display) printGameOver(gameState application.GameState) {
-	fmt.Println()
-	fmt.Println("┌" + strings.Repeat("─", 30) + "┐")
-	fmt.Println("│" + centerText("ゲームオーバー！", 30) + "│")
-	fmt.Printf("│" + centerText(fmt.Sprintf("最終スコア: %d", gameState.Score), 30) + "│\n")
-	fmt.Printf("│" + centerText(fmt.Sprintf("消去ライン: %d", gameState.Lines), 30) + "│\n")
-	fmt.Println("│" + centerText("Rでリスタート、Qで終了", 30) + "│")
-	fmt.Println("└" + strings.Repeat("─", 30) + "┘")
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", 30)+"┐")
+	fmt.Fprintln(d.out, "│"+centerText("ゲームオーバー！", 30)+"│")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("最終スコア: %d", gameState.Score), 30)+"│\n")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("消去ライン: %d", gameState.Lines), 30)+"│\n")
+	d.printStatsSummary(gameState.Stats)
+	d.printFinesseSummary(gameState.Finesse)
+	fmt.Fprintln(d.out, "│"+centerText("Rでリスタート、Qで終了", 30)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", 30)+"┘")
+}
+
+func (d *Display) printClearResult(gameState application.GameState) {
+	result := gameState.Result
+
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", 30)+"┐")
+	fmt.Fprintln(d.out, "│"+centerText("クリア！", 30)+"│")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("最終スコア: %d", result.Score), 30)+"│\n")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("消去ライン: %d", result.Lines), 30)+"│\n")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("タイム: %s", formatDuration(result.Elapsed)), 30)+"│\n")
+	if result.PersonalBest {
+		fmt.Fprintln(d.out, "│"+centerText("ハイスコア更新！", 30)+"│")
+	}
+	d.printStatsSummary(result.Stats)
+	d.printFinesseSummary(result.Finesse)
+	fmt.Fprintln(d.out, "│"+centerText("Rでリスタート、Qで終了", 30)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", 30)+"┘")
+}
+
+func (d *Display) printTimeAttackResult(gameState application.GameState) {
+	result := gameState.Result
+
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", 30)+"┐")
+	if result.Status == application.ModeCompleted {
+		fmt.Fprintln(d.out, "│"+centerText("完走！", 30)+"│")
+		fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("タイム: %s", formatDuration(result.Elapsed)), 30)+"│\n")
+	} else {
+		fmt.Fprintln(d.out, "│"+centerText("失敗", 30)+"│")
+		fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("消去ライン: %d", result.Lines), 30)+"│\n")
+	}
+	for i, split := range result.Splits {
+		line := fmt.Sprintf("%dライン: %s", (i+1)*application.SprintSplitInterval, formatDuration(split))
+		fmt.Fprintf(d.out, "│"+centerText(line, 30)+"│\n")
+	}
+	if result.PersonalBest {
+		fmt.Fprintln(d.out, "│"+centerText("自己ベスト更新！", 30)+"│")
+	} else if result.BestTime > 0 {
+		fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("自己ベスト: %s", formatDuration(result.BestTime)), 30)+"│\n")
+	}
+	d.printStatsSummary(result.Stats)
+	d.printFinesseSummary(result.Finesse)
+	fmt.Fprintln(d.out, "│"+centerText("Rでリスタート、Qで終了", 30)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", 30)+"┘")
+}
+
+func (d *Display) printUltraResult(gameState application.GameState) {
+	result := gameState.Result
+
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", 30)+"┐")
+	if result.Status == application.ModeCompleted {
+		fmt.Fprintln(d.out, "│"+centerText("タイムアップ！", 30)+"│")
+	} else {
+		fmt.Fprintln(d.out, "│"+centerText("ゲームオーバー！", 30)+"│")
+	}
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("スコア: %d", result.Score), 30)+"│\n")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("消去ライン: %d", result.Lines), 30)+"│\n")
+	if result.PersonalBest {
+		fmt.Fprintln(d.out, "│"+centerText("ハイスコア更新！", 30)+"│")
+	} else if result.BestScore > 0 {
+		fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("ハイスコア: %d", result.BestScore), 30)+"│\n")
+	}
+	d.printStatsSummary(result.Stats)
+	d.printFinesseSummary(result.Finesse)
+	fmt.Fprintln(d.out, "│"+centerText("Rでリスタート、Qで終了", 30)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", 30)+"┘")
+}
+
+// printStatsSummary はゲーム終了時の統計を結果の枠内に表示する
+func (d *Display) printStatsSummary(stats application.PlayStats) {
+	lines := []string{
+		fmt.Sprintf("PPS %.2f  APM %.1f", stats.PPS(), stats.APM()),
+		fmt.Sprintf("KPP %.2f  Pieces %d", stats.KPP(), stats.Pieces),
+		fmt.Sprintf("最大コンボ: %d", stats.MaxCombo),
+	}
+	for _, line := range lines {
+		fmt.Fprintf(d.out, "│"+centerText(line, 30)+"│\n")
+	}
+}
+
+// printFinesseSummary はゲーム終了時のフィネスの集計を結果の枠内に表示する
+func (d *Display) printFinesseSummary(finesse application.FinesseStats) {
+	line := fmt.Sprintf("フィネスミス: %d/%d", finesse.FaultyPieces, finesse.Pieces)
+	fmt.Fprintf(d.out, "│"+centerText(line, 30)+"│\n")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("無駄入力: %d", finesse.WastedInputs), 30)+"│\n")
+}
+
+func formatDuration(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	seconds := int((d % time.Minute) / time.Second)
+	millis := int((d % time.Second) / time.Millisecond)
+	return fmt.Sprintf("%02d:%02d.%03d", minutes, seconds, millis)
 }
 
 func centerText(text string, width int) string {
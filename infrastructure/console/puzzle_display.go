@@ -0,0 +1,80 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"tetris/application"
+	"unicode/utf8"
+)
+
+// RenderPuzzlePicker は遊ぶパズルを選ぶ一覧を表示する。selectedは選択中のパズルの位置
+func (d *Display) RenderPuzzlePicker(puzzles []application.Puzzle, selected int) error {
+	if err := d.ClearScreen(); err != nil {
+		return fmt.Errorf("画面クリアエラー: %w", err)
+	}
+
+	d.printHeader()
+	for i, puzzle := range puzzles {
+		cursor := " "
+		if i == selected {
+			cursor = ">"
+		}
+		fmt.Fprintf(d.out, "│ %s %s│\n", cursor, padRight(fmt.Sprintf("%d. %s", i+1, puzzle.Name), 37))
+		details := fmt.Sprintf("%s / %dピース", puzzle.Goal.String(), len(puzzle.Pieces))
+		fmt.Fprintf(d.out, "│      %s│\n", padRight(details, 34))
+	}
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", panelWidth)+"┘")
+
+	if selected >= 0 && selected < len(puzzles) && puzzles[selected].Description != "" {
+		fmt.Fprintln(d.out)
+		fmt.Fprintln(d.out, puzzles[selected].Description)
+	}
+
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "操作方法:")
+	fmt.Fprintln(d.out, "  W/S: 選択")
+	fmt.Fprintln(d.out, "  1-9: 番号で選択して開始")
+	fmt.Fprintln(d.out, "  Space: 開始")
+	fmt.Fprintln(d.out, "  Q: 終了")
+	return nil
+}
+
+func (d *Display) printPuzzleInfo(puzzle *application.PuzzleInfo) {
+	fmt.Fprintf(d.out, "│ パズル: %s │\n", padRight(puzzle.Name, 29))
+	fmt.Fprintf(d.out, "│ 目標: %s │\n", padRight(puzzle.Goal, 31))
+	fmt.Fprintf(d.out, "│ 残りピース: %-25d │\n", puzzle.PiecesLeft)
+}
+
+func (d *Display) printPuzzleResult(gameState application.GameState) {
+	result := gameState.Result
+	puzzle := gameState.Mode.Puzzle
+
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", 30)+"┐")
+	if result.Status == application.ModeCompleted {
+		fmt.Fprintln(d.out, "│"+centerText("クリア！", 30)+"│")
+	} else {
+		fmt.Fprintln(d.out, "│"+centerText("失敗", 30)+"│")
+	}
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("目標: %s", puzzle.Goal), 30)+"│\n")
+	fmt.Fprintf(d.out, "│"+centerText(fmt.Sprintf("使用ピース: %d", result.Stats.Pieces), 30)+"│\n")
+	d.printFinesseSummary(result.Finesse)
+	if gameState.History.Undo > 0 {
+		fmt.Fprintln(d.out, "│"+centerText("Zで1手戻す", 30)+"│")
+	}
+	fmt.Fprintln(d.out, "│"+centerText("Rでリトライ、Qで終了", 30)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", 30)+"┘")
+}
+
+// padRight は全角文字を2列として、表示幅がwidthになるまで空白で埋める
+func padRight(text string, width int) string {
+	columns := 0
+	for _, r := range text {
+		if utf8.RuneLen(r) > 1 {
+			columns += 2
+		} else {
+			columns++
+		}
+	}
+	return text + strings.Repeat(" ", max(width-columns, 0))
+}
@@ -0,0 +1,114 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"tetris/application"
+)
+
+const (
+	GarbageMeterBlock = "▓"
+	versusBoardGap    = "    "
+)
+
+func (d *Display) RenderVersus(state application.VersusState) error {
+	if err := d.ClearScreen(); err != nil {
+		return fmt.Errorf("画面クリアエラー: %w", err)
+	}
+
+	d.printVersusHeader(state)
+	d.printVersusInfo(state)
+	d.printVersusBoards(state)
+	d.printVersusControls()
+
+	if state.Finished {
+		d.printVersusResult(state)
+	} else if state.Paused {
+		fmt.Fprintln(d.out)
+		fmt.Fprintln(d.out, "一時停止中（Pで再開）")
+	}
+
+	return nil
+}
+
+// versusWidth は横に並べた盤面全体の表示幅。盤面ごとにおじゃまメーターと左右の枠の3列を加える
+func (d *Display) versusWidth(state application.VersusState) int {
+	width := len(versusBoardGap) * (application.VersusPlayers - 1)
+	for _, player := range state.Players {
+		width += player.Game.Board.Width*2 + 3
+	}
+	return width
+}
+
+func (d *Display) printVersusHeader(state application.VersusState) {
+	width := d.versusWidth(state)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", width)+"┐")
+	fmt.Fprintln(d.out, "│"+centerText("テトリス 対戦", width)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", width)+"┘")
+}
+
+func (d *Display) printVersusInfo(state application.VersusState) {
+	columns := make([]string, 0, application.VersusPlayers)
+	for i, player := range state.Players {
+		columns = append(columns, fmt.Sprintf("P%d スコア:%-7d ライン:%-4d", i+1, player.Game.Score, player.Game.Lines))
+	}
+	fmt.Fprintln(d.out, strings.Join(columns, versusBoardGap))
+
+	columns = columns[:0]
+	for _, player := range state.Players {
+		columns = append(columns, fmt.Sprintf("   送信:%-4d 受信待ち:%-4d     ", player.Sent, player.PendingGarbage))
+	}
+	fmt.Fprintln(d.out, strings.Join(columns, versusBoardGap))
+}
+
+func (d *Display) printVersusBoards(state application.VersusState) {
+	boards := make([][]string, application.VersusPlayers)
+	for i, player := range state.Players {
+		boards[i] = d.withGarbageMeter(d.boardLines(player.Game), player.PendingGarbage)
+	}
+
+	for row := range boards[0] {
+		columns := make([]string, 0, application.VersusPlayers)
+		for _, board := range boards {
+			columns = append(columns, board[row])
+		}
+		fmt.Fprintln(d.out, strings.Join(columns, versusBoardGap))
+	}
+}
+
+// withGarbageMeter は盤面の行の左におじゃまメーターを付ける。最後の行は盤面の下枠
+func (d *Display) withGarbageMeter(lines []string, pending int) []string {
+	height := len(lines) - 1
+	meterTop := height - min(pending, height)
+
+	result := make([]string, len(lines))
+	for y, line := range lines {
+		meter := " "
+		if y < height && y >= meterTop {
+			meter = GarbageMeterBlock
+		}
+		result[y] = meter + line
+	}
+	return result
+}
+
+func (d *Display) printVersusControls() {
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "操作方法:")
+	fmt.Fprintln(d.out, "  P1: A/D 左右移動  S 下移動  W 回転  E 一気に落下")
+	fmt.Fprintln(d.out, "  P2: J/L 左右移動  K 下移動  I 回転  U 一気に落下")
+	fmt.Fprintln(d.out, "  P: 一時停止  R: リスタート  Q: 終了")
+}
+
+func (d *Display) printVersusResult(state application.VersusState) {
+	message := "引き分け！"
+	if state.Winner != application.NoWinner {
+		message = fmt.Sprintf("プレイヤー%dの勝利！", state.Winner+1)
+	}
+
+	fmt.Fprintln(d.out)
+	fmt.Fprintln(d.out, "┌"+strings.Repeat("─", 30)+"┐")
+	fmt.Fprintln(d.out, "│"+centerText(message, 30)+"│")
+	fmt.Fprintln(d.out, "│"+centerText("Rでリスタート、Qで終了", 30)+"│")
+	fmt.Fprintln(d.out, "└"+strings.Repeat("─", 30)+"┘")
+}
@@ -0,0 +1,536 @@
+package fumen
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"tetris/domain/model"
+	"unicode/utf16"
+)
+
+const (
+	// Prefix は対応する fumen のバージョン v115 のデータの先頭
+	Prefix = "v115@"
+
+	fieldWidth  = 10
+	fieldTop    = 23
+	fieldBlocks = (fieldTop + 1) * fieldWidth
+
+	encodeTable  = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	commentTable = " !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+	commentBase      = len(commentTable) + 1
+	maxCommentLength = 4095
+	maxRepeat        = len(encodeTable) - 1
+
+	// fumen のデータは先頭42文字の後、47文字ごとに'?'で区切る
+	firstChunkLength = 42
+	chunkLength      = 47
+)
+
+var ErrInvalidFumen = errors.New("無効なfumenです")
+
+// Page は fumen の1ページ。Boardはピースを置く前の盤面で、Pieceがnilならピースを置かない。
+// Lockがtrueならピースを固定してラインを消した盤面が次のページの元になり、Riseでせり上がり、Mirrorで左右反転する。
+// Commentは前のページと同じなら省略して保存する
+type Page struct {
+	Board   *model.Board
+	Piece   *model.Tetromino
+	Comment string
+	Lock    bool
+	Rise    bool
+	Mirror  bool
+}
+
+// legacyOffsets は v115 がO・I・S・Zの回転中心を旧来の位置で保存するための補正。デコード時に足し、エンコード時に引く
+var legacyOffsets = map[[2]int][2]int{
+	{blockO, rotationLeft}:    {1, -1},
+	{blockO, rotationReverse}: {1, 0},
+	{blockO, rotationSpawn}:   {0, -1},
+	{blockI, rotationReverse}: {1, 0},
+	{blockI, rotationLeft}:    {0, -1},
+	{blockS, rotationSpawn}:   {0, -1},
+	{blockS, rotationRight}:   {-1, 0},
+	{blockZ, rotationSpawn}:   {0, -1},
+	{blockZ, rotationLeft}:    {1, 0},
+}
+
+// field はせり上がり用の最下段の下の1行を含むフィールド。添字はエンコードの順で、最上段の左端から並ぶ
+type field [fieldBlocks]int
+
+func fieldIndex(x, y int) int {
+	return (fieldTop-1-y)*fieldWidth + x
+}
+
+// newField は盤面をフィールドに変換する。前のフィールドと同じ位置のブロックは色を引き継ぎ、新しいブロックは灰色にする
+func newField(board *model.Board, prev *field) (field, error) {
+	if board == nil {
+		return field{}, fmt.Errorf("%w: ボードがありません", ErrInvalidFumen)
+	}
+	if board.Width != fieldWidth || board.Height > fieldTop {
+		return field{}, fmt.Errorf("%w: ボードの大きさ%dx%dは幅%d・高さ%d以下である必要があります",
+			ErrInvalidFumen, board.Width, board.Height, fieldWidth, fieldTop)
+	}
+
+	var f field
+	copy(f[fieldIndex(0, -1):], prev[fieldIndex(0, -1):])
+	for row := 0; row < board.Height; row++ {
+		for x := 0; x < board.Width; x++ {
+			if !board.Grid[row][x] {
+				continue
+			}
+			i := fieldIndex(x, board.Height-1-row)
+			f[i] = prev[i]
+			if f[i] == blockEmpty {
+				f[i] = blockGray
+			}
+		}
+	}
+	return f, nil
+}
+
+// board はフィールドを高さheightの盤面に変換する。せり上がり用の行は含めない
+func (f *field) board(height int) (*model.Board, error) {
+	board, err := model.NewBoard(fieldWidth, height)
+	if err != nil {
+		return nil, err
+	}
+	for y := 0; y < fieldTop; y++ {
+		for x := 0; x < fieldWidth; x++ {
+			if f[fieldIndex(x, y)] == blockEmpty {
+				continue
+			}
+			if y >= height {
+				return nil, fmt.Errorf("%w: 高さ%dを超える位置にブロックがあります", ErrInvalidFumen, height)
+			}
+			board.Grid[height-1-y][x] = true
+		}
+	}
+	return board, nil
+}
+
+// lock はピースを固定してラインを消し、必要に応じてせり上げと左右反転をする
+func (f *field) lock(op operation, rise, mirror bool) {
+	if op.block != blockEmpty {
+		for _, block := range op.blocks() {
+			f[fieldIndex(block[0], block[1])] = op.block
+		}
+	}
+
+	var cleared field
+	copy(cleared[fieldIndex(0, -1):], f[fieldIndex(0, -1):])
+	y := 0
+	for row := 0; row < fieldTop; row++ {
+		line := f[fieldIndex(0, row) : fieldIndex(0, row)+fieldWidth]
+		if isLineFull(line) {
+			continue
+		}
+		copy(cleared[fieldIndex(0, y):], line)
+		y++
+	}
+	*f = cleared
+
+	if rise {
+		copy(f[:fieldIndex(0, -1)], f[fieldIndex(0, fieldTop-2):])
+		for x := 0; x < fieldWidth; x++ {
+			f[fieldIndex(x, -1)] = blockEmpty
+		}
+	}
+	if mirror {
+		for row := -1; row < fieldTop; row++ {
+			line := f[fieldIndex(0, row) : fieldIndex(0, row)+fieldWidth]
+			for left, right := 0, fieldWidth-1; left < right; left, right = left+1, right-1 {
+				line[left], line[right] = line[right], line[left]
+			}
+		}
+	}
+}
+
+func isLineFull(line []int) bool {
+	for _, block := range line {
+		if block == blockEmpty {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode はページを v115 の fumen 文字列に変換する。ボードは幅10・高さ23以下で、ブロックの色は前のページから引き継ぐか灰色になる
+func Encode(pages []Page) (string, error) {
+	if len(pages) == 0 {
+		return "", fmt.Errorf("%w: ページがありません", ErrInvalidFumen)
+	}
+
+	var w writer
+	var prev field
+	prevComment := ""
+	repeatIndex := -1
+	for i, page := range pages {
+		current, err := newField(page.Board, &prev)
+		if err != nil {
+			return "", fmt.Errorf("%dページ目: %w", i+1, err)
+		}
+		repeatIndex = w.writeField(&prev, &current, repeatIndex)
+
+		op := operation{}
+		if page.Piece != nil {
+			if op, err = newOperation(page.Piece, page.Board.Height); err != nil {
+				return "", fmt.Errorf("%dページ目: %w", i+1, err)
+			}
+		}
+
+		hasComment := page.Comment != prevComment
+		w.push(encodeAction(op, actionFlags{
+			lock:     page.Lock,
+			comment:  hasComment,
+			colorize: i == 0,
+			mirror:   page.Mirror,
+			rise:     page.Rise,
+		}), 3)
+		if hasComment {
+			if err := w.writeComment(page.Comment); err != nil {
+				return "", fmt.Errorf("%dページ目: %w", i+1, err)
+			}
+			prevComment = page.Comment
+		}
+
+		if page.Lock {
+			current.lock(op, page.Rise, page.Mirror)
+		}
+		prev = current
+	}
+
+	return Prefix + splitData(w.String()), nil
+}
+
+// Decode は v115 の fumen 文字列をページに変換する。URLの'?'より前の部分は読み飛ばす。
+// ボードは model.BoardHeight の高さになり、それを超える位置のブロックやピースはエラーになる
+func Decode(data string) ([]Page, error) {
+	start := strings.Index(data, Prefix)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: %sで始まるデータがありません", ErrInvalidFumen, Prefix)
+	}
+	r := &reader{data: strings.Map(func(c rune) rune {
+		if c == '?' || c == ' ' || c == '\n' || c == '\r' || c == '\t' {
+			return -1
+		}
+		return c
+	}, data[start+len(Prefix):])}
+
+	var pages []Page
+	var prev field
+	comment := ""
+	repeat := 0
+	for !r.done() {
+		current := prev
+		if repeat > 0 {
+			repeat--
+		} else {
+			var err error
+			if repeat, err = r.readField(&current); err != nil {
+				return nil, err
+			}
+		}
+
+		page, op, err := r.readPage(&current, comment)
+		if err != nil {
+			return nil, fmt.Errorf("%dページ目: %w", len(pages)+1, err)
+		}
+		comment = page.Comment
+		pages = append(pages, page)
+
+		if page.Lock {
+			current.lock(op, page.Rise, page.Mirror)
+		}
+		prev = current
+	}
+
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("%w: ページがありません", ErrInvalidFumen)
+	}
+	return pages, nil
+}
+
+type actionFlags struct {
+	lock     bool
+	comment  bool
+	colorize bool
+	mirror   bool
+	rise     bool
+}
+
+func encodeAction(op operation, flags actionFlags) int {
+	value := 0
+	for _, flag := range []bool{!flags.lock, flags.comment, flags.colorize, flags.mirror, flags.rise} {
+		value *= 2
+		if flag {
+			value++
+		}
+	}
+	value = value*fieldBlocks + encodeCoordinate(op)
+	value = value*4 + op.rotation
+	return value*8 + op.block
+}
+
+func decodeAction(value int) (operation, actionFlags) {
+	op := operation{block: value % 8}
+	value /= 8
+	op.rotation = value % 4
+	value /= 4
+	op.x, op.y = decodeCoordinate(value%fieldBlocks, op.block, op.rotation)
+	value /= fieldBlocks
+
+	var flags actionFlags
+	for _, flag := range []*bool{&flags.rise, &flags.mirror, &flags.colorize, &flags.comment, &flags.lock} {
+		*flag = value%2 == 1
+		value /= 2
+	}
+	flags.lock = !flags.lock
+	return op, flags
+}
+
+func encodeCoordinate(op operation) int {
+	if op.block == blockEmpty {
+		return 0
+	}
+	offset := legacyOffsets[[2]int{op.block, op.rotation}]
+	x, y := op.x-offset[0], op.y-offset[1]
+	return fieldIndex(x, y)
+}
+
+func decodeCoordinate(value, block, rotation int) (int, int) {
+	offset := legacyOffsets[[2]int{block, rotation}]
+	x := value%fieldWidth + offset[0]
+	y := fieldTop - value/fieldWidth - 1 + offset[1]
+	return x, y
+}
+
+// readPage はフィールドの後に続くピースとコメントを読む。コメントがなければ前のページのコメントを引き継ぐ
+func (r *reader) readPage(current *field, prevComment string) (Page, operation, error) {
+	value, err := r.poll(3)
+	if err != nil {
+		return Page{}, operation{}, err
+	}
+	op, flags := decodeAction(value)
+
+	page := Page{Comment: prevComment, Lock: flags.lock, Rise: flags.rise, Mirror: flags.mirror}
+	if flags.comment {
+		if page.Comment, err = r.readComment(); err != nil {
+			return Page{}, operation{}, err
+		}
+	}
+
+	if page.Board, err = current.board(model.BoardHeight); err != nil {
+		return Page{}, operation{}, err
+	}
+	if op.block != blockEmpty {
+		if page.Piece, err = op.toTetromino(model.BoardHeight); err != nil {
+			return Page{}, operation{}, err
+		}
+	}
+	return page, op, nil
+}
+
+type writer struct {
+	values []int
+}
+
+func (w *writer) push(value, digits int) {
+	for i := 0; i < digits; i++ {
+		w.values = append(w.values, value%len(encodeTable))
+		value /= len(encodeTable)
+	}
+}
+
+func (w *writer) String() string {
+	var builder strings.Builder
+	for _, value := range w.values {
+		builder.WriteByte(encodeTable[value])
+	}
+	return builder.String()
+}
+
+// writeField は前のページとの差分を連長圧縮で書く。変化がなければ直前の繰り返し回数を増やし、その位置を返す
+func (w *writer) writeField(prev, current *field, repeatIndex int) int {
+	type run struct{ diff, count int }
+	var runs []run
+	for i := range current {
+		diff := current[i] - prev[i] + blockGray
+		if len(runs) > 0 && runs[len(runs)-1].diff == diff {
+			runs[len(runs)-1].count++
+			continue
+		}
+		runs = append(runs, run{diff: diff, count: 1})
+	}
+
+	unchanged := len(runs) == 1 && runs[0].diff == blockGray
+	if unchanged && repeatIndex >= 0 && w.values[repeatIndex] < maxRepeat {
+		w.values[repeatIndex]++
+		return repeatIndex
+	}
+
+	for _, r := range runs {
+		w.push(r.diff*fieldBlocks+r.count-1, 2)
+	}
+	if !unchanged {
+		return -1
+	}
+	w.push(0, 1)
+	return len(w.values) - 1
+}
+
+func (w *writer) writeComment(comment string) error {
+	escaped := escape(comment)
+	if len(escaped) > maxCommentLength {
+		return fmt.Errorf("%w: コメントが長すぎます", ErrInvalidFumen)
+	}
+
+	w.push(len(escaped), 2)
+	for i := 0; i < len(escaped); i += 4 {
+		value := 0
+		for j := min(i+4, len(escaped)) - 1; j >= i; j-- {
+			value = value*commentBase + strings.IndexByte(commentTable, escaped[j])
+		}
+		w.push(value, 5)
+	}
+	return nil
+}
+
+type reader struct {
+	data string
+	pos  int
+}
+
+func (r *reader) done() bool {
+	return r.pos >= len(r.data)
+}
+
+func (r *reader) poll(digits int) (int, error) {
+	if r.pos+digits > len(r.data) {
+		return 0, fmt.Errorf("%w: データが途中で終わっています", ErrInvalidFumen)
+	}
+
+	value, scale := 0, 1
+	for i := 0; i < digits; i++ {
+		digit := strings.IndexByte(encodeTable, r.data[r.pos+i])
+		if digit < 0 {
+			return 0, fmt.Errorf("%w: 不明な文字 %q", ErrInvalidFumen, r.data[r.pos+i])
+		}
+		value += digit * scale
+		scale *= len(encodeTable)
+	}
+	r.pos += digits
+	return value, nil
+}
+
+// readField は差分を前のページのフィールドに適用する。変化がなければ後に続く同じページの数を返す
+func (r *reader) readField(current *field) (int, error) {
+	for i := 0; i < fieldBlocks; {
+		value, err := r.poll(2)
+		if err != nil {
+			return 0, err
+		}
+		diff, count := value/fieldBlocks, value%fieldBlocks+1
+		if i+count > fieldBlocks {
+			return 0, fmt.Errorf("%w: フィールドの差分が範囲を超えています", ErrInvalidFumen)
+		}
+		if diff == blockGray && count == fieldBlocks {
+			return r.poll(1)
+		}
+
+		for ; count > 0; count-- {
+			current[i] += diff - blockGray
+			if current[i] < blockEmpty || current[i] > blockGray {
+				return 0, fmt.Errorf("%w: 不明なブロック%d", ErrInvalidFumen, current[i])
+			}
+			i++
+		}
+	}
+	return 0, nil
+}
+
+func (r *reader) readComment() (string, error) {
+	length, err := r.poll(2)
+	if err != nil {
+		return "", err
+	}
+
+	var escaped strings.Builder
+	for i := 0; i < length; i += 4 {
+		value, err := r.poll(5)
+		if err != nil {
+			return "", err
+		}
+		for j := i; j < min(i+4, length); j++ {
+			index := value % commentBase
+			if index >= len(commentTable) {
+				return "", fmt.Errorf("%w: コメントに不明な文字があります", ErrInvalidFumen)
+			}
+			escaped.WriteByte(commentTable[index])
+			value /= commentBase
+		}
+	}
+	return unescape(escaped.String())
+}
+
+// splitData は fumen のエディタと同じ位置に区切りの'?'を入れる
+func splitData(data string) string {
+	if len(data) <= firstChunkLength {
+		return data
+	}
+
+	chunks := []string{data[:firstChunkLength]}
+	for rest := data[firstChunkLength:]; len(rest) > 0; {
+		size := min(chunkLength, len(rest))
+		chunks = append(chunks, rest[:size])
+		rest = rest[size:]
+	}
+	return strings.Join(chunks, "?")
+}
+
+// escape は JavaScript の escape と同じ規則で、コメントをUTF-16の単位ごとに%XXまたは%uXXXXへ変換する
+func escape(text string) string {
+	var builder strings.Builder
+	for _, unit := range utf16.Encode([]rune(text)) {
+		switch {
+		case unit < 0x80 && isUnescaped(byte(unit)):
+			builder.WriteByte(byte(unit))
+		case unit < 0x100:
+			fmt.Fprintf(&builder, "%%%02X", unit)
+		default:
+			fmt.Fprintf(&builder, "%%u%04X", unit)
+		}
+	}
+	return builder.String()
+}
+
+func isUnescaped(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("@*_+-./", c) >= 0
+}
+
+func unescape(text string) (string, error) {
+	var units []uint16
+	for i := 0; i < len(text); {
+		if text[i] != '%' {
+			units = append(units, uint16(text[i]))
+			i++
+			continue
+		}
+
+		digits, width := 2, 1
+		if i+1 < len(text) && text[i+1] == 'u' {
+			digits, width = 4, 2
+		}
+		if i+width+digits > len(text) {
+			return "", fmt.Errorf("%w: コメントのエスケープが途中で終わっています", ErrInvalidFumen)
+		}
+		unit, err := strconv.ParseUint(text[i+width:i+width+digits], 16, 16)
+		if err != nil {
+			return "", fmt.Errorf("%w: コメントのエスケープ: %w", ErrInvalidFumen, err)
+		}
+		units = append(units, uint16(unit))
+		i += width + digits
+	}
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,275 @@
+package fumen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"tetris/domain/model"
+)
+
+// boardFromRows は下揃えの行の並び（model.ParseBoard の表記）から標準の大きさの盤面を作る。
+// fumen の盤面はピースの種類を持たないため、ブロックはすべておじゃまブロック（'X'）で表す
+func boardFromRows(t *testing.T, rows ...string) *model.Board {
+	t.Helper()
+	board, err := model.ParseBoardRows(model.BoardWidth, model.BoardHeight, rows...)
+	if err != nil {
+		t.Fatalf("ParseBoardRows() error = %v", err)
+	}
+	return board
+}
+
+func assertBoard(t *testing.T, got, want *model.Board) {
+	t.Helper()
+	if got.String() != want.String() {
+		t.Errorf("board =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages func(t *testing.T) []Page
+		want  string
+	}{
+		{
+			name: "空のフィールド",
+			pages: func(t *testing.T) []Page {
+				return []Page{{Board: boardFromRows(t), Lock: true}}
+			},
+			want: "v115@vhAAgH",
+		},
+		{
+			name: "左下の1ブロック",
+			pages: func(t *testing.T) []Page {
+				return []Page{{Board: boardFromRows(t, "X........."), Lock: true}}
+			},
+			want: "v115@bhA8SeAgH",
+		},
+		{
+			name: "変化のないページは繰り返し回数にまとめる",
+			pages: func(t *testing.T) []Page {
+				return []Page{
+					{Board: boardFromRows(t), Lock: true},
+					{Board: boardFromRows(t), Lock: true},
+					{Board: boardFromRows(t), Lock: true},
+				}
+			},
+			want: "v115@vhCAgHAAAAAA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Encode(tt.pages(t))
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    [][]string
+		wantErr bool
+	}{
+		{name: "空のフィールド", data: "v115@vhAAgH", want: [][]string{nil}},
+		{
+			name: "パーフェクトクリアの土台",
+			data: "v115@9gF8DeF8DeF8DeF8NeAgH",
+			want: [][]string{{"XXXXXX....", "XXXXXX....", "XXXXXX....", "XXXXXX...."}},
+		},
+		{name: "URLの一部", data: "https://fumen.zui.jp/?v115@vhAAgH", want: [][]string{nil}},
+		{name: "繰り返し", data: "v115@vhCAgHAAAAAA", want: [][]string{nil, nil, nil}},
+		{name: "接頭辞がない", data: "vhAAgH", wantErr: true},
+		{name: "途中で終わる", data: "v115@vhAAg", wantErr: true},
+		{name: "不明な文字", data: "v115@vh!AgH", wantErr: true},
+		{name: "ページがない", data: "v115@", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages, err := Decode(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFumen) {
+					t.Errorf("Decode() error = %v, want %v", err, ErrInvalidFumen)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if len(pages) != len(tt.want) {
+				t.Fatalf("len(pages) = %d, want %d", len(pages), len(tt.want))
+			}
+			for i, page := range pages {
+				assertBoard(t, page.Board, boardFromRows(t, tt.want[i]...))
+				if page.Piece != nil || page.Comment != "" || !page.Lock {
+					t.Errorf("page %d = %+v, want locked page without piece and comment", i, page)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	tPiece, err := model.NewTetromino(model.T, model.Point{X: 3, Y: 17})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	iPiece, err := model.NewTetromino(model.I, model.Point{X: 5, Y: 16})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	if err := iPiece.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	pages := []Page{
+		{
+			Board:   boardFromRows(t, "XXX...XXXX", "XXXX.XXXXX"),
+			Piece:   tPiece,
+			Comment: "T-Spin? ダブル 🎉",
+			Lock:    true,
+		},
+		{Board: boardFromRows(t, "XXXXXXX.XX"), Piece: iPiece, Comment: "T-Spin? ダブル 🎉", Lock: true},
+		{Board: boardFromRows(t, "XXXXXXX.XX"), Comment: "100% clear"},
+		{Board: boardFromRows(t, "XXXXXXX.XX")},
+	}
+
+	data, err := Encode(pages)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode(%q) error = %v", data, err)
+	}
+	if len(decoded) != len(pages) {
+		t.Fatalf("len(Decode()) = %d, want %d", len(decoded), len(pages))
+	}
+
+	for i, want := range pages {
+		got := decoded[i]
+		assertBoard(t, got.Board, want.Board)
+		if got.Lock != want.Lock {
+			t.Errorf("page %d Lock = %v, want %v", i, got.Lock, want.Lock)
+		}
+		if got.Comment != want.Comment {
+			t.Errorf("page %d Comment = %q, want %q", i, got.Comment, want.Comment)
+		}
+		if (got.Piece == nil) != (want.Piece == nil) {
+			t.Fatalf("page %d Piece = %v, want %v", i, got.Piece, want.Piece)
+		}
+		if want.Piece != nil && !sameBlocks(got.Piece.GetBlocks(), want.Piece.GetBlocks()) {
+			t.Errorf("page %d Piece blocks = %v, want %v", i, got.Piece.GetBlocks(), want.Piece.GetBlocks())
+		}
+	}
+}
+
+func TestDecode_LockClearsLines(t *testing.T) {
+	iPiece, err := model.NewTetromino(model.I, model.Point{X: 3, Y: 17})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+	data, err := Encode([]Page{{Board: boardFromRows(t, "XXX....XXX", "X.XXXXXXXX"), Piece: iPiece, Lock: true}})
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	// 2ページ目はフィールドの差分がなく、1ページ目でIミノを固定して1ライン消した盤面になる
+	pages, err := Decode(data + "vhAAAA")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("len(pages) = %d, want 2", len(pages))
+	}
+	assertBoard(t, pages[1].Board, boardFromRows(t, "X.XXXXXXXX"))
+}
+
+func TestEncode_SplitsLongData(t *testing.T) {
+	var pages []Page
+	for i := 0; i < 20; i++ {
+		pages = append(pages, Page{Board: boardFromRows(t, strings.Repeat("X", i%9+1)+strings.Repeat(".", 9-i%9))})
+	}
+
+	data, err := Encode(pages)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	chunks := strings.Split(strings.TrimPrefix(data, Prefix), "?")
+	if len(chunks) < 2 || len(chunks[0]) != firstChunkLength || len(chunks[1]) > chunkLength {
+		t.Errorf("chunks = %v, want %d characters then up to %d", chunks, firstChunkLength, chunkLength)
+	}
+
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	for i := range pages {
+		assertBoard(t, decoded[i].Board, pages[i].Board)
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	wide, err := model.NewBoard(12, model.BoardHeight)
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	outside, err := model.NewTetromino(model.O, model.Point{X: 3, Y: -6})
+	if err != nil {
+		t.Fatalf("NewTetromino() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		pages []Page
+	}{
+		{name: "ページがない"},
+		{name: "ボードがない", pages: []Page{{}}},
+		{name: "幅が違う", pages: []Page{{Board: wide}}},
+		{name: "ピースがフィールドの外", pages: []Page{{Board: boardFromRows(t), Piece: outside}}},
+		{name: "コメントが長すぎる", pages: []Page{{Board: boardFromRows(t), Comment: strings.Repeat("あ", 700)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Encode(tt.pages); !errors.Is(err, ErrInvalidFumen) {
+				t.Errorf("Encode() error = %v, want %v", err, ErrInvalidFumen)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "abc-_.", want: "abc-_."},
+		{text: "a b", want: "a%20b"},
+		{text: "é", want: "%E9"},
+		{text: "テ", want: "%u30C6"},
+		{text: "🎉", want: "%uD83C%uDF89"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.text), func(t *testing.T) {
+			got := escape(tt.text)
+			if got != tt.want {
+				t.Errorf("escape() = %q, want %q", got, tt.want)
+			}
+			text, err := unescape(got)
+			if err != nil || text != tt.text {
+				t.Errorf("unescape(%q) = %q, %v, want %q", got, text, err, tt.text)
+			}
+		})
+	}
+}
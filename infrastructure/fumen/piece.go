@@ -0,0 +1,191 @@
+package fumen
+
+import (
+	"fmt"
+	"tetris/domain/model"
+)
+
+// fumen のブロックの色。ピースの色は model.TetrominoType と並びが異なる
+const (
+	blockEmpty = 0
+	blockI     = 1
+	blockL     = 2
+	blockO     = 3
+	blockZ     = 4
+	blockT     = 5
+	blockJ     = 6
+	blockS     = 7
+	blockGray  = 8
+)
+
+// fumen の向き。エンコード時の値の順に並ぶ
+const (
+	rotationReverse = 0
+	rotationRight   = 1
+	rotationSpawn   = 2
+	rotationLeft    = 3
+)
+
+var pieceBlocks = map[model.TetrominoType]int{
+	model.I: blockI, model.L: blockL, model.O: blockO, model.Z: blockZ,
+	model.T: blockT, model.J: blockJ, model.S: blockS,
+}
+
+// pieceOffsets は出現時の向きのピースの、回転中心からのブロックの位置（上向きが正）
+var pieceOffsets = map[int][4][2]int{
+	blockI: {{0, 0}, {-1, 0}, {1, 0}, {2, 0}},
+	blockL: {{0, 0}, {-1, 0}, {1, 0}, {1, 1}},
+	blockO: {{0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	blockZ: {{0, 0}, {1, 0}, {0, 1}, {-1, 1}},
+	blockT: {{0, 0}, {-1, 0}, {1, 0}, {0, 1}},
+	blockJ: {{0, 0}, {-1, 0}, {1, 0}, {-1, 1}},
+	blockS: {{0, 0}, {-1, 0}, {0, 1}, {1, 1}},
+}
+
+// operation は fumen のピースの置き方。X, Yは回転中心で、Yはフィールドの最下段を0とする
+type operation struct {
+	block    int
+	rotation int
+	x        int
+	y        int
+}
+
+func (op operation) blocks() [4][2]int {
+	blocks := pieceOffsets[op.block]
+	for i, offset := range blocks {
+		dx, dy := offset[0], offset[1]
+		switch op.rotation {
+		case rotationRight:
+			dx, dy = dy, -dx
+		case rotationReverse:
+			dx, dy = -dx, -dy
+		case rotationLeft:
+			dx, dy = -dy, dx
+		}
+		blocks[i] = [2]int{op.x + dx, op.y + dy}
+	}
+	return blocks
+}
+
+// modelRotation は fumen の向きを model.Tetromino の回転数に変換する。
+// I・S・Zは向きが2つしかないため、逆向きは出現時の向きと、左向きは右向きと同じ形になる
+func modelRotation(rotation, rotationCount int) int {
+	index := map[int]int{rotationSpawn: 0, rotationRight: 1, rotationReverse: 2, rotationLeft: 3}[rotation]
+	return index % rotationCount
+}
+
+func fumenRotation(rotation int) int {
+	return [...]int{rotationSpawn, rotationRight, rotationReverse, rotationLeft}[rotation]
+}
+
+// toTetromino は fumen のピースを高さheightのボード上の model.Tetromino に変換する
+func (op operation) toTetromino(height int) (*model.Tetromino, error) {
+	tetrominoType, err := blockTetromino(op.block)
+	if err != nil {
+		return nil, err
+	}
+	piece, err := model.NewTetromino(tetrominoType, model.Point{})
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < modelRotation(op.rotation, piece.RotationCount()); i++ {
+		if rotateErr := piece.Rotate(); rotateErr != nil {
+			return nil, rotateErr
+		}
+	}
+
+	var target []model.Point
+	for _, block := range op.blocks() {
+		point := model.Point{X: block[0], Y: height - 1 - block[1]}
+		if point.X < 0 || point.X >= fieldWidth || point.Y < 0 || point.Y >= height {
+			return nil, fmt.Errorf("%w: ピース%sがボードの外にあります", ErrInvalidFumen, tetrominoType)
+		}
+		target = append(target, point)
+	}
+
+	origin, want := topLeft(piece.GetBlocks()), topLeft(target)
+	piece.Position = model.Point{X: want.X - origin.X, Y: want.Y - origin.Y}
+	if !sameBlocks(piece.GetBlocks(), target) {
+		return nil, fmt.Errorf("%w: ピース%sの形が一致しません", ErrInvalidFumen, tetrominoType)
+	}
+	return piece, nil
+}
+
+// newOperation は高さheightのボード上の model.Tetromino を fumen のピースに変換する
+func newOperation(piece *model.Tetromino, height int) (operation, error) {
+	op := operation{block: pieceBlocks[piece.Type], rotation: fumenRotation(piece.Rotation())}
+	if op.block == blockEmpty {
+		return operation{}, fmt.Errorf("%w: ピース%s", ErrInvalidFumen, piece.Type)
+	}
+
+	var target []model.Point
+	for _, block := range piece.GetBlocks() {
+		target = append(target, model.Point{X: block.X, Y: height - 1 - block.Y})
+	}
+
+	var offsets []model.Point
+	for _, block := range op.blocks() {
+		offsets = append(offsets, model.Point{X: block[0], Y: block[1]})
+	}
+	origin, want := bottomLeft(offsets), bottomLeft(target)
+	op.x, op.y = want.X-origin.X, want.Y-origin.Y
+
+	var blocks []model.Point
+	for _, block := range op.blocks() {
+		if block[0] < 0 || block[0] >= fieldWidth || block[1] < 0 || block[1] >= fieldTop {
+			return operation{}, fmt.Errorf("%w: ピース%sがフィールドの外にあります", ErrInvalidFumen, piece.Type)
+		}
+		blocks = append(blocks, model.Point{X: block[0], Y: block[1]})
+	}
+	if !sameBlocks(blocks, target) {
+		return operation{}, fmt.Errorf("%w: ピース%sの形が一致しません", ErrInvalidFumen, piece.Type)
+	}
+	return op, nil
+}
+
+func blockTetromino(block int) (model.TetrominoType, error) {
+	for pieceType, pieceBlock := range pieceBlocks {
+		if pieceBlock == block {
+			return pieceType, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: ピースの種類%d", ErrInvalidFumen, block)
+}
+
+// topLeft は下向きが正の座標で、最も左の列のうち最も上のブロックを返す
+func topLeft(points []model.Point) model.Point {
+	best := points[0]
+	for _, point := range points[1:] {
+		if point.X < best.X || (point.X == best.X && point.Y < best.Y) {
+			best = point
+		}
+	}
+	return best
+}
+
+// bottomLeft は上向きが正の座標で、最も左の列のうち最も上のブロックを返す
+func bottomLeft(points []model.Point) model.Point {
+	best := points[0]
+	for _, point := range points[1:] {
+		if point.X < best.X || (point.X == best.X && point.Y > best.Y) {
+			best = point
+		}
+	}
+	return best
+}
+
+func sameBlocks(a, b []model.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[model.Point]bool, len(a))
+	for _, point := range a {
+		set[point] = true
+	}
+	for _, point := range b {
+		if !set[point] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package fumen
+
+import (
+	"testing"
+	"tetris/domain/model"
+)
+
+func TestOperation_ToTetromino(t *testing.T) {
+	tests := []struct {
+		name string
+		op   operation
+		want []model.Point
+	}{
+		{
+			name: "出現時の向きのT",
+			op:   operation{block: blockT, rotation: rotationSpawn, x: 4, y: 0},
+			want: []model.Point{{X: 3, Y: 19}, {X: 4, Y: 19}, {X: 5, Y: 19}, {X: 4, Y: 18}},
+		},
+		{
+			name: "右向きのJ",
+			op:   operation{block: blockJ, rotation: rotationRight, x: 0, y: 1},
+			want: []model.Point{{X: 0, Y: 17}, {X: 1, Y: 17}, {X: 0, Y: 18}, {X: 0, Y: 19}},
+		},
+		{
+			name: "逆向きのIは出現時の向きと同じ形",
+			op:   operation{block: blockI, rotation: rotationReverse, x: 2, y: 0},
+			want: []model.Point{{X: 0, Y: 19}, {X: 1, Y: 19}, {X: 2, Y: 19}, {X: 3, Y: 19}},
+		},
+		{
+			name: "左向きのS",
+			op:   operation{block: blockS, rotation: rotationLeft, x: 9, y: 1},
+			want: []model.Point{{X: 8, Y: 17}, {X: 8, Y: 18}, {X: 9, Y: 18}, {X: 9, Y: 19}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			piece, err := tt.op.toTetromino(model.BoardHeight)
+			if err != nil {
+				t.Fatalf("toTetromino() error = %v", err)
+			}
+			if !sameBlocks(piece.GetBlocks(), tt.want) {
+				t.Errorf("blocks = %v, want %v", piece.GetBlocks(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOperation_RoundTrip(t *testing.T) {
+	for block := blockI; block <= blockS; block++ {
+		for rotation := rotationReverse; rotation <= rotationLeft; rotation++ {
+			op := operation{block: block, rotation: rotation, x: 4, y: 5}
+			piece, err := op.toTetromino(model.BoardHeight)
+			if err != nil {
+				t.Fatalf("toTetromino(%+v) error = %v", op, err)
+			}
+
+			encoded, err := newOperation(piece, model.BoardHeight)
+			if err != nil {
+				t.Fatalf("newOperation(%+v) error = %v", op, err)
+			}
+			decoded, flags := decodeAction(encodeAction(encoded, actionFlags{lock: true}))
+			if !flags.lock {
+				t.Errorf("decodeAction() lock = false, want true")
+			}
+
+			roundTrip, err := decoded.toTetromino(model.BoardHeight)
+			if err != nil {
+				t.Fatalf("toTetromino(%+v) error = %v", decoded, err)
+			}
+			if roundTrip.Type != piece.Type || !sameBlocks(roundTrip.GetBlocks(), piece.GetBlocks()) {
+				t.Errorf("round trip of %+v = %v, want %v", op, roundTrip.GetBlocks(), piece.GetBlocks())
+			}
+		}
+	}
+}
+
+func TestEncodeCoordinate_Legacy(t *testing.T) {
+	tests := []struct {
+		name string
+		op   operation
+		want int
+	}{
+		{name: "T", op: operation{block: blockT, rotation: rotationSpawn, x: 4, y: 0}, want: 224},
+		{name: "Oは左上の位置", op: operation{block: blockO, rotation: rotationSpawn, x: 4, y: 0}, want: 214},
+		{name: "逆向きのI", op: operation{block: blockI, rotation: rotationReverse, x: 5, y: 0}, want: 224},
+		{name: "ピースなし", op: operation{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeCoordinate(tt.op); got != tt.want {
+				t.Errorf("encodeCoordinate() = %d, want %d", got, tt.want)
+			}
+			if tt.op.block == blockEmpty {
+				return
+			}
+			if x, y := decodeCoordinate(tt.want, tt.op.block, tt.op.rotation); x != tt.op.x || y != tt.op.y {
+				t.Errorf("decodeCoordinate() = (%d, %d), want (%d, %d)", x, y, tt.op.x, tt.op.y)
+			}
+		})
+	}
+}
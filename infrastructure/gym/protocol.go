@@ -0,0 +1,123 @@
+package gym
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"tetris/application/rl"
+)
+
+const MaxRequestSize = 1 << 16
+
+var ErrInvalidRequest = errors.New("無効なリクエストです")
+
+type Op string
+
+const (
+	OpReset Op = "reset"
+	OpStep  Op = "step"
+	OpSpec  Op = "spec"
+	OpClose Op = "close"
+)
+
+// Request は1行1リクエストのJSON。actionは行動名（"left"）または行動空間の添字（1）のどちらでもよい
+type Request struct {
+	Op     Op              `json:"op"`
+	Seed   uint64          `json:"seed,omitempty"`
+	Action json.RawMessage `json:"action,omitempty"`
+}
+
+// Response はリクエストごとに1行返す。resetはobservation、stepはobservation・reward・done・infoを持つ
+type Response struct {
+	Observation *rl.Observation `json:"observation,omitempty"`
+	Reward      float64         `json:"reward"`
+	Done        bool            `json:"done"`
+	Info        *rl.Info        `json:"info,omitempty"`
+	Actions     []rl.Action     `json:"actions,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// Serve はreaderからリクエストを読み、環境を進めた結果をwriterに書く。closeリクエストか入力の終端で終わる。
+// 不正なリクエストにはerrorを返して処理を続けるため、学習側は1つの誤りでセッションを失わない
+func Serve(env *rl.Environment, reader io.Reader, writer io.Writer) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 1024), MaxRequestSize)
+	output := bufio.NewWriter(writer)
+	encoder := json.NewEncoder(output)
+
+	for scanner.Scan() {
+		var request Request
+		response, done := Response{}, false
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			response.Error = fmt.Sprintf("%v: %v", ErrInvalidRequest, err)
+		} else {
+			response, done = handle(env, request)
+		}
+
+		if err := encoder.Encode(response); err != nil {
+			return fmt.Errorf("レスポンス送信エラー: %w", err)
+		}
+		if err := output.Flush(); err != nil {
+			return fmt.Errorf("レスポンス送信エラー: %w", err)
+		}
+		if done {
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("リクエスト受信エラー: %w", err)
+	}
+	return nil
+}
+
+func handle(env *rl.Environment, request Request) (Response, bool) {
+	switch request.Op {
+	case OpReset:
+		observation, err := env.Reset(request.Seed)
+		if err != nil {
+			return Response{Error: err.Error()}, false
+		}
+		return Response{Observation: &observation}, false
+
+	case OpStep:
+		action, err := parseAction(request.Action)
+		if err != nil {
+			return Response{Error: err.Error()}, false
+		}
+		result, err := env.Step(action)
+		if err != nil {
+			return Response{Error: err.Error()}, false
+		}
+		return Response{
+			Observation: &result.Observation,
+			Reward:      result.Reward,
+			Done:        result.Done,
+			Info:        &result.Info,
+		}, false
+
+	case OpSpec:
+		return Response{Actions: rl.Actions}, false
+
+	case OpClose:
+		return Response{Done: true}, true
+
+	default:
+		return Response{Error: fmt.Sprintf("%v: 不明な操作 %q", ErrInvalidRequest, request.Op)}, false
+	}
+}
+
+func parseAction(raw json.RawMessage) (rl.Action, error) {
+	var index int
+	if err := json.Unmarshal(raw, &index); err == nil {
+		return rl.ActionAt(index)
+	}
+
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return "", fmt.Errorf("%w: %s", rl.ErrInvalidAction, raw)
+	}
+	return rl.Action(name), nil
+}
@@ -0,0 +1,147 @@
+package gym
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"tetris/application/rl"
+)
+
+func serveLines(t testing.TB, lines ...string) []Response {
+	t.Helper()
+
+	var output bytes.Buffer
+	if err := Serve(rl.NewEnvironment(rl.Config{}), strings.NewReader(strings.Join(lines, "\n")+"\n"), &output); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	var responses []Response
+	decoder := json.NewDecoder(&output)
+	for {
+		var response Response
+		if err := decoder.Decode(&response); err == io.EOF {
+			return responses
+		} else if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		responses = append(responses, response)
+	}
+}
+
+func TestServe(t *testing.T) {
+	responses := serveLines(t,
+		`{"op":"spec"}`,
+		`{"op":"step","action":"left"}`,
+		`{"op":"reset","seed":9}`,
+		`{"op":"step","action":"left"}`,
+		`{"op":"step","action":5}`,
+		`{"op":"step","action":99}`,
+		`{"op":"step","action":"jump"}`,
+		`not json`,
+		`{"op":"fly"}`,
+		`{"op":"close"}`,
+		`{"op":"reset"}`,
+	)
+
+	tests := []struct {
+		name  string
+		check func(Response) bool
+	}{
+		{name: "spec", check: func(r Response) bool { return len(r.Actions) == len(rl.Actions) }},
+		{name: "reset前のstep", check: func(r Response) bool { return r.Error != "" }},
+		{name: "reset", check: func(r Response) bool { return r.Observation != nil && r.Observation.Current != nil }},
+		{name: "行動名", check: func(r Response) bool { return r.Error == "" && r.Info != nil && r.Info.Steps == 1 }},
+		{name: "行動の添字", check: func(r Response) bool { return r.Error == "" && r.Info.PiecesLocked == 1 }},
+		{name: "範囲外の添字", check: func(r Response) bool { return r.Error != "" }},
+		{name: "不明な行動", check: func(r Response) bool { return r.Error != "" }},
+		{name: "不正なJSON", check: func(r Response) bool { return r.Error != "" }},
+		{name: "不明な操作", check: func(r Response) bool { return r.Error != "" }},
+		{name: "close", check: func(r Response) bool { return r.Done }},
+	}
+
+	if len(responses) != len(tests) {
+		t.Fatalf("got %d responses, want %d (requests after close must be ignored)", len(responses), len(tests))
+	}
+	for i, tt := range tests {
+		if !tt.check(responses[i]) {
+			t.Errorf("%s: unexpected response %+v", tt.name, responses[i])
+		}
+	}
+}
+
+func TestServer_IndependentEnvironments(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	server := NewServer(rl.Config{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	request := func(conn net.Conn, reader *bufio.Reader, line string) Response {
+		t.Helper()
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		data, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("ReadBytes() error = %v", err)
+		}
+		var response Response
+		if err := json.Unmarshal(data, &response); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		return response
+	}
+
+	var observations []*rl.Observation
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			t.Fatalf("Dial() error = %v", err)
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		request(conn, reader, `{"op":"reset","seed":4}`)
+		if i == 0 {
+			request(conn, reader, `{"op":"step","action":"drop"}`)
+		}
+		observations = append(observations, request(conn, reader, `{"op":"step","action":"noop"}`).Observation)
+	}
+
+	// 1つ目の接続でだけピースを固定しても、2つ目の接続の盤面は空のまま
+	for i, expected := range []int{4, 0} {
+		filled := 0
+		for _, row := range observations[i].Board {
+			for _, cell := range row {
+				filled += cell
+			}
+		}
+		if filled != expected {
+			t.Errorf("connection %d: %d filled cells, want %d", i, filled, expected)
+		}
+	}
+}
+
+func BenchmarkServe(b *testing.B) {
+	actions := []string{`"left"`, `"rotate"`, `"right"`, `"down"`, `"drop"`}
+	var input strings.Builder
+	input.WriteString(`{"op":"reset","seed":1}` + "\n")
+	for i := 0; i < b.N; i++ {
+		input.WriteString(`{"op":"step","action":` + actions[i%len(actions)] + "}\n")
+		if i%200 == 199 {
+			input.WriteString(`{"op":"reset","seed":1}` + "\n")
+		}
+	}
+
+	b.ResetTimer()
+	if err := Serve(rl.NewEnvironment(rl.Config{}), strings.NewReader(input.String()), io.Discard); err != nil {
+		b.Fatalf("Serve() error = %v", err)
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "steps/s")
+}
@@ -1,11 +1,15 @@
 package input
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 )
@@ -13,13 +17,31 @@ import (
 var (
 	ErrInputCancelled = errors.New("入力がキャンセルされました")
 	ErrInvalidInput   = errors.New("無効な入力です")
+	ErrRawModeFailed  = errors.New("端末をRAWモードに設定できません")
 )
 
+const (
+	escapeKey    = 0x1b
+	interruptKey = 0x03
+)
+
+var escapeSequences = map[byte]string{
+	'A': "up",
+	'B': "down",
+	'C': "right",
+	'D': "left",
+}
+
 type KeyboardInput struct {
-	inputChan chan string
-	ctx       context.Context
-	cancel    context.CancelFunc
-	once      sync.Once
+	inputChan    chan string
+	ctx          context.Context
+	cancel       context.CancelFunc
+	once         sync.Once
+	sendMu       sync.Mutex
+	reader       io.Reader
+	remote       bool
+	raw          bool
+	savedTTYMode string
 }
 
 func NewKeyboardInput() *KeyboardInput {
@@ -28,22 +50,66 @@ func NewKeyboardInput() *KeyboardInput {
 		inputChan: make(chan string, 10),
 		ctx:       ctx,
 		cancel:    cancel,
+		reader:    os.Stdin,
+	}
+}
+
+// NewRemoteKeyboardInput はSSHのPTYなど、既にRAWモードの端末から届くキー入力を読み込む
+func NewRemoteKeyboardInput(reader io.Reader) *KeyboardInput {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &KeyboardInput{
+		inputChan: make(chan string, 10),
+		ctx:       ctx,
+		cancel:    cancel,
+		reader:    reader,
+		remote:    true,
+		raw:       true,
 	}
 }
 
 func (k *KeyboardInput) Start() error {
-	k.setupSignalHandler()
+	if !k.remote {
+		k.setupSignalHandler()
+	}
 	go k.readInput()
 	return nil
 }
 
+func (k *KeyboardInput) EnableRawMode() error {
+	saved, err := runStty("-g")
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRawModeFailed, err)
+	}
+
+	if _, err := runStty("-icanon", "-echo", "min", "1"); err != nil {
+		return fmt.Errorf("%w: %v", ErrRawModeFailed, err)
+	}
+
+	k.savedTTYMode = strings.TrimSpace(saved)
+	k.raw = true
+	return nil
+}
+
 func (k *KeyboardInput) Stop() {
 	k.once.Do(func() {
 		k.cancel()
+		k.sendMu.Lock()
 		close(k.inputChan)
+		k.sendMu.Unlock()
+		if k.raw && !k.remote {
+			_, _ = runStty(k.savedTTYMode)
+		}
 	})
 }
 
+func (k *KeyboardInput) Inputs() <-chan string {
+	return k.inputChan
+}
+
+func (k *KeyboardInput) Done() <-chan struct{} {
+	return k.ctx.Done()
+}
+
 func (k *KeyboardInput) GetInput() (string, error) {
 	select {
 	case input, ok := <-k.inputChan:
@@ -68,6 +134,11 @@ func (k *KeyboardInput) HasInput() bool {
 func (k *KeyboardInput) readInput() {
 	defer k.cancel()
 
+	if k.raw {
+		k.readRawInput()
+		return
+	}
+
 	for {
 		select {
 		case <-k.ctx.Done():
@@ -79,15 +150,77 @@ func (k *KeyboardInput) readInput() {
 				continue
 			}
 
-			select {
-			case k.inputChan <- input:
-			case <-k.ctx.Done():
+			if !k.send(input) {
 				return
 			}
 		}
 	}
 }
 
+func (k *KeyboardInput) readRawInput() {
+	reader := bufio.NewReader(k.reader)
+	for {
+		key, err := readKey(reader)
+		if err != nil || key == string(rune(interruptKey)) {
+			return
+		}
+
+		if !k.send(key) {
+			return
+		}
+	}
+}
+
+// send はStopによるチャネルのクローズと競合しないよう、キャンセル確認と送信をロック下で行う
+func (k *KeyboardInput) send(key string) bool {
+	k.sendMu.Lock()
+	defer k.sendMu.Unlock()
+
+	if k.ctx.Err() != nil {
+		return false
+	}
+	select {
+	case k.inputChan <- key:
+		return true
+	case <-k.ctx.Done():
+		return false
+	}
+}
+
+func readKey(reader *bufio.Reader) (string, error) {
+	b, err := reader.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	if b != escapeKey || reader.Buffered() < 2 {
+		return string(b), nil
+	}
+
+	if next, _ := reader.Peek(1); next[0] != '[' {
+		return string(b), nil
+	}
+	if _, err := reader.Discard(1); err != nil {
+		return "", err
+	}
+
+	code, err := reader.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	if key, exists := escapeSequences[code]; exists {
+		return key, nil
+	}
+	return string(b), nil
+}
+
+func runStty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	output, err := cmd.Output()
+	return string(output), err
+}
+
 func (k *KeyboardInput) setupSignalHandler() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -118,14 +251,27 @@ func MapInputToCommand(input string) (string, error) {
 		"Q":       "quit",
 		"r":       "restart",
 		"R":       "restart",
+		"c":       "hold",
+		"C":       "hold",
+		"z":       "undo",
+		"Z":       "undo",
+		"y":       "redo",
+		"Y":       "redo",
+		"f":       "export",
+		"F":       "export",
 		"left":    "left",
 		"right":   "right",
 		"down":    "down",
 		"rotate":  "rotate",
 		"drop":    "drop",
+		"up":      "rotate",
 		"pause":   "pause",
 		"quit":    "quit",
 		"restart": "restart",
+		"hold":    "hold",
+		"undo":    "undo",
+		"redo":    "redo",
+		"export":  "export",
 	}
 
 	if command, exists := commandMap[input]; exists {
@@ -134,3 +280,37 @@ func MapInputToCommand(input string) (string, error) {
 
 	return "", fmt.Errorf("%w: %s", ErrInvalidInput, input)
 }
+
+const (
+	PlayerOne = 0
+	PlayerTwo = 1
+)
+
+type VersusCommand struct {
+	Player  int
+	Command string
+}
+
+var versusKeyBindings = map[string]VersusCommand{
+	"a": {Player: PlayerOne, Command: "left"},
+	"d": {Player: PlayerOne, Command: "right"},
+	"s": {Player: PlayerOne, Command: "down"},
+	"w": {Player: PlayerOne, Command: "rotate"},
+	"e": {Player: PlayerOne, Command: "drop"},
+	"j": {Player: PlayerTwo, Command: "left"},
+	"l": {Player: PlayerTwo, Command: "right"},
+	"k": {Player: PlayerTwo, Command: "down"},
+	"i": {Player: PlayerTwo, Command: "rotate"},
+	"u": {Player: PlayerTwo, Command: "drop"},
+	"p": {Player: PlayerOne, Command: "pause"},
+	"q": {Player: PlayerOne, Command: "quit"},
+	"r": {Player: PlayerOne, Command: "restart"},
+}
+
+func MapVersusInputToCommand(input string) (VersusCommand, error) {
+	if command, exists := versusKeyBindings[strings.ToLower(input)]; exists && len(input) == 1 {
+		return command, nil
+	}
+
+	return VersusCommand{}, fmt.Errorf("%w: %s", ErrInvalidInput, input)
+}
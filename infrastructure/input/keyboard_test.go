@@ -1,7 +1,9 @@
 package input
 
 import (
+	"bufio"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -157,6 +159,39 @@ func TestMapInputToCommand(t *testing.T) {
 			expected:    "restart",
 			expectError: false,
 		},
+		// ホールドコマンド
+		{
+			name:        "小文字c - ホールド",
+			input:       "c",
+			expected:    "hold",
+			expectError: false,
+		},
+		// 取り消し・やり直しコマンド
+		{
+			name:        "小文字z - 取り消し",
+			input:       "z",
+			expected:    "undo",
+			expectError: false,
+		},
+		{
+			name:        "大文字Y - やり直し",
+			input:       "Y",
+			expected:    "redo",
+			expectError: false,
+		},
+		{
+			name:        "undo - 取り消し",
+			input:       "undo",
+			expected:    "undo",
+			expectError: false,
+		},
+		// fumen出力コマンド
+		{
+			name:        "大文字F - fumen出力",
+			input:       "F",
+			expected:    "export",
+			expectError: false,
+		},
 		// 無効な入力
 		{
 			name:        "無効な文字",
@@ -413,3 +448,148 @@ func TestInputCommandMapping_Completeness(t *testing.T) {
 		})
 	}
 }
+
+func TestMapVersusInputToCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    VersusCommand
+		expectError bool
+	}{
+		{
+			name:     "プレイヤー1 左移動",
+			input:    "a",
+			expected: VersusCommand{Player: PlayerOne, Command: "left"},
+		},
+		{
+			name:     "プレイヤー1 ドロップ",
+			input:    "E",
+			expected: VersusCommand{Player: PlayerOne, Command: "drop"},
+		},
+		{
+			name:     "プレイヤー2 回転",
+			input:    "i",
+			expected: VersusCommand{Player: PlayerTwo, Command: "rotate"},
+		},
+		{
+			name:     "プレイヤー2 右移動",
+			input:    "L",
+			expected: VersusCommand{Player: PlayerTwo, Command: "right"},
+		},
+		{
+			name:     "一時停止",
+			input:    "p",
+			expected: VersusCommand{Player: PlayerOne, Command: "pause"},
+		},
+		{
+			name:        "割り当てのないキー",
+			input:       "x",
+			expectError: true,
+		},
+		{
+			name:        "複数文字",
+			input:       "left",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := MapVersusInputToCommand(tt.input)
+
+			if tt.expectError {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("MapVersusInputToCommand() error = %v, wantErr %v", err, ErrInvalidInput)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("MapVersusInputToCommand() unexpected error = %v", err)
+			}
+
+			if command != tt.expected {
+				t.Errorf("MapVersusInputToCommand() = %+v, want %+v", command, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "通常のキー",
+			input:    "ad ",
+			expected: []string{"a", "d", " "},
+		},
+		{
+			name:     "矢印キー",
+			input:    "\x1b[A\x1b[B\x1b[C\x1b[D",
+			expected: []string{"up", "down", "right", "left"},
+		},
+		{
+			name:     "単独のエスケープ",
+			input:    "\x1b",
+			expected: []string{"\x1b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bufio.NewReader(strings.NewReader(tt.input))
+
+			for _, expected := range tt.expected {
+				key, err := readKey(reader)
+				if err != nil {
+					t.Fatalf("readKey() error = %v", err)
+				}
+				if key != expected {
+					t.Errorf("readKey() = %q, want %q", key, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoteKeyboardInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "キーと矢印キー",
+			input:    "a\x1b[Dq",
+			expected: []string{"a", "left", "q"},
+		},
+		{
+			name:     "Ctrl-Cで入力終了",
+			input:    "s\x03d",
+			expected: []string{"s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyboardInput := NewRemoteKeyboardInput(strings.NewReader(tt.input))
+			if err := keyboardInput.Start(); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			defer keyboardInput.Stop()
+
+			<-keyboardInput.Done()
+
+			var got []string
+			for len(keyboardInput.Inputs()) > 0 {
+				got = append(got, <-keyboardInput.Inputs())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("inputs = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
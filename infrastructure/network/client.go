@@ -0,0 +1,164 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"tetris/application"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+type PlayerView struct {
+	Snapshot application.GameSnapshot
+	Pending  int
+	Sent     int
+}
+
+type MatchView struct {
+	Player      int
+	Opponent    string
+	Match       int
+	PlayerCount int
+	Spectator   bool
+	Started     bool
+	Finished    bool
+	Winner      int
+	Players     [application.VersusPlayers]PlayerView
+}
+
+type Client struct {
+	conn *Conn
+
+	mu   sync.Mutex
+	view MatchView
+}
+
+func Dial(addr, name string) (*Client, error) {
+	return dial("tcp", addr, Message{Type: MessageHello, Name: name})
+}
+
+// Spectate は読み取り専用の観戦者として接続する。matchが0なら最新の対戦を観戦する
+func Spectate(network, addr string, match int) (*Client, error) {
+	return dial(network, addr, Message{Type: MessageSpectate, Match: match})
+}
+
+func dial(network, addr string, hello Message) (*Client, error) {
+	conn, err := net.DialTimeout(network, addr, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("サーバー接続エラー: %w", err)
+	}
+
+	client := &Client{
+		conn: NewConn(conn),
+		view: MatchView{
+			Winner:    application.NoWinner,
+			Spectator: hello.Type == MessageSpectate,
+		},
+	}
+
+	if err := client.conn.Send(hello); err != nil {
+		_ = client.conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (c *Client) SendInput(command string) error {
+	if c.View().Spectator {
+		return ErrSpectator
+	}
+	return c.conn.Send(Message{Type: MessageInput, Command: command})
+}
+
+func (c *Client) Receive() (Message, error) {
+	message, err := c.conn.Receive()
+	if err != nil {
+		return Message{}, err
+	}
+
+	if err := c.apply(message); err != nil {
+		return Message{}, err
+	}
+	return message, nil
+}
+
+func (c *Client) View() MatchView {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.view
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) apply(message Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch message.Type {
+	case MessageStart:
+		c.view.Player = message.Player
+		c.view.Opponent = message.Opponent
+		c.view.Match = message.Match
+		c.view.PlayerCount = message.PlayerCount
+		if c.view.PlayerCount <= 0 || c.view.PlayerCount > application.VersusPlayers {
+			c.view.PlayerCount = application.VersusPlayers
+		}
+		c.view.Started = true
+	case MessageState:
+		for _, delta := range message.Players {
+			if delta.Player < 0 || delta.Player >= c.view.PlayerCount {
+				return fmt.Errorf("%w: プレイヤー=%d", ErrInvalidMessage, delta.Player)
+			}
+
+			player := &c.view.Players[delta.Player]
+			snapshot, err := player.Snapshot.Apply(delta.Delta)
+			if err != nil {
+				return fmt.Errorf("状態適用エラー: %w", err)
+			}
+			player.Snapshot = snapshot
+			player.Pending = delta.Pending
+			player.Sent = delta.Sent
+		}
+	case MessageEnd:
+		c.view.Finished = true
+		c.view.Winner = message.Winner
+	case MessageError:
+		return fmt.Errorf("サーバーエラー: %s", message.Error)
+	}
+
+	return nil
+}
+
+func (v MatchView) GameState(player int) (application.GameState, error) {
+	if player < 0 || player >= v.PlayerCount {
+		return application.GameState{}, fmt.Errorf("%w: プレイヤー=%d", ErrInvalidMessage, player)
+	}
+	return v.Players[player].Snapshot.ToGameState()
+}
+
+func (v MatchView) VersusState() (application.VersusState, error) {
+	state := application.VersusState{
+		Finished: v.Finished,
+		Winner:   v.Winner,
+	}
+
+	for i, player := range v.Players {
+		game, err := player.Snapshot.ToGameState()
+		if err != nil {
+			return state, err
+		}
+		state.Players[i] = application.VersusPlayerState{
+			Game:           game,
+			PendingGarbage: player.Pending,
+			Sent:           player.Sent,
+		}
+	}
+
+	return state, nil
+}
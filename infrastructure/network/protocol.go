@@ -0,0 +1,119 @@
+package network
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"tetris/application"
+)
+
+const MaxFrameSize = 1 << 20
+
+type MessageType string
+
+const (
+	MessageHello    MessageType = "hello"
+	MessageSpectate MessageType = "spectate"
+	MessageWaiting  MessageType = "waiting"
+	MessageStart    MessageType = "start"
+	MessageInput    MessageType = "input"
+	MessageState    MessageType = "state"
+	MessageGarbage  MessageType = "garbage"
+	MessageEnd      MessageType = "end"
+	MessageError    MessageType = "error"
+)
+
+// SpectatorPlayer は観戦者に割り当てられるプレイヤー番号
+const SpectatorPlayer = -1
+
+var (
+	ErrConnectionClosed = errors.New("接続が閉じられました")
+	ErrInvalidMessage   = errors.New("無効なメッセージです")
+	ErrSpectator        = errors.New("観戦者は操作できません")
+)
+
+type PlayerDelta struct {
+	Player  int                       `json:"player"`
+	Delta   application.SnapshotDelta `json:"delta"`
+	Pending int                       `json:"pending"`
+	Sent    int                       `json:"sent"`
+}
+
+type GarbageEvent struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Lines int `json:"lines"`
+}
+
+type Message struct {
+	Type        MessageType   `json:"type"`
+	Name        string        `json:"name,omitempty"`
+	Player      int           `json:"player"`
+	Opponent    string        `json:"opponent,omitempty"`
+	Match       int           `json:"match,omitempty"`
+	PlayerCount int           `json:"playerCount,omitempty"`
+	Command     string        `json:"command,omitempty"`
+	Players     []PlayerDelta `json:"players,omitempty"`
+	Garbage     *GarbageEvent `json:"garbage,omitempty"`
+	Winner      int           `json:"winner"`
+	Error       string        `json:"error,omitempty"`
+}
+
+type Conn struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	writeMu sync.Mutex
+}
+
+func NewConn(conn net.Conn) *Conn {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), MaxFrameSize)
+
+	return &Conn{
+		conn:    conn,
+		scanner: scanner,
+	}
+}
+
+func (c *Conn) Send(message Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("メッセージエンコードエラー: %w", err)
+	}
+	data = append(data, '\n')
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if _, err := c.conn.Write(data); err != nil {
+		return fmt.Errorf("メッセージ送信エラー: %w", err)
+	}
+	return nil
+}
+
+func (c *Conn) Receive() (Message, error) {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return Message{}, fmt.Errorf("メッセージ受信エラー: %w", err)
+		}
+		return Message{}, ErrConnectionClosed
+	}
+
+	var message Message
+	if err := json.Unmarshal(c.scanner.Bytes(), &message); err != nil {
+		return Message{}, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	return message, nil
+}
+
+func (c *Conn) Close() error {
+	err := c.conn.Close()
+	if err != nil && !errors.Is(err, net.ErrClosed) && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,556 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"tetris/application"
+	"time"
+)
+
+const (
+	DefaultTickInterval   = 16 * time.Millisecond
+	helloTimeout          = 10 * time.Second
+	spectatorBufferSize   = 64
+	spectatorWriteTimeout = 5 * time.Second
+)
+
+var allowedCommands = map[string]bool{
+	"left":   true,
+	"right":  true,
+	"down":   true,
+	"rotate": true,
+	"drop":   true,
+}
+
+type session struct {
+	conn   *Conn
+	name   string
+	inputs chan string
+	closed chan struct{}
+}
+
+type Server struct {
+	config       application.GameConfig
+	tickInterval time.Duration
+
+	mu          sync.Mutex
+	waiting     *session
+	listener    net.Listener
+	sessions    map[*session]struct{}
+	matches     map[int]*matchStream
+	lastMatchID int
+	wg          sync.WaitGroup
+}
+
+func NewServer(config application.GameConfig) *Server {
+	return &Server{
+		config:       config,
+		tickInterval: DefaultTickInterval,
+		sessions:     make(map[*session]struct{}),
+		matches:      make(map[int]*matchStream),
+	}
+}
+
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("待ち受けエラー: %w", err)
+	}
+	return s.Serve(listener)
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("接続受付エラー: %w", err)
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(NewConn(conn))
+		}()
+	}
+}
+
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for sess := range s.sessions {
+		_ = sess.conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) handleConn(conn *Conn) {
+	defer conn.Close()
+
+	_ = conn.conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	hello, err := conn.Receive()
+	if err != nil || (hello.Type != MessageHello && hello.Type != MessageSpectate) {
+		_ = conn.Send(Message{Type: MessageError, Error: "helloメッセージが必要です"})
+		return
+	}
+	_ = conn.conn.SetReadDeadline(time.Time{})
+
+	sess := &session{
+		conn:   conn,
+		name:   hello.Name,
+		inputs: make(chan string, 32),
+		closed: make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.sessions[sess] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, sess)
+		s.mu.Unlock()
+	}()
+
+	if hello.Type == MessageSpectate {
+		s.spectate(sess, hello.Match)
+		return
+	}
+
+	go s.readInputs(sess)
+
+	opponent, err := s.joinLobby(sess)
+	if err != nil {
+		return
+	}
+	if opponent == nil {
+		<-sess.closed
+		return
+	}
+
+	s.runMatch([application.VersusPlayers]*session{opponent, sess})
+}
+
+func (s *Server) readInputs(sess *session) {
+	defer close(sess.closed)
+
+	for {
+		message, err := sess.conn.Receive()
+		if err != nil {
+			return
+		}
+		if message.Type != MessageInput || !allowedCommands[message.Command] {
+			continue
+		}
+
+		select {
+		case sess.inputs <- message.Command:
+		default:
+		}
+	}
+}
+
+func (s *Server) spectate(sess *session, matchID int) {
+	s.mu.Lock()
+	if matchID == 0 {
+		matchID = s.lastMatchID
+	}
+	stream, exists := s.matches[matchID]
+	s.mu.Unlock()
+
+	if !exists {
+		_ = sess.conn.Send(Message{Type: MessageError, Error: "観戦できる対戦がありません"})
+		return
+	}
+
+	if err := stream.addSpectator(sess.conn); err != nil {
+		return
+	}
+	defer stream.removeSpectator(sess.conn)
+
+	rejectInputs(sess.conn)
+}
+
+// rejectInputs は観戦者からのメッセージを接続が閉じるまで読み捨て、入力にはエラーを返す
+func rejectInputs(conn *Conn) {
+	for {
+		message, err := conn.Receive()
+		if err != nil {
+			return
+		}
+		if message.Type == MessageInput {
+			_ = conn.Send(Message{Type: MessageError, Error: ErrSpectator.Error()})
+		}
+	}
+}
+
+// joinLobby は待機中のプレイヤーがいれば対戦相手として返す。いなければ Waiting を送ってから待機者になるため、
+// 後から来たプレイヤーとの対戦の Start が Waiting より先に届くことはない
+func (s *Server) joinLobby(sess *session) (*session, error) {
+	if opponent := s.pairWaiting(nil); opponent != nil {
+		return opponent, nil
+	}
+	if err := sess.conn.Send(Message{Type: MessageWaiting}); err != nil {
+		return nil, err
+	}
+	return s.pairWaiting(sess), nil
+}
+
+// pairWaiting は待機中のプレイヤーを取り出す。いなければ sess を待機者にする
+func (s *Server) pairWaiting(sess *session) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.waiting != nil {
+		select {
+		case <-s.waiting.closed:
+			s.waiting = nil
+		default:
+		}
+	}
+
+	if s.waiting == nil {
+		s.waiting = sess
+		return nil
+	}
+
+	opponent := s.waiting
+	s.waiting = nil
+	return opponent
+}
+
+func (s *Server) runMatch(players [application.VersusPlayers]*session) {
+	defer func() {
+		for _, player := range players {
+			_ = player.conn.Close()
+		}
+	}()
+
+	config := s.config
+	if config.Seed == 0 {
+		config.Seed = uint64(time.Now().UnixNano())
+	}
+
+	match, err := application.NewVersusMatch(config)
+	if err != nil {
+		for _, player := range players {
+			_ = player.conn.Send(Message{Type: MessageError, Error: err.Error()})
+		}
+		return
+	}
+
+	stream := s.registerMatch(players)
+	defer s.unregisterMatch(stream)
+
+	if err := stream.start(); err != nil {
+		return
+	}
+	if err := stream.broadcast(match.GetState()); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(s.tickInterval)
+	defer ticker.Stop()
+	stream.play(match, ticker.C)
+}
+
+// registerMatch は対戦に番号を付け、観戦できるよう登録する
+func (s *Server) registerMatch(players [application.VersusPlayers]*session) *matchStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastMatchID++
+	stream := newMatchStream(s.lastMatchID, players)
+	s.matches[stream.id] = stream
+	return stream
+}
+
+func (s *Server) unregisterMatch(stream *matchStream) {
+	s.mu.Lock()
+	delete(s.matches, stream.id)
+	s.mu.Unlock()
+	stream.closeSpectators()
+}
+
+type matchStream struct {
+	id      int
+	players [application.VersusPlayers]*session
+
+	mu         sync.Mutex
+	snapshots  [application.VersusPlayers]application.GameSnapshot
+	pending    [application.VersusPlayers]int
+	sent       [application.VersusPlayers]int
+	spectators map[*Conn]*spectatorConn
+	ended      bool
+}
+
+func newMatchStream(id int, players [application.VersusPlayers]*session) *matchStream {
+	return &matchStream{
+		id:         id,
+		players:    players,
+		spectators: make(map[*Conn]*spectatorConn),
+	}
+}
+
+// start は各プレイヤーに自分の番号と対戦相手を知らせる
+func (m *matchStream) start() error {
+	for i, player := range m.players {
+		opponent := m.players[(i+1)%application.VersusPlayers]
+		start := Message{
+			Type:        MessageStart,
+			Player:      i,
+			Opponent:    opponent.name,
+			Match:       m.id,
+			PlayerCount: application.VersusPlayers,
+		}
+		if err := player.conn.Send(start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// play は一定間隔の更新と両プレイヤーの入力を、決着か切断まで対戦に反映する
+func (m *matchStream) play(match *application.VersusMatch, ticks <-chan time.Time) {
+	for {
+		var err error
+		select {
+		case <-ticks:
+			err = match.Update()
+		case command := <-m.players[0].inputs:
+			err = match.HandleInput(0, command)
+		case command := <-m.players[1].inputs:
+			err = match.HandleInput(1, command)
+		case <-m.players[0].closed:
+			m.end(1)
+			return
+		case <-m.players[1].closed:
+			m.end(0)
+			return
+		}
+		if !m.publish(match, err) {
+			return
+		}
+	}
+}
+
+// publish は更新の結果を配信し、対戦を続けるかを返す
+func (m *matchStream) publish(match *application.VersusMatch, err error) bool {
+	if err != nil {
+		m.fail(err)
+		return false
+	}
+
+	state := match.GetState()
+	if err := m.broadcast(state); err != nil {
+		return false
+	}
+	if state.Finished {
+		m.end(state.Winner)
+		return false
+	}
+	return true
+}
+
+// addSpectator は観戦者を登録し、途中参加でも描画できるよう現在の全体状態を送る
+func (m *matchStream) addSpectator(conn *Conn) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spectator := newSpectatorConn(conn)
+	if m.ended {
+		spectator.enqueue(Message{Type: MessageError, Error: "対戦は終了しました"})
+		spectator.close()
+		return ErrConnectionClosed
+	}
+
+	spectator.enqueue(Message{
+		Type:        MessageStart,
+		Player:      SpectatorPlayer,
+		Match:       m.id,
+		PlayerCount: application.VersusPlayers,
+	})
+
+	deltas := make([]PlayerDelta, 0, application.VersusPlayers)
+	for i, snapshot := range m.snapshots {
+		deltas = append(deltas, PlayerDelta{
+			Player:  i,
+			Delta:   application.DiffSnapshots(application.GameSnapshot{}, snapshot),
+			Pending: m.pending[i],
+			Sent:    m.sent[i],
+		})
+	}
+	spectator.enqueue(Message{Type: MessageState, Players: deltas})
+
+	m.spectators[conn] = spectator
+	return nil
+}
+
+func (m *matchStream) removeSpectator(conn *Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if spectator, exists := m.spectators[conn]; exists {
+		spectator.close()
+		delete(m.spectators, conn)
+	}
+}
+
+func (m *matchStream) closeSpectators() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ended = true
+	for conn, spectator := range m.spectators {
+		spectator.close()
+		delete(m.spectators, conn)
+	}
+}
+
+func (m *matchStream) broadcast(state application.VersusState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deltas []PlayerDelta
+	var events []GarbageEvent
+
+	for i, player := range state.Players {
+		snapshot := application.NewGameSnapshot(player.Game)
+		if player.Sent > m.sent[i] {
+			events = append(events, GarbageEvent{
+				From:  i,
+				To:    (i + 1) % application.VersusPlayers,
+				Lines: player.Sent - m.sent[i],
+			})
+		}
+
+		if snapshot.Equal(m.snapshots[i]) && player.PendingGarbage == m.pending[i] && player.Sent == m.sent[i] {
+			continue
+		}
+
+		deltas = append(deltas, PlayerDelta{
+			Player:  i,
+			Delta:   application.DiffSnapshots(m.snapshots[i], snapshot),
+			Pending: player.PendingGarbage,
+			Sent:    player.Sent,
+		})
+		m.snapshots[i] = snapshot
+		m.pending[i] = player.PendingGarbage
+		m.sent[i] = player.Sent
+	}
+
+	for _, event := range events {
+		if err := m.send(Message{Type: MessageGarbage, Garbage: &event}); err != nil {
+			return err
+		}
+	}
+
+	if len(deltas) == 0 {
+		return nil
+	}
+	return m.send(Message{Type: MessageState, Players: deltas})
+}
+
+func (m *matchStream) end(winner int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_ = m.send(Message{Type: MessageEnd, Winner: winner})
+}
+
+func (m *matchStream) fail(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_ = m.send(Message{Type: MessageError, Error: err.Error()})
+}
+
+// send はプレイヤーに送信し、観戦者には送信待ちに積む。観戦者への送信は対戦を待たせない
+func (m *matchStream) send(message Message) error {
+	var firstErr error
+	for _, player := range m.players {
+		if err := player.conn.Send(message); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for conn, spectator := range m.spectators {
+		if !spectator.enqueue(message) {
+			spectator.close()
+			delete(m.spectators, conn)
+		}
+	}
+	return firstErr
+}
+
+// spectatorConn は観戦者への送信を専用のゴルーチンで行う。受信しない観戦者は書き込みの期限で切断する
+type spectatorConn struct {
+	conn   *Conn
+	queue  chan Message
+	closed bool
+}
+
+func newSpectatorConn(conn *Conn) *spectatorConn {
+	spectator := &spectatorConn{
+		conn:  conn,
+		queue: make(chan Message, spectatorBufferSize),
+	}
+	go spectator.writeLoop()
+	return spectator
+}
+
+// enqueue は送信待ちに積む。溢れた観戦者は差分を取りこぼして表示が壊れるため false を返し、切断させる
+func (s *spectatorConn) enqueue(message Message) bool {
+	if s.closed {
+		return false
+	}
+	select {
+	case s.queue <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// close は送信待ちを送り終えてから接続を閉じさせる。matchStream のロック中に呼ぶ
+func (s *spectatorConn) close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.queue)
+}
+
+func (s *spectatorConn) writeLoop() {
+	defer s.conn.Close()
+
+	for message := range s.queue {
+		_ = s.conn.conn.SetWriteDeadline(time.Now().Add(spectatorWriteTimeout))
+		if err := s.conn.Send(message); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package network
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"tetris/application"
+	"time"
+)
+
+func startTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	server := NewServer(application.GameConfig{Seed: 17})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+
+	for server.Addr() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	return server
+}
+
+func dialTestClient(t *testing.T, server *Server, name string) *Client {
+	t.Helper()
+
+	client, err := Dial(server.Addr().String(), name)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+func receiveUntil(t *testing.T, client *Client, done func(Message) bool) Message {
+	t.Helper()
+
+	_ = client.conn.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	defer client.conn.conn.SetReadDeadline(time.Time{})
+
+	for {
+		message, err := client.Receive()
+		if err != nil {
+			t.Fatalf("Client.Receive() error = %v", err)
+		}
+		if done(message) {
+			return message
+		}
+	}
+}
+
+func hasFilledCell(snapshot application.GameSnapshot) bool {
+	for _, row := range snapshot.Rows {
+		if strings.ContainsRune(row, application.SnapshotFilledCell) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestServer_MatchOverLoopback(t *testing.T) {
+	server := startTestServer(t)
+
+	first := dialTestClient(t, server, "alice")
+	receiveUntil(t, first, func(m Message) bool { return m.Type == MessageWaiting })
+
+	second := dialTestClient(t, server, "bob")
+
+	firstStart := receiveUntil(t, first, func(m Message) bool { return m.Type == MessageStart })
+	secondStart := receiveUntil(t, second, func(m Message) bool { return m.Type == MessageStart })
+
+	if firstStart.Player == secondStart.Player {
+		t.Fatalf("both clients were assigned player %d", firstStart.Player)
+	}
+	if firstStart.Opponent != "bob" || secondStart.Opponent != "alice" {
+		t.Errorf("opponents = %q, %q, want bob, alice", firstStart.Opponent, secondStart.Opponent)
+	}
+
+	receiveUntil(t, first, func(m Message) bool {
+		view := first.View()
+		return view.Players[0].Snapshot.Height > 0 && view.Players[1].Snapshot.Height > 0
+	})
+
+	if err := first.SendInput("drop"); err != nil {
+		t.Fatalf("Client.SendInput() error = %v", err)
+	}
+
+	receiveUntil(t, second, func(m Message) bool {
+		return hasFilledCell(second.View().Players[firstStart.Player].Snapshot)
+	})
+
+	if _, err := second.View().VersusState(); err != nil {
+		t.Errorf("MatchView.VersusState() error = %v", err)
+	}
+}
+
+func TestServer_OpponentDisconnect(t *testing.T) {
+	server := startTestServer(t)
+
+	first := dialTestClient(t, server, "alice")
+	receiveUntil(t, first, func(m Message) bool { return m.Type == MessageWaiting })
+
+	second := dialTestClient(t, server, "bob")
+	start := receiveUntil(t, second, func(m Message) bool { return m.Type == MessageStart })
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Client.Close() error = %v", err)
+	}
+
+	end := receiveUntil(t, second, func(m Message) bool { return m.Type == MessageEnd })
+	if end.Winner != start.Player {
+		t.Errorf("winner = %d, want %d", end.Winner, start.Player)
+	}
+	if !second.View().Finished {
+		t.Error("MatchView.Finished should be true after end message")
+	}
+}
+
+func TestServer_LobbySkipsDisconnectedPlayer(t *testing.T) {
+	server := startTestServer(t)
+
+	gone := dialTestClient(t, server, "gone")
+	receiveUntil(t, gone, func(m Message) bool { return m.Type == MessageWaiting })
+	_ = gone.Close()
+	time.Sleep(50 * time.Millisecond)
+
+	first := dialTestClient(t, server, "alice")
+	receiveUntil(t, first, func(m Message) bool { return m.Type == MessageWaiting })
+
+	second := dialTestClient(t, server, "bob")
+	start := receiveUntil(t, second, func(m Message) bool { return m.Type == MessageStart })
+	if start.Opponent != "alice" {
+		t.Errorf("opponent = %q, want alice", start.Opponent)
+	}
+}
+
+func TestServer_WaitingBeforeStart(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		server := startTestServer(t)
+
+		// 2人がほぼ同時に接続し、どちらが待機者になっても Waiting が Start より後に届かないことを確かめる
+		var clients [application.VersusPlayers]*Client
+		dialErr := make(chan error, 1)
+		go func() {
+			var err error
+			clients[1], err = Dial(server.Addr().String(), "bob")
+			dialErr <- err
+		}()
+		clients[0] = dialTestClient(t, server, "alice")
+		if err := <-dialErr; err != nil {
+			t.Fatalf("Dial() error = %v", err)
+		}
+		t.Cleanup(func() {
+			_ = clients[1].Close()
+		})
+
+		waited := 0
+		for _, client := range clients {
+			started := false
+			receiveUntil(t, client, func(m Message) bool {
+				switch m.Type {
+				case MessageWaiting:
+					if started {
+						t.Fatal("Waiting arrived after Start")
+					}
+					waited++
+				case MessageStart:
+					started = true
+				}
+				return m.Type == MessageState
+			})
+		}
+		if waited == 0 {
+			t.Fatal("no player received Waiting before the match started")
+		}
+	}
+}
+
+func TestConn_Frames(t *testing.T) {
+	serverSide, clientSide := net.Pipe()
+	defer serverSide.Close()
+	defer clientSide.Close()
+
+	sender := NewConn(clientSide)
+	receiver := NewConn(serverSide)
+
+	go func() {
+		_ = sender.Send(Message{Type: MessageInput, Command: "left"})
+		_, _ = clientSide.Write([]byte("not json\n"))
+	}()
+
+	message, err := receiver.Receive()
+	if err != nil {
+		t.Fatalf("Conn.Receive() error = %v", err)
+	}
+	if message.Type != MessageInput || message.Command != "left" {
+		t.Errorf("Conn.Receive() = %+v, want input left", message)
+	}
+
+	if _, err := receiver.Receive(); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("Conn.Receive() error = %v, wantErr %v", err, ErrInvalidMessage)
+	}
+}
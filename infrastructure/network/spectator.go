@@ -0,0 +1,132 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"tetris/application"
+	"time"
+)
+
+// FeedServer は1つのゲームの状態フィードを観戦者に配信する読み取り専用サーバー
+type FeedServer struct {
+	feed *application.StateFeed
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[*Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+func NewFeedServer(feed *application.StateFeed) *FeedServer {
+	return &FeedServer{
+		feed:  feed,
+		conns: make(map[*Conn]struct{}),
+	}
+}
+
+func (s *FeedServer) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("接続受付エラー: %w", err)
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(NewConn(conn))
+		}()
+	}
+}
+
+func (s *FeedServer) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+func (s *FeedServer) Close() error {
+	s.mu.Lock()
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *FeedServer) handleConn(conn *Conn) {
+	defer conn.Close()
+
+	_ = conn.conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	hello, err := conn.Receive()
+	if err != nil || hello.Type != MessageSpectate {
+		_ = conn.Send(Message{Type: MessageError, Error: "spectateメッセージが必要です"})
+		return
+	}
+	_ = conn.conn.SetReadDeadline(time.Time{})
+
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	updates, unsubscribe := s.feed.Subscribe()
+	defer unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		rejectInputs(conn)
+	}()
+
+	if err := conn.Send(Message{Type: MessageStart, Player: SpectatorPlayer, PlayerCount: 1}); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				_ = conn.Send(Message{Type: MessageEnd, Winner: application.NoWinner})
+				return
+			}
+			delta := PlayerDelta{Delta: feedDelta(update)}
+			if err := conn.Send(Message{Type: MessageState, Players: []PlayerDelta{delta}}); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// feedDelta は全体スナップショットを空の状態からの差分に変換し、クライアントの適用処理を共通化する
+func feedDelta(update application.FeedUpdate) application.SnapshotDelta {
+	if update.Snapshot != nil {
+		return application.DiffSnapshots(application.GameSnapshot{}, *update.Snapshot)
+	}
+	return *update.Delta
+}
@@ -0,0 +1,229 @@
+package network
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"tetris/application"
+	"time"
+)
+
+func spectateTestClient(t *testing.T, network, addr string, match int) *Client {
+	t.Helper()
+
+	client, err := Spectate(network, addr, match)
+	if err != nil {
+		t.Fatalf("Spectate() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+func TestServer_SpectatorWatchesMatch(t *testing.T) {
+	server := startTestServer(t)
+
+	first := dialTestClient(t, server, "alice")
+	receiveUntil(t, first, func(m Message) bool { return m.Type == MessageWaiting })
+	second := dialTestClient(t, server, "bob")
+	firstStart := receiveUntil(t, first, func(m Message) bool { return m.Type == MessageStart })
+
+	spectator := spectateTestClient(t, "tcp", server.Addr().String(), 0)
+	start := receiveUntil(t, spectator, func(m Message) bool { return m.Type == MessageStart })
+	if start.Player != SpectatorPlayer {
+		t.Errorf("spectator player = %d, want %d", start.Player, SpectatorPlayer)
+	}
+	if start.Match != firstStart.Match {
+		t.Errorf("spectator match = %d, want %d", start.Match, firstStart.Match)
+	}
+
+	receiveUntil(t, spectator, func(m Message) bool {
+		view := spectator.View()
+		return view.Players[0].Snapshot.Height > 0 && view.Players[1].Snapshot.Height > 0
+	})
+
+	if err := spectator.SendInput("drop"); !errors.Is(err, ErrSpectator) {
+		t.Errorf("SendInput() error = %v, want %v", err, ErrSpectator)
+	}
+
+	if err := first.SendInput("drop"); err != nil {
+		t.Fatalf("Client.SendInput() error = %v", err)
+	}
+	receiveUntil(t, spectator, func(m Message) bool {
+		return hasFilledCell(spectator.View().Players[firstStart.Player].Snapshot)
+	})
+
+	if _, err := spectator.View().VersusState(); err != nil {
+		t.Errorf("MatchView.VersusState() error = %v", err)
+	}
+
+	_ = second.Close()
+	end := receiveUntil(t, spectator, func(m Message) bool { return m.Type == MessageEnd })
+	if end.Winner != firstStart.Player {
+		t.Errorf("winner = %d, want %d", end.Winner, firstStart.Player)
+	}
+}
+
+func TestServer_SpectatorInputIsRejected(t *testing.T) {
+	server := startTestServer(t)
+
+	first := dialTestClient(t, server, "alice")
+	receiveUntil(t, first, func(m Message) bool { return m.Type == MessageWaiting })
+	dialTestClient(t, server, "bob")
+	receiveUntil(t, first, func(m Message) bool { return m.Type == MessageStart })
+
+	spectator := spectateTestClient(t, "tcp", server.Addr().String(), 0)
+	receiveUntil(t, spectator, func(m Message) bool { return m.Type == MessageStart })
+
+	if err := spectator.conn.Send(Message{Type: MessageInput, Command: "drop"}); err != nil {
+		t.Fatalf("Conn.Send() error = %v", err)
+	}
+
+	_ = spectator.conn.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, err := spectator.Receive()
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrConnectionClosed) {
+			t.Fatal("connection closed before the input was rejected")
+		}
+		break
+	}
+
+	for _, player := range spectator.View().Players {
+		if hasFilledCell(player.Snapshot) {
+			t.Error("spectator input must not affect the match")
+		}
+	}
+}
+
+func TestServer_SpectateWithoutMatch(t *testing.T) {
+	server := startTestServer(t)
+
+	spectator := spectateTestClient(t, "tcp", server.Addr().String(), 0)
+	_ = spectator.conn.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := spectator.Receive(); err == nil || errors.Is(err, ErrConnectionClosed) {
+		t.Errorf("Receive() error = %v, want server error", err)
+	}
+}
+
+func TestFeedServer_UnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tetris")
+	if err != nil {
+		t.Fatalf("os.MkdirTemp() error = %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "spectate.sock"))
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{Seed: 3})
+	if err != nil {
+		t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+	}
+	feed := application.NewStateFeed()
+	feed.Publish(controller.GetGameState())
+
+	server := NewFeedServer(feed)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Close()
+
+	spectators := []*Client{
+		spectateTestClient(t, "unix", listener.Addr().String(), 0),
+		spectateTestClient(t, "unix", listener.Addr().String(), 0),
+	}
+	for _, spectator := range spectators {
+		start := receiveUntil(t, spectator, func(m Message) bool { return m.Type == MessageStart })
+		if start.PlayerCount != 1 {
+			t.Errorf("PlayerCount = %d, want 1", start.PlayerCount)
+		}
+		receiveUntil(t, spectator, func(m Message) bool { return spectator.View().Players[0].Snapshot.Height > 0 })
+	}
+
+	if err := controller.HandleInput("drop"); err != nil {
+		t.Fatalf("HandleInput() error = %v", err)
+	}
+	feed.Publish(controller.GetGameState())
+
+	want := application.NewGameSnapshot(controller.GetGameState())
+	for _, spectator := range spectators {
+		receiveUntil(t, spectator, func(m Message) bool {
+			return spectator.View().Players[0].Snapshot.Equal(want)
+		})
+
+		if _, err := spectator.View().GameState(0); err != nil {
+			t.Errorf("MatchView.GameState() error = %v", err)
+		}
+		if err := spectator.SendInput("left"); !errors.Is(err, ErrSpectator) {
+			t.Errorf("SendInput() error = %v, want %v", err, ErrSpectator)
+		}
+	}
+
+	feed.Close()
+	for _, spectator := range spectators {
+		receiveUntil(t, spectator, func(m Message) bool { return m.Type == MessageEnd })
+	}
+}
+
+func TestMatchStream_StalledSpectatorDoesNotBlockPlayers(t *testing.T) {
+	var players [application.VersusPlayers]*session
+	for i := range players {
+		local, remote := net.Pipe()
+		t.Cleanup(func() {
+			_ = local.Close()
+			_ = remote.Close()
+		})
+		go func() {
+			_, _ = io.Copy(io.Discard, remote)
+		}()
+		players[i] = &session{conn: NewConn(local)}
+	}
+	stream := newMatchStream(1, players)
+
+	// 観戦者側の接続は一度も読まないため、書き込みはすべて止まる
+	local, remote := net.Pipe()
+	t.Cleanup(func() {
+		_ = local.Close()
+		_ = remote.Close()
+	})
+	if err := stream.addSpectator(NewConn(local)); err != nil {
+		t.Fatalf("addSpectator() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < spectatorBufferSize*2; i++ {
+			if err := stream.send(Message{Type: MessageState}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("send() blocked on a stalled spectator")
+	}
+
+	stream.mu.Lock()
+	remaining := len(stream.spectators)
+	stream.mu.Unlock()
+	if remaining != 0 {
+		t.Errorf("spectators = %d, want the stalled spectator to be dropped", remaining)
+	}
+}
@@ -0,0 +1,330 @@
+package sshserver
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const handshakeTimeout = 10 * time.Second
+
+var (
+	ErrPTYRequired = errors.New("PTYが割り当てられていません（ssh -t で接続してください）")
+	ErrInvalidPTY  = errors.New("無効なPTY要求です")
+)
+
+// Session は1つのSSH接続上の対話端末。Stdout への改行は CRLF に変換される
+type Session struct {
+	User   string
+	Term   string
+	Width  int
+	Height int
+	Stdin  io.Reader
+	Stdout io.Writer
+}
+
+type Handler func(session *Session) error
+
+type Server struct {
+	config  *ssh.ServerConfig
+	handler Handler
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+func NewServer(hostKey ssh.Signer, handler Handler) *Server {
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(hostKey)
+
+	return &Server{
+		config:  config,
+		handler: handler,
+		conns:   make(map[net.Conn]struct{}),
+	}
+}
+
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("待ち受けエラー: %w", err)
+	}
+	return s.Serve(listener)
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("接続受付エラー: %w", err)
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn)
+		}()
+	}
+}
+
+func (s *Server) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+	defer serverConn.Close()
+
+	go ssh.DiscardRequests(requests)
+
+	var sessions sync.WaitGroup
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "sessionチャネルのみ対応しています")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		sessions.Add(1)
+		go func() {
+			defer sessions.Done()
+			s.handleSession(serverConn.User(), channel, channelRequests)
+		}()
+	}
+	sessions.Wait()
+}
+
+func (s *Server) handleSession(user string, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	state := &sessionState{
+		server:   s,
+		channel:  channel,
+		session:  &Session{User: user, Stdin: channel, Stdout: newCRLFWriter(channel)},
+		finished: make(chan uint32, 1),
+	}
+
+	for {
+		select {
+		case request, ok := <-requests:
+			if !ok {
+				if state.started {
+					<-state.finished
+				}
+				return
+			}
+			if !state.handleRequest(request) {
+				return
+			}
+		case status := <-state.finished:
+			sendExitStatus(channel, status)
+			return
+		}
+	}
+}
+
+// sessionState はセッションチャネルへの要求の処理状況。ゲームはPTYの割り当て後のshell要求で1度だけ開始する
+type sessionState struct {
+	server   *Server
+	channel  ssh.Channel
+	session  *Session
+	hasPTY   bool
+	started  bool
+	finished chan uint32
+}
+
+// handleRequest は要求に応答する。セッションを終了する場合はfalseを返す
+func (st *sessionState) handleRequest(request *ssh.Request) bool {
+	switch request.Type {
+	case "pty-req":
+		term, width, height, err := parsePTYRequest(request.Payload)
+		if err == nil && !st.started {
+			st.session.Term, st.session.Width, st.session.Height = term, width, height
+			st.hasPTY = true
+		}
+		_ = request.Reply(err == nil, nil)
+	case "window-change":
+		_ = request.Reply(false, nil)
+	case "shell":
+		return st.startShell(request)
+	default:
+		_ = request.Reply(false, nil)
+	}
+	return true
+}
+
+// startShell はゲームを別のゴルーチンで開始する。PTYがなければエラーを表示してセッションを終了する
+func (st *sessionState) startShell(request *ssh.Request) bool {
+	if !st.hasPTY {
+		_ = request.Reply(false, nil)
+		_, _ = fmt.Fprintln(st.session.Stdout, ErrPTYRequired)
+		sendExitStatus(st.channel, 1)
+		return false
+	}
+	if st.started {
+		_ = request.Reply(false, nil)
+		return true
+	}
+	_ = request.Reply(true, nil)
+	st.started = true
+
+	go func() {
+		status := uint32(0)
+		if err := st.server.handler(st.session); err != nil {
+			_, _ = fmt.Fprintf(st.session.Stdout, "\nエラー: %v\n", err)
+			status = 1
+		}
+		st.finished <- status
+	}()
+	return true
+}
+
+func sendExitStatus(channel ssh.Channel, status uint32) {
+	payload := binary.BigEndian.AppendUint32(nil, status)
+	_, _ = channel.SendRequest("exit-status", false, payload)
+}
+
+// parsePTYRequest は RFC 4254 6.2 の pty-req ペイロードから端末種別と桁数・行数を取り出す
+func parsePTYRequest(payload []byte) (term string, width, height int, err error) {
+	var request struct {
+		Term          string
+		Columns, Rows uint32
+		Width, Height uint32
+		Modes         string
+	}
+	if err := ssh.Unmarshal(payload, &request); err != nil {
+		return "", 0, 0, fmt.Errorf("%w: %v", ErrInvalidPTY, err)
+	}
+	return request.Term, int(request.Columns), int(request.Rows), nil
+}
+
+// crlfWriter はPTYの出力処理（onlcr）の代わりに LF を CRLF に変換する
+type crlfWriter struct {
+	out io.Writer
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newCRLFWriter(out io.Writer) *crlfWriter {
+	return &crlfWriter{out: out}
+}
+
+func (w *crlfWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = w.buf[:0]
+	for _, b := range p {
+		if b == '\n' {
+			w.buf = append(w.buf, '\r')
+		}
+		w.buf = append(w.buf, b)
+	}
+
+	if _, err := w.out.Write(w.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// LoadOrGenerateHostKey はホスト鍵を読み込み、存在しなければ ed25519 鍵を生成して保存する
+func LoadOrGenerateHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		signer, parseErr := ssh.ParsePrivateKey(data)
+		if parseErr != nil {
+			return nil, fmt.Errorf("ホスト鍵解析エラー: %w", parseErr)
+		}
+		return signer, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("ホスト鍵読み込みエラー: %w", err)
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("ホスト鍵生成エラー: %w", err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "tetris host key")
+	if err != nil {
+		return nil, fmt.Errorf("ホスト鍵変換エラー: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("ホスト鍵ディレクトリ作成エラー: %w", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		return nil, fmt.Errorf("ホスト鍵書き込みエラー: %w", err)
+	}
+
+	return ssh.NewSignerFromKey(privateKey)
+}
+
+func DefaultHostKeyPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("設定ディレクトリ取得エラー: %w", err)
+	}
+	return filepath.Join(configDir, "tetris", "ssh_host_ed25519_key"), nil
+}
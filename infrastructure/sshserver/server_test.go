@@ -0,0 +1,325 @@
+package sshserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"tetris/application"
+	"tetris/infrastructure/console"
+	"tetris/infrastructure/input"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// playGame は端末ごとに独立したコントローラーで入力のたびに描画する最小のゲームループ
+func playGame(session *Session) error {
+	controller, err := application.NewGameControllerWithConfig(application.GameConfig{Seed: 9})
+	if err != nil {
+		return err
+	}
+	display := console.NewANSIDisplay(session.Stdout)
+	keyboard := input.NewRemoteKeyboardInput(session.Stdin)
+	if err := keyboard.Start(); err != nil {
+		return err
+	}
+	defer keyboard.Stop()
+
+	if err := display.Render(controller.GetGameState()); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-keyboard.Done():
+			return nil
+		case key, ok := <-keyboard.Inputs():
+			if !ok {
+				return nil
+			}
+			command, err := input.MapInputToCommand(key)
+			if err != nil {
+				continue
+			}
+			if command == "quit" {
+				return nil
+			}
+			if err := controller.HandleInput(command); err != nil {
+				return err
+			}
+			if err := display.Render(controller.GetGameState()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func startTestServer(t *testing.T, handler Handler) *Server {
+	t.Helper()
+
+	hostKey, err := LoadOrGenerateHostKey(filepath.Join(t.TempDir(), "host_key"))
+	if err != nil {
+		t.Fatalf("LoadOrGenerateHostKey() error = %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	server := NewServer(hostKey, handler)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+
+	for server.Addr() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	return server
+}
+
+func dialTestClient(t *testing.T, server *Server) *ssh.Client {
+	t.Helper()
+
+	client, err := ssh.Dial("tcp", server.Addr().String(), &ssh.ClientConfig{
+		User:            "alice",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec // テスト用のサーバー
+		Timeout:         5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("ssh.Dial() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+// syncBuffer はSSHセッションの出力を複数ゴルーチンから安全に読むためのバッファ
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func waitForOutput(t *testing.T, output *syncBuffer, want string, count int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if strings.Count(output.String(), want) >= count {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("output does not contain %q %d times:\n%s", want, count, output.String())
+}
+
+type testTerminal struct {
+	session *ssh.Session
+	stdin   io.WriteCloser
+	output  *syncBuffer
+}
+
+func openTerminal(t *testing.T, client *ssh.Client) *testTerminal {
+	t.Helper()
+
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = session.Close()
+	})
+
+	if err := session.RequestPty("xterm", 40, 80, ssh.TerminalModes{}); err != nil {
+		t.Fatalf("RequestPty() error = %v", err)
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		t.Fatalf("StdinPipe() error = %v", err)
+	}
+	output := &syncBuffer{}
+	session.Stdout = output
+
+	if err := session.Shell(); err != nil {
+		t.Fatalf("Shell() error = %v", err)
+	}
+	return &testTerminal{session: session, stdin: stdin, output: output}
+}
+
+func TestServer_PlaysGameOverSSH(t *testing.T) {
+	server := startTestServer(t, playGame)
+	client := dialTestClient(t, server)
+	terminal := openTerminal(t, client)
+
+	waitForOutput(t, terminal.output, "テトリス", 1)
+	output := terminal.output.String()
+	if !strings.HasPrefix(output, "\x1b[H\x1b[2J") {
+		t.Errorf("output should start with an ANSI clear sequence: %q", output[:min(len(output), 20)])
+	}
+	if strings.Contains(strings.ReplaceAll(output, "\r\n", ""), "\n") {
+		t.Error("every newline should be translated to CRLF")
+	}
+
+	if _, err := terminal.stdin.Write([]byte("\x1b[D")); err != nil {
+		t.Fatalf("stdin write error = %v", err)
+	}
+	waitForOutput(t, terminal.output, "\x1b[H\x1b[2J", 2)
+
+	if _, err := terminal.stdin.Write([]byte("q")); err != nil {
+		t.Fatalf("stdin write error = %v", err)
+	}
+	if err := terminal.session.Wait(); err != nil {
+		t.Errorf("Wait() error = %v, want clean exit", err)
+	}
+}
+
+func TestServer_SessionsAreIndependent(t *testing.T) {
+	var mu sync.Mutex
+	users := make(map[*Session]string)
+	handler := func(session *Session) error {
+		mu.Lock()
+		users[session] = session.User
+		mu.Unlock()
+		return playGame(session)
+	}
+
+	server := startTestServer(t, handler)
+	first := openTerminal(t, dialTestClient(t, server))
+	second := openTerminal(t, dialTestClient(t, server))
+
+	waitForOutput(t, first.output, "テトリス", 1)
+	waitForOutput(t, second.output, "テトリス", 1)
+
+	if _, err := first.stdin.Write([]byte(" ")); err != nil {
+		t.Fatalf("stdin write error = %v", err)
+	}
+	waitForOutput(t, first.output, "\x1b[H\x1b[2J", 2)
+
+	if got := strings.Count(second.output.String(), "\x1b[H\x1b[2J"); got != 1 {
+		t.Errorf("second terminal rendered %d times, want 1", got)
+	}
+
+	mu.Lock()
+	if len(users) != 2 {
+		t.Errorf("handler called for %d sessions, want 2", len(users))
+	}
+	mu.Unlock()
+
+	for _, terminal := range []*testTerminal{first, second} {
+		_, _ = terminal.stdin.Write([]byte{0x03})
+		if err := terminal.session.Wait(); err != nil {
+			t.Errorf("Wait() error = %v, want clean exit", err)
+		}
+	}
+}
+
+func TestServer_RequiresPTY(t *testing.T) {
+	called := false
+	server := startTestServer(t, func(*Session) error {
+		called = true
+		return nil
+	})
+	client := dialTestClient(t, server)
+
+	session, err := client.NewSession()
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	defer session.Close()
+
+	if err := session.Shell(); err == nil {
+		t.Error("Shell() without a PTY should be rejected")
+	}
+	if called {
+		t.Error("handler should not run without a PTY")
+	}
+}
+
+func TestServer_HandlerErrorSetsExitStatus(t *testing.T) {
+	server := startTestServer(t, func(*Session) error {
+		return errors.New("boom")
+	})
+	terminal := openTerminal(t, dialTestClient(t, server))
+
+	var exitErr *ssh.ExitError
+	if err := terminal.session.Wait(); !errors.As(err, &exitErr) || exitErr.ExitStatus() != 1 {
+		t.Errorf("Wait() error = %v, want exit status 1", err)
+	}
+	if !strings.Contains(terminal.output.String(), "boom") {
+		t.Errorf("output should contain the handler error: %q", terminal.output.String())
+	}
+}
+
+func TestParsePTYRequest(t *testing.T) {
+	payload := ssh.Marshal(struct {
+		Term          string
+		Columns, Rows uint32
+		Width, Height uint32
+		Modes         string
+	}{Term: "xterm-256color", Columns: 120, Rows: 40})
+
+	term, width, height, err := parsePTYRequest(payload)
+	if err != nil {
+		t.Fatalf("parsePTYRequest() error = %v", err)
+	}
+	if term != "xterm-256color" || width != 120 || height != 40 {
+		t.Errorf("parsePTYRequest() = (%q, %d, %d)", term, width, height)
+	}
+
+	if _, _, _, err := parsePTYRequest([]byte{0x01}); !errors.Is(err, ErrInvalidPTY) {
+		t.Errorf("parsePTYRequest() error = %v, want %v", err, ErrInvalidPTY)
+	}
+}
+
+func TestCRLFWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := newCRLFWriter(&out)
+
+	n, err := writer.Write([]byte("a\nb\n"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if n != 4 {
+		t.Errorf("Write() = %d, want 4", n)
+	}
+	if out.String() != "a\r\nb\r\n" {
+		t.Errorf("output = %q, want %q", out.String(), "a\r\nb\r\n")
+	}
+}
+
+func TestLoadOrGenerateHostKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "host_key")
+
+	generated, err := LoadOrGenerateHostKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerateHostKey() error = %v", err)
+	}
+	loaded, err := LoadOrGenerateHostKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrGenerateHostKey() error = %v", err)
+	}
+
+	if !bytes.Equal(generated.PublicKey().Marshal(), loaded.PublicKey().Marshal()) {
+		t.Error("host key should be persisted and reused")
+	}
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"tetris/application/ai"
+)
+
+type FileCheckpointStore struct {
+	path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load はチェックポイントがまだない場合、最初から学習する空のチェックポイントを返す
+func (s *FileCheckpointStore) Load() (ai.Checkpoint, error) {
+	var checkpoint ai.Checkpoint
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checkpoint, nil
+		}
+		return checkpoint, fmt.Errorf("チェックポイント読み込みエラー: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return checkpoint, fmt.Errorf("チェックポイント解析エラー: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+func (s *FileCheckpointStore) Save(checkpoint ai.Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("チェックポイントエンコードエラー: %w", err)
+	}
+
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("チェックポイントディレクトリ作成エラー: %w", err)
+		}
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("チェックポイント書き込みエラー: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("チェックポイント置換エラー: %w", err)
+	}
+
+	return nil
+}
+
+// LoadWeights はチェックポイントに保存された最良個体の重みを読み込む
+func LoadWeights(path string) (ai.Weights, error) {
+	checkpoint, err := NewFileCheckpointStore(path).Load()
+	if err != nil {
+		return ai.Weights{}, err
+	}
+	if checkpoint.Best == nil {
+		return ai.Weights{}, fmt.Errorf("チェックポイントに学習済みの重みがありません: %s", path)
+	}
+	return checkpoint.Best.Weights, nil
+}
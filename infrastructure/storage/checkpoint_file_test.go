@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"tetris/application/ai"
+)
+
+func TestFileCheckpointStore_LoadMissingFile(t *testing.T) {
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "none", "weights.json"))
+
+	checkpoint, err := store.Load()
+	if err != nil {
+		t.Fatalf("FileCheckpointStore.Load() unexpected error = %v", err)
+	}
+	if checkpoint.Generation != 0 || len(checkpoint.Population) != 0 || checkpoint.Best != nil {
+		t.Errorf("FileCheckpointStore.Load() = %+v, want empty checkpoint", checkpoint)
+	}
+}
+
+func TestFileCheckpointStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "train", "weights.json")
+	store := NewFileCheckpointStore(path)
+
+	best := ai.Individual{Weights: ai.DefaultWeights(), Fitness: 42.5}
+	checkpoint := ai.Checkpoint{
+		Generation: 3,
+		Population: []ai.Individual{best, {Weights: ai.Weights{Holes: -2}}},
+		Best:       &best,
+		RNG:        []byte{1, 2, 3},
+	}
+	if err := store.Save(checkpoint); err != nil {
+		t.Fatalf("FileCheckpointStore.Save() unexpected error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("FileCheckpointStore.Load() unexpected error = %v", err)
+	}
+	if loaded.Generation != 3 || len(loaded.Population) != 2 || loaded.Population[1].Weights.Holes != -2 {
+		t.Errorf("FileCheckpointStore.Load() = %+v", loaded)
+	}
+	if loaded.Best == nil || *loaded.Best != best || string(loaded.RNG) != string(checkpoint.RNG) {
+		t.Errorf("FileCheckpointStore.Load() best = %+v, rng = %v", loaded.Best, loaded.RNG)
+	}
+
+	weights, err := LoadWeights(path)
+	if err != nil {
+		t.Fatalf("LoadWeights() unexpected error = %v", err)
+	}
+	if weights != ai.DefaultWeights() {
+		t.Errorf("LoadWeights() = %+v, want %+v", weights, ai.DefaultWeights())
+	}
+}
+
+func TestLoadWeights_Errors(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(broken, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "ファイルなし", path: filepath.Join(dir, "missing.json")},
+		{name: "不正なJSON", path: broken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadWeights(tt.path); err == nil {
+				t.Error("LoadWeights() expected error")
+			}
+		})
+	}
+}
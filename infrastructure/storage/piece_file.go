@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"tetris/application"
+	"tetris/domain/model"
+)
+
+// LoadPieceSet はJSONのピースセット定義を読み込んでピースを登録し、出現させるピースの種類の一覧を返す
+func LoadPieceSet(path string) ([]model.TetrominoType, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ピースセットファイル読み込みエラー: %w", err)
+	}
+
+	var set application.PieceSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("ピースセットファイル解析エラー: %s: %w", filepath.Base(path), err)
+	}
+	types, err := set.Register()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return types, nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"tetris/domain/model"
+)
+
+const shippedPieceSet = "../../pieces/trominoes.json"
+
+func TestLoadPieceSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "組み込みのセットと独自のピース",
+			content:   `{"include":["big"],"pieces":[{"name":"FileMono","cell":"m","shape":["#"]}]}`,
+			wantCount: 8,
+		},
+		{name: "JSONでない", content: `pieces`},
+		{
+			name:    "不正な形",
+			content: `{"pieces":[{"name":"FileBad","shape":["#", ""]}]}`,
+			wantErr: model.ErrInvalidPieceDefinition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pieces.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			types, err := LoadPieceSet(path)
+			if tt.wantCount == 0 {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Errorf("LoadPieceSet() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadPieceSet() error = %v", err)
+			}
+			if len(types) != tt.wantCount {
+				t.Errorf("LoadPieceSet() = %d pieces, want %d", len(types), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestShippedPieceSet(t *testing.T) {
+	types, err := LoadPieceSet(shippedPieceSet)
+	if err != nil {
+		t.Fatalf("LoadPieceSet() error = %v", err)
+	}
+	if len(types) != 9 {
+		t.Fatalf("LoadPieceSet() = %d pieces, want 9", len(types))
+	}
+
+	board, _ := model.NewBoard(model.BoardWidth, model.BoardHeight)
+	for _, pieceType := range types {
+		piece, err := model.SpawnTetromino(pieceType, board.Width)
+		if err != nil {
+			t.Fatalf("SpawnTetromino(%v) error = %v", pieceType, err)
+		}
+		if !board.CanPlaceTetromino(piece) {
+			t.Errorf("%v does not fit at spawn: %v", pieceType, piece.GetBlocks())
+		}
+	}
+}
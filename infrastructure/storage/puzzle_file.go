@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"tetris/application"
+)
+
+const puzzleFilePattern = "*.json"
+
+// LoadPuzzle はJSONのパズル定義を読み込み、ゲームとして遊べるかを検証する
+func LoadPuzzle(path string) (application.Puzzle, error) {
+	var puzzle application.Puzzle
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return puzzle, fmt.Errorf("パズルファイル読み込みエラー: %w", err)
+	}
+	if err := json.Unmarshal(data, &puzzle); err != nil {
+		return puzzle, fmt.Errorf("パズルファイル解析エラー: %s: %w", filepath.Base(path), err)
+	}
+	if _, err := puzzle.GameOptions(); err != nil {
+		return puzzle, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	return puzzle, nil
+}
+
+// LoadPuzzles はディレクトリ内のパズルをファイル名の順に読み込む
+func LoadPuzzles(dir string) ([]application.Puzzle, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, puzzleFilePattern))
+	if err != nil {
+		return nil, fmt.Errorf("パズルディレクトリ検索エラー: %w", err)
+	}
+	sort.Strings(paths)
+
+	puzzles := make([]application.Puzzle, 0, len(paths))
+	for _, path := range paths {
+		puzzle, err := LoadPuzzle(path)
+		if err != nil {
+			return nil, err
+		}
+		puzzles = append(puzzles, puzzle)
+	}
+	if len(puzzles) == 0 {
+		return nil, fmt.Errorf("%w: %sにパズルがありません", application.ErrInvalidPuzzle, dir)
+	}
+	return puzzles, nil
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"tetris/application"
+	"tetris/application/ai"
+	"time"
+)
+
+const shippedPuzzleDir = "../../puzzles"
+
+func writePuzzle(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestLoadPuzzles(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantNames []string
+		wantErr   error
+	}{
+		{
+			name: "ファイル名の順に読む",
+			files: map[string]string{
+				"b.json": `{"name":"B","board":["#########."],"pieces":["I"],"goal":{"type":"lines","lines":1}}`,
+				"a.json": `{"name":"A","board":[],"pieces":["O"],"goal":{"type":"perfect-clear"}}`,
+				"c.txt":  `not a puzzle`,
+			},
+			wantNames: []string{"A", "B"},
+		},
+		{
+			name:    "パズルがない",
+			files:   map[string]string{},
+			wantErr: application.ErrInvalidPuzzle,
+		},
+		{
+			name: "不正なゴール",
+			files: map[string]string{
+				"a.json": `{"name":"A","board":[],"pieces":["O"],"goal":{"type":"win"}}`,
+			},
+			wantErr: application.ErrInvalidPuzzle,
+		},
+		{
+			name: "不正なJSON",
+			files: map[string]string{
+				"a.json": `{"name":`,
+			},
+			wantErr: errAny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writePuzzle(t, dir, name, content)
+			}
+
+			puzzles, err := LoadPuzzles(dir)
+			if tt.wantErr != nil {
+				if err == nil || (tt.wantErr != errAny && !errors.Is(err, tt.wantErr)) {
+					t.Errorf("LoadPuzzles() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadPuzzles() error = %v", err)
+			}
+
+			var names []string
+			for _, puzzle := range puzzles {
+				names = append(names, puzzle.Name)
+			}
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("names = %v, want %v", names, tt.wantNames)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Errorf("names = %v, want %v", names, tt.wantNames)
+				}
+			}
+		})
+	}
+}
+
+var errAny = errors.New("任意のエラー")
+
+// TestShippedPuzzlesAreSolvable は同梱のパズルを全探索で解き、どれも解けることを確かめる。
+// 探索は取り消しを使って1手ずつ戻るため、取り消しとやり直しの検証も兼ねる
+func TestShippedPuzzlesAreSolvable(t *testing.T) {
+	puzzles, err := LoadPuzzles(shippedPuzzleDir)
+	if err != nil {
+		t.Fatalf("LoadPuzzles() error = %v", err)
+	}
+
+	for _, puzzle := range puzzles {
+		t.Run(puzzle.Name, func(t *testing.T) {
+			mode, err := application.NewPuzzleMode(puzzle)
+			if err != nil {
+				t.Fatalf("NewPuzzleMode() error = %v", err)
+			}
+			controller, err := application.NewGameControllerWithConfig(application.GameConfig{
+				Mode:      mode,
+				Clock:     application.FixedClock{Time: time.Unix(0, 0)},
+				UndoLimit: mode.PieceCount(),
+			})
+			if err != nil {
+				t.Fatalf("NewGameControllerWithConfig() error = %v", err)
+			}
+
+			if !solvePuzzle(t, controller) {
+				t.Errorf("パズル %q が解けません", puzzle.Name)
+			}
+		})
+	}
+}
+
+func solvePuzzle(t *testing.T, controller *application.GameController) bool {
+	t.Helper()
+
+	for _, hold := range []bool{true, false} {
+		if hold && (!controller.GetGameState().HoldEnabled || !tryInput(t, controller, "hold")) {
+			continue
+		}
+
+		state := controller.GetGameState()
+		placements, err := ai.ReachablePlacements(state.Board.Clone(), state.CurrentPiece.Clone())
+		if err != nil {
+			t.Fatalf("ReachablePlacements() error = %v", err)
+		}
+
+		for i, placement := range placements {
+			// 取り消しはピースが出現した時点に戻すため、ホールドもやり直す
+			if hold && i > 0 {
+				tryInput(t, controller, "hold")
+			}
+			for _, move := range placement.Moves {
+				tryInput(t, controller, move)
+			}
+
+			result := controller.GetGameState().Result
+			if result != nil && result.Status == application.ModeCompleted {
+				return true
+			}
+			if result == nil && solvePuzzle(t, controller) {
+				return true
+			}
+			tryInput(t, controller, "undo")
+		}
+	}
+	return false
+}
+
+func tryInput(t *testing.T, controller *application.GameController, input string) bool {
+	t.Helper()
+	before := controller.GetGameState().HoldPiece
+	if err := controller.HandleInput(input); err != nil {
+		t.Fatalf("HandleInput(%q) error = %v", input, err)
+	}
+	return input != "hold" || controller.GetGameState().HoldPiece != before
+}
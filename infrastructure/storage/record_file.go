@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"tetris/application"
+)
+
+const recordFileName = "records.json"
+
+type FileRecordRepository struct {
+	path string
+}
+
+func NewFileRecordRepository(path string) *FileRecordRepository {
+	return &FileRecordRepository{path: path}
+}
+
+func DefaultRecordPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("設定ディレクトリ取得エラー: %w", err)
+	}
+	return filepath.Join(configDir, "tetris", recordFileName), nil
+}
+
+func (r *FileRecordRepository) Load() (application.Records, error) {
+	var records application.Records
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return records, nil
+		}
+		return records, fmt.Errorf("記録ファイル読み込みエラー: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		return records, fmt.Errorf("記録ファイル解析エラー: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *FileRecordRepository) Save(records application.Records) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("記録エンコードエラー: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("記録ディレクトリ作成エラー: %w", err)
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("記録ファイル書き込みエラー: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		return fmt.Errorf("記録ファイル置換エラー: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"tetris/application"
+	"time"
+)
+
+func TestFileRecordRepository_LoadMissingFile(t *testing.T) {
+	repository := NewFileRecordRepository(filepath.Join(t.TempDir(), "none", "records.json"))
+
+	records, err := repository.Load()
+	if err != nil {
+		t.Fatalf("FileRecordRepository.Load() unexpected error = %v", err)
+	}
+
+	if len(records.BestTimes) != 0 || len(records.HighScores) != 0 {
+		t.Errorf("FileRecordRepository.Load() = %+v, want empty records", records)
+	}
+}
+
+func TestFileRecordRepository_SaveAndLoad(t *testing.T) {
+	repository := NewFileRecordRepository(filepath.Join(t.TempDir(), "tetris", "records.json"))
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var records application.Records
+	records.Add(application.ModeResult{
+		Mode:       application.ModeSprint,
+		Status:     application.ModeCompleted,
+		Elapsed:    95 * time.Second,
+		Splits:     []time.Duration{20 * time.Second, 45 * time.Second},
+		RankByTime: true,
+	}, date)
+	records.Add(application.ModeResult{
+		Mode:   application.ModeEndless,
+		Status: application.ModeFailed,
+		Score:  1200,
+		Lines:  12,
+		Level:  2,
+	}, date)
+
+	if err := repository.Save(records); err != nil {
+		t.Fatalf("FileRecordRepository.Save() unexpected error = %v", err)
+	}
+
+	loaded, err := repository.Load()
+	if err != nil {
+		t.Fatalf("FileRecordRepository.Load() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		check   func() bool
+		message string
+	}{
+		{
+			name: "スプリント記録が復元される",
+			check: func() bool {
+				best, ok := loaded.BestTime(application.ModeSprint)
+				return ok && best == 95*time.Second && len(loaded.BestTimes[application.ModeSprint][0].Splits) == 2
+			},
+			message: "sprint record was not restored",
+		},
+		{
+			name: "スコア記録がモード別に復元される",
+			check: func() bool {
+				best, ok := loaded.BestScore(application.ModeEndless)
+				return ok && best == 1200
+			},
+			message: "endless score was not restored",
+		},
+		{
+			name: "スプリント記録はスコア記録に混ざらない",
+			check: func() bool {
+				_, ok := loaded.BestScore(application.ModeSprint)
+				return !ok
+			},
+			message: "sprint result should not be stored as a high score",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.check() {
+				t.Error(tt.message)
+			}
+		})
+	}
+}
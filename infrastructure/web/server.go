@@ -0,0 +1,478 @@
+package web
+
+import (
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"sync"
+	"tetris/application"
+	"tetris/infrastructure/fumen"
+	"tetris/infrastructure/input"
+	"time"
+)
+
+const (
+	DefaultTickInterval = 16 * time.Millisecond
+	DefaultIdleTimeout  = 5 * time.Minute
+	maxRequestBody      = 4 * 1024
+	commandBufferSize   = 32
+)
+
+const (
+	MessageSnapshot = "snapshot"
+	MessageDelta    = "delta"
+	MessageCommand  = "command"
+	MessageFumen    = "fumen"
+	MessageError    = "error"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+type Message struct {
+	Type     string                     `json:"type"`
+	Command  string                     `json:"command,omitempty"`
+	Snapshot *application.GameSnapshot  `json:"snapshot,omitempty"`
+	Delta    *application.SnapshotDelta `json:"delta,omitempty"`
+	Fumen    string                     `json:"fumen,omitempty"`
+	Error    string                     `json:"error,omitempty"`
+}
+
+type CreateGameRequest struct {
+	Mode  string `json:"mode"`
+	Level int    `json:"level"`
+	Seed  uint64 `json:"seed"`
+}
+
+type CreateGameResponse struct {
+	ID     string `json:"id"`
+	Mode   string `json:"mode"`
+	Stream string `json:"stream"`
+}
+
+// gameCommand は接続から受けた入力。処理の結果を送った接続に返し、エラーを伝えられるようにする
+type gameCommand struct {
+	name   string
+	result chan gameReply
+}
+
+// gameReply は入力を処理した結果。exportの場合は fumen に盤面のfumen文字列が入る
+type gameReply struct {
+	fumen string
+	err   error
+}
+
+type gameSession struct {
+	id         string
+	mode       string
+	controller *application.GameController
+	feed       *application.StateFeed
+	commands   chan gameCommand
+	done       chan struct{}
+	finished   chan struct{}
+	stopOnce   sync.Once
+
+	mu      sync.Mutex
+	failure error
+}
+
+func (g *gameSession) stop() {
+	g.stopOnce.Do(func() {
+		close(g.done)
+	})
+}
+
+func (g *gameSession) apply(command string) gameReply {
+	switch command {
+	case "restart":
+		return gameReply{err: g.controller.Reset()}
+	case "export":
+		return g.export()
+	default:
+		return gameReply{err: g.controller.HandleInput(command)}
+	}
+}
+
+// export は現在の盤面と操作中のピースをfumenに変換する
+func (g *gameSession) export() gameReply {
+	state := g.controller.GetGameState()
+	data, err := fumen.Encode([]fumen.Page{{Board: state.Board, Piece: state.CurrentPiece, Lock: true}})
+	if err != nil {
+		return gameReply{err: fmt.Errorf("fumen出力エラー: %w", err)}
+	}
+	return gameReply{fumen: data}
+}
+
+// fail はゲームを続けられなくなった原因を記録し、接続中のクライアントに伝えられるようにする
+func (g *gameSession) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.failure = err
+}
+
+func (g *gameSession) err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.failure
+}
+
+type Server struct {
+	config       application.GameConfig
+	tickInterval time.Duration
+	idleTimeout  time.Duration
+	handler      http.Handler
+
+	mu      sync.Mutex
+	games   map[string]*gameSession
+	sockets map[*wsConn]struct{}
+	server  *http.Server
+	wg      sync.WaitGroup
+}
+
+func NewServer(config application.GameConfig) *Server {
+	s := &Server{
+		config:       config,
+		tickInterval: DefaultTickInterval,
+		idleTimeout:  DefaultIdleTimeout,
+		games:        make(map[string]*gameSession),
+		sockets:      make(map[*wsConn]struct{}),
+	}
+
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(fmt.Sprintf("静的ファイル読み込みエラー: %v", err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.HandleFunc("POST /games", s.handleCreateGame)
+	mux.HandleFunc("GET /games/{id}", s.handleGetGame)
+	mux.HandleFunc("GET /games/{id}/ws", s.handleStream)
+	s.handler = mux
+
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+func (s *Server) ListenAndServe(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("待ち受けエラー: %w", err)
+	}
+	return s.Serve(listener)
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	s.mu.Lock()
+	s.server = server
+	s.mu.Unlock()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTPサーバーエラー: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) Close() error {
+	s.mu.Lock()
+	var err error
+	if s.server != nil {
+		err = s.server.Close()
+	}
+	for _, game := range s.games {
+		game.stop()
+	}
+	for socket := range s.sockets {
+		_ = socket.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) handleCreateGame(w http.ResponseWriter, r *http.Request) {
+	var request CreateGameRequest
+	body := http.MaxBytesReader(w, r.Body, maxRequestBody)
+	if err := json.NewDecoder(body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("リクエスト解析エラー: %w", err))
+		return
+	}
+
+	game, err := s.createGame(request)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Location", "/games/"+game.id)
+	writeJSON(w, http.StatusCreated, CreateGameResponse{
+		ID:     game.id,
+		Mode:   game.mode,
+		Stream: "/games/" + game.id + "/ws",
+	})
+}
+
+func (s *Server) createGame(request CreateGameRequest) (*gameSession, error) {
+	mode, err := application.NewGameMode(request.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	config := s.config
+	config.Mode = mode
+	if request.Level > 0 {
+		config.StartLevel = request.Level
+	}
+	if request.Seed != 0 {
+		config.Seed = request.Seed
+	}
+
+	controller, err := application.NewGameControllerWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newGameID()
+	if err != nil {
+		return nil, err
+	}
+
+	game := &gameSession{
+		id:         id,
+		mode:       mode.Name(),
+		controller: controller,
+		feed:       application.NewStateFeed(),
+		commands:   make(chan gameCommand, commandBufferSize),
+		done:       make(chan struct{}),
+		finished:   make(chan struct{}),
+	}
+	game.feed.Publish(controller.GetGameState())
+
+	s.mu.Lock()
+	s.games[id] = game
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runGame(game)
+	}()
+
+	return game, nil
+}
+
+func newGameID() (string, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("ゲームID生成エラー: %w", err)
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
+
+// runGame はゲームを所有する唯一のゴルーチンで、入力と落下を順に処理して状態を配信する。
+// 入力のエラーは送った接続に返し、落下の更新に失敗した場合はゲームを終了して全ての接続に伝える
+func (s *Server) runGame(game *gameSession) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.games, game.id)
+		s.mu.Unlock()
+		close(game.finished)
+		game.feed.Close()
+	}()
+
+	ticker := time.NewTicker(s.tickInterval)
+	defer ticker.Stop()
+
+	lastSeen := time.Now()
+	for {
+		select {
+		case <-game.done:
+			return
+		case <-ticker.C:
+			if game.feed.SubscriberCount() > 0 {
+				lastSeen = time.Now()
+			} else if time.Since(lastSeen) > s.idleTimeout {
+				return
+			}
+			if err := game.controller.Update(); err != nil {
+				game.fail(err)
+				return
+			}
+		case command := <-game.commands:
+			command.result <- game.apply(command.name)
+		}
+
+		game.feed.Publish(game.controller.GetGameState())
+	}
+}
+
+func (s *Server) lookupGame(id string) (*gameSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	game, exists := s.games[id]
+	return game, exists
+}
+
+func (s *Server) handleGetGame(w http.ResponseWriter, r *http.Request) {
+	game, exists := s.lookupGame(r.PathValue("id"))
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf("ゲームが見つかりません: %s", r.PathValue("id")))
+		return
+	}
+
+	updates, unsubscribe := game.feed.Subscribe()
+	defer unsubscribe()
+
+	select {
+	case update, ok := <-updates:
+		if ok && update.Snapshot != nil {
+			writeJSON(w, http.StatusOK, update.Snapshot)
+			return
+		}
+	case <-r.Context().Done():
+		return
+	}
+	writeJSONError(w, http.StatusGone, fmt.Errorf("ゲームは終了しました: %s", game.id))
+}
+
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	game, exists := s.lookupGame(r.PathValue("id"))
+	if !exists {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf("ゲームが見つかりません: %s", r.PathValue("id")))
+		return
+	}
+
+	socket, err := upgrade(w, r)
+	if errors.Is(err, ErrInvalidOrigin) {
+		writeJSONError(w, http.StatusForbidden, err)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.sockets[socket] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.sockets, socket)
+		s.mu.Unlock()
+		_ = socket.Close()
+	}()
+
+	updates, unsubscribe := game.feed.Subscribe()
+	defer unsubscribe()
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		s.readCommands(socket, game)
+	}()
+
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				if err := game.err(); err != nil {
+					_ = sendMessage(socket, Message{Type: MessageError, Error: err.Error()})
+				}
+				return
+			}
+			if err := sendUpdate(socket, update); err != nil {
+				return
+			}
+		case <-readDone:
+			return
+		}
+	}
+}
+
+func (s *Server) readCommands(socket *wsConn, game *gameSession) {
+	for {
+		data, err := socket.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var message Message
+		if err := json.Unmarshal(data, &message); err != nil || message.Type != MessageCommand {
+			_ = sendMessage(socket, Message{Type: MessageError, Error: "commandメッセージが必要です"})
+			continue
+		}
+
+		command, err := input.MapInputToCommand(message.Command)
+		if err != nil {
+			_ = sendMessage(socket, Message{Type: MessageError, Error: err.Error()})
+			continue
+		}
+		if command == "quit" {
+			return
+		}
+
+		request := gameCommand{name: command, result: make(chan gameReply, 1)}
+		select {
+		case game.commands <- request:
+		case <-game.done:
+			return
+		default:
+			continue
+		}
+
+		select {
+		case reply := <-request.result:
+			if reply.err != nil {
+				_ = sendMessage(socket, Message{Type: MessageError, Error: reply.err.Error()})
+			} else if reply.fumen != "" {
+				_ = sendMessage(socket, Message{Type: MessageFumen, Fumen: reply.fumen})
+			}
+		case <-game.finished:
+			return
+		}
+	}
+}
+
+func sendUpdate(socket *wsConn, update application.FeedUpdate) error {
+	if update.Snapshot != nil {
+		return sendMessage(socket, Message{Type: MessageSnapshot, Snapshot: update.Snapshot})
+	}
+	return sendMessage(socket, Message{Type: MessageDelta, Delta: update.Delta})
+}
+
+func sendMessage(socket *wsConn, message Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("メッセージ変換エラー: %w", err)
+	}
+	return socket.WriteText(data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, Message{Type: MessageError, Error: err.Error()})
+}
@@ -0,0 +1,483 @@
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tetris/application"
+	"tetris/infrastructure/fumen"
+	"time"
+)
+
+type testSocket struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialTestSocket(t *testing.T, serverURL, path string) *testSocket {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(serverURL, "http://"))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	request := "GET " + path + " HTTP/1.1\r\n" +
+		"Host: localhost\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + key + "\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatalf("handshake write error = %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("handshake read error = %v", err)
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want %d", response.StatusCode, http.StatusSwitchingProtocols)
+	}
+	if got := response.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("Sec-WebSocket-Accept = %q", got)
+	}
+
+	return &testSocket{conn: conn, reader: reader}
+}
+
+func (s *testSocket) send(t *testing.T, opcode byte, payload []byte) {
+	t.Helper()
+
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{finBit | opcode, maskBit | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := s.conn.Write(frame); err != nil {
+		t.Fatalf("frame write error = %v", err)
+	}
+}
+
+func (s *testSocket) sendCommand(t *testing.T, command string) {
+	t.Helper()
+
+	data, err := json.Marshal(Message{Type: MessageCommand, Command: command})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	s.send(t, opText, data)
+}
+
+func (s *testSocket) readFrame(t *testing.T) (byte, []byte) {
+	t.Helper()
+
+	_ = s.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var header [2]byte
+	if _, err := io.ReadFull(s.reader, header[:]); err != nil {
+		t.Fatalf("frame read error = %v", err)
+	}
+	if header[1]&maskBit != 0 {
+		t.Fatal("server frames must not be masked")
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		_, _ = io.ReadFull(s.reader, extended[:])
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		_, _ = io.ReadFull(s.reader, extended[:])
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, payload); err != nil {
+		t.Fatalf("payload read error = %v", err)
+	}
+	return header[0] & 0x0F, payload
+}
+
+func (s *testSocket) readMessage(t *testing.T) Message {
+	t.Helper()
+
+	for {
+		opcode, payload := s.readFrame(t)
+		if opcode != opText {
+			continue
+		}
+
+		var message Message
+		if err := json.Unmarshal(payload, &message); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		return message
+	}
+}
+
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	return newTestServerWithConfig(t, application.GameConfig{})
+}
+
+func newTestServerWithConfig(t *testing.T, config application.GameConfig) (*Server, *httptest.Server) {
+	t.Helper()
+
+	server := NewServer(config)
+	server.tickInterval = time.Hour
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(func() {
+		httpServer.Close()
+		_ = server.Close()
+	})
+	return server, httpServer
+}
+
+func createGame(t *testing.T, serverURL string, request CreateGameRequest) CreateGameResponse {
+	t.Helper()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	response, err := http.Post(serverURL+"/games", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /games error = %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /games status = %d, want %d", response.StatusCode, http.StatusCreated)
+	}
+
+	var created CreateGameResponse
+	if err := json.NewDecoder(response.Body).Decode(&created); err != nil {
+		t.Fatalf("response decode error = %v", err)
+	}
+	return created
+}
+
+func TestServer_CreateGame(t *testing.T) {
+	_, httpServer := newTestServer(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantMode   string
+	}{
+		{name: "default mode", body: "", wantStatus: http.StatusCreated, wantMode: application.ModeEndless},
+		{name: "sprint", body: `{"mode":"sprint","level":3}`, wantStatus: http.StatusCreated, wantMode: application.ModeSprint},
+		{name: "unknown mode", body: `{"mode":"unknown"}`, wantStatus: http.StatusBadRequest},
+		{name: "malformed body", body: `{"mode":`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := http.Post(httpServer.URL+"/games", "application/json", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("POST /games error = %v", err)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var created CreateGameResponse
+			if err := json.NewDecoder(response.Body).Decode(&created); err != nil {
+				t.Fatalf("response decode error = %v", err)
+			}
+			if created.ID == "" {
+				t.Error("created game should have an ID")
+			}
+			if created.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", created.Mode, tt.wantMode)
+			}
+			if created.Stream != "/games/"+created.ID+"/ws" {
+				t.Errorf("Stream = %q", created.Stream)
+			}
+		})
+	}
+}
+
+func TestServer_GetGame(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{Seed: 7})
+
+	response, err := http.Get(httpServer.URL + "/games/" + created.ID)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", response.StatusCode, http.StatusOK)
+	}
+
+	var snapshot application.GameSnapshot
+	if err := json.NewDecoder(response.Body).Decode(&snapshot); err != nil {
+		t.Fatalf("snapshot decode error = %v", err)
+	}
+	if snapshot.Width == 0 || len(snapshot.Rows) != snapshot.Height || snapshot.Piece == nil {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+
+	missing, err := http.Get(httpServer.URL + "/games/missing")
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer missing.Body.Close()
+	if missing.StatusCode != http.StatusNotFound {
+		t.Errorf("missing game status = %d, want %d", missing.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestServer_StreamSnapshotAndDeltas(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{Seed: 7})
+
+	socket := dialTestSocket(t, httpServer.URL, created.Stream)
+
+	first := socket.readMessage(t)
+	if first.Type != MessageSnapshot || first.Snapshot == nil {
+		t.Fatalf("first message = %+v, want snapshot", first)
+	}
+	snapshot := *first.Snapshot
+
+	socket.sendCommand(t, "drop")
+
+	delta := socket.readMessage(t)
+	if delta.Type != MessageDelta || delta.Delta == nil {
+		t.Fatalf("second message = %+v, want delta", delta)
+	}
+	if len(delta.Delta.Rows) == 0 {
+		t.Error("drop should change at least one row")
+	}
+
+	next, err := snapshot.Apply(*delta.Delta)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if next.Equal(snapshot) {
+		t.Error("state should change after drop")
+	}
+}
+
+func TestServer_StreamRejectsInvalidCommand(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	socket := dialTestSocket(t, httpServer.URL, created.Stream)
+	socket.readMessage(t)
+
+	socket.sendCommand(t, "fly")
+	if message := socket.readMessage(t); message.Type != MessageError {
+		t.Errorf("message type = %q, want %q", message.Type, MessageError)
+	}
+
+	socket.send(t, opText, []byte("not json"))
+	if message := socket.readMessage(t); message.Type != MessageError {
+		t.Errorf("message type = %q, want %q", message.Type, MessageError)
+	}
+}
+
+func TestServer_StreamExportsFumen(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	socket := dialTestSocket(t, httpServer.URL, created.Stream)
+	socket.readMessage(t)
+
+	socket.sendCommand(t, "export")
+	message := socket.readMessage(t)
+	if message.Type != MessageFumen {
+		t.Fatalf("message = %+v, want a fumen message", message)
+	}
+	pages, err := fumen.Decode(message.Fumen)
+	if err != nil {
+		t.Fatalf("Decode(%q) error = %v", message.Fumen, err)
+	}
+	if len(pages) != 1 || pages[0].Piece == nil {
+		t.Errorf("pages = %+v, want one page with the current piece", pages)
+	}
+}
+
+func TestServer_StreamReportsCommandError(t *testing.T) {
+	_, httpServer := newTestServerWithConfig(t, application.GameConfig{Width: 12})
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	socket := dialTestSocket(t, httpServer.URL, created.Stream)
+	socket.readMessage(t)
+
+	// fumenは幅10の盤面しか表せないため、出力のエラーが返る
+	socket.sendCommand(t, "export")
+	if message := socket.readMessage(t); message.Type != MessageError || message.Error == "" {
+		t.Errorf("message = %+v, want an error message", message)
+	}
+
+	socket.sendCommand(t, "drop")
+	if message := socket.readMessage(t); message.Type != MessageDelta {
+		t.Errorf("message type = %q, want %q after an input error", message.Type, MessageDelta)
+	}
+}
+
+func TestServer_StreamRejectsInvalidFrames(t *testing.T) {
+	mask := []byte{1, 2, 3, 4}
+	tests := []struct {
+		name  string
+		frame []byte
+	}{
+		{name: "マスクされていないフレーム", frame: []byte{finBit | opText, 2, '{', '}'}},
+		{name: "分割された制御フレーム", frame: append([]byte{opPing, maskBit}, mask...)},
+		{
+			name:  "125バイトを超える制御フレーム",
+			frame: append(append([]byte{finBit | opPing, maskBit | 126, 0, 126}, mask...), make([]byte, 126)...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, httpServer := newTestServer(t)
+			created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+			socket := dialTestSocket(t, httpServer.URL, created.Stream)
+			socket.readMessage(t)
+
+			if _, err := socket.conn.Write(tt.frame); err != nil {
+				t.Fatalf("frame write error = %v", err)
+			}
+			for {
+				opcode, _ := socket.readFrame(t)
+				if opcode == opClose {
+					return
+				}
+				if opcode == opPong {
+					t.Fatal("invalid control frame should not be answered")
+				}
+			}
+		})
+	}
+}
+
+func TestServer_StreamChecksOrigin(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	tests := []struct {
+		name   string
+		origin string
+		want   int
+	}{
+		{name: "同じオリジン", origin: httpServer.URL, want: http.StatusSwitchingProtocols},
+		{name: "別のオリジン", origin: "http://evil.example", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, httpServer.URL+created.Stream, nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest() error = %v", err)
+			}
+			request.Header.Set("Connection", "Upgrade")
+			request.Header.Set("Upgrade", "websocket")
+			request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			request.Header.Set("Sec-WebSocket-Version", "13")
+			request.Header.Set("Origin", tt.origin)
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("GET error = %v", err)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", response.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_StreamPingAndClose(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	socket := dialTestSocket(t, httpServer.URL, created.Stream)
+	socket.readMessage(t)
+
+	socket.send(t, opPing, []byte("hi"))
+	opcode, payload := socket.readFrame(t)
+	if opcode != opPong || string(payload) != "hi" {
+		t.Errorf("ping reply = (%d, %q), want (%d, %q)", opcode, payload, opPong, "hi")
+	}
+
+	socket.send(t, opClose, nil)
+	if opcode, _ := socket.readFrame(t); opcode != opClose {
+		t.Errorf("close reply opcode = %d, want %d", opcode, opClose)
+	}
+}
+
+func TestServer_StreamRequiresUpgrade(t *testing.T) {
+	_, httpServer := newTestServer(t)
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	response, err := http.Get(httpServer.URL + created.Stream)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", response.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestServer_ServesClient(t *testing.T) {
+	_, httpServer := newTestServer(t)
+
+	for _, path := range []string{"/", "/app.js"} {
+		response, err := http.Get(httpServer.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK || len(body) == 0 {
+			t.Errorf("GET %s status = %d, body length = %d", path, response.StatusCode, len(body))
+		}
+	}
+}
+
+func TestServer_IdleGameExpires(t *testing.T) {
+	server, httpServer := newTestServer(t)
+	server.tickInterval = time.Millisecond
+	server.idleTimeout = 10 * time.Millisecond
+
+	created := createGame(t, httpServer.URL, CreateGameRequest{})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, exists := server.lookupGame(created.ID); !exists {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("idle game should be removed")
+}
@@ -0,0 +1,149 @@
+"use strict";
+
+const CELL = 30;
+const COLORS = ["#0ff", "#ff0", "#a0f", "#0f0", "#f00", "#00f", "#f80"];
+const STATUS = ["プレイ中", "クリア", "終了"];
+const KEYS = {
+  ArrowLeft: "left", a: "left", A: "left",
+  ArrowRight: "right", d: "right", D: "right",
+  ArrowDown: "down", s: "down", S: "down",
+  ArrowUp: "rotate", w: "rotate", W: "rotate",
+  " ": "drop",
+  p: "pause", P: "pause",
+  r: "restart", R: "restart",
+  f: "export", F: "export",
+};
+
+let socket = null;
+let snapshot = null;
+
+function applyDelta(current, delta) {
+  const next = Object.assign({}, current);
+  if (delta.width || delta.height) {
+    next.width = delta.width;
+    next.height = delta.height;
+    next.rows = new Array(delta.height).fill(".".repeat(delta.width));
+  } else {
+    next.rows = current.rows.slice();
+  }
+  for (const [y, row] of Object.entries(delta.rows || {})) {
+    next.rows[Number(y)] = row;
+  }
+  for (const key of ["piece", "next", "score", "lines", "level", "garbage", "gameOver", "status"]) {
+    next[key] = delta[key];
+  }
+  return next;
+}
+
+function drawCell(ctx, x, y, color) {
+  ctx.fillStyle = color;
+  ctx.fillRect(x * CELL + 1, y * CELL + 1, CELL - 2, CELL - 2);
+}
+
+function render() {
+  if (!snapshot) {
+    return;
+  }
+
+  const board = document.getElementById("board");
+  board.width = snapshot.width * CELL;
+  board.height = snapshot.height * CELL;
+  const ctx = board.getContext("2d");
+  ctx.clearRect(0, 0, board.width, board.height);
+
+  snapshot.rows.forEach((row, y) => {
+    for (let x = 0; x < row.length; x++) {
+      if (row[x] === "#") {
+        drawCell(ctx, x, y, "#888");
+      }
+    }
+  });
+  if (snapshot.piece) {
+    for (const block of snapshot.piece.blocks || []) {
+      drawCell(ctx, block.X, block.Y, COLORS[snapshot.piece.type]);
+    }
+  }
+
+  const preview = document.getElementById("next");
+  const pctx = preview.getContext("2d");
+  pctx.clearRect(0, 0, preview.width, preview.height);
+  if (snapshot.next && snapshot.next.blocks) {
+    const minX = Math.min(...snapshot.next.blocks.map((b) => b.X));
+    const minY = Math.min(...snapshot.next.blocks.map((b) => b.Y));
+    for (const block of snapshot.next.blocks) {
+      drawCell(pctx, block.X - minX, block.Y - minY, COLORS[snapshot.next.type]);
+    }
+  }
+
+  document.getElementById("score").textContent = snapshot.score;
+  document.getElementById("lines").textContent = snapshot.lines;
+  document.getElementById("level-value").textContent = snapshot.level;
+  document.getElementById("status").textContent = snapshot.gameOver ? "ゲームオーバー" : STATUS[snapshot.status];
+}
+
+function connect(stream) {
+  if (socket) {
+    socket.close();
+  }
+  snapshot = null;
+
+  const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+  socket = new WebSocket(scheme + "//" + location.host + stream);
+  socket.onmessage = (event) => {
+    const message = JSON.parse(event.data);
+    switch (message.type) {
+      case "snapshot":
+        snapshot = message.snapshot;
+        break;
+      case "delta":
+        if (snapshot) {
+          snapshot = applyDelta(snapshot, message.delta);
+        }
+        break;
+      case "fumen":
+        document.getElementById("message").textContent = "fumen: " + message.fumen;
+        return;
+      case "error":
+        document.getElementById("message").textContent = message.error;
+        return;
+    }
+    render();
+  };
+  socket.onclose = () => {
+    document.getElementById("message").textContent = "接続が終了しました";
+  };
+}
+
+async function newGame(event) {
+  if (event) {
+    event.preventDefault();
+  }
+  document.getElementById("message").textContent = "";
+
+  const response = await fetch("/games", {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({
+      mode: document.getElementById("mode").value,
+      level: Number(document.getElementById("level").value),
+    }),
+  });
+  const body = await response.json();
+  if (!response.ok) {
+    document.getElementById("message").textContent = body.error;
+    return;
+  }
+  connect(body.stream);
+}
+
+document.getElementById("new-game").addEventListener("submit", newGame);
+document.addEventListener("keydown", (event) => {
+  const command = KEYS[event.key];
+  if (!command || !socket || socket.readyState !== WebSocket.OPEN || event.target.tagName === "INPUT") {
+    return;
+  }
+  event.preventDefault();
+  socket.send(JSON.stringify({ type: "command", command: command }));
+});
+
+newGame();
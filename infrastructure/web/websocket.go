@@ -0,0 +1,249 @@
+package web
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // RFC 6455 のハンドシェイクで規定されている
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	websocketGUID       = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	maxWebSocketMessage = 64 * 1024
+	maxControlPayload   = 125
+
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+
+	finBit  = 0x80
+	maskBit = 0x80
+)
+
+var (
+	ErrNotWebSocket  = errors.New("WebSocketのハンドシェイク要求ではありません")
+	ErrInvalidFrame  = errors.New("無効なWebSocketフレームです")
+	ErrSocketClosed  = errors.New("WebSocket接続が閉じられました")
+	ErrFrameTooLarge = errors.New("WebSocketメッセージが大きすぎます")
+	ErrInvalidOrigin = errors.New("許可されていないOriginです")
+)
+
+type wsConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" ||
+		key == "" {
+		return nil, ErrNotWebSocket
+	}
+	if !sameOrigin(r) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOrigin, r.Header.Get("Origin"))
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, fmt.Errorf("%w: 接続を引き継げません", ErrNotWebSocket)
+	}
+	conn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("接続引き継ぎエラー: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("ハンドシェイク送信エラー: %w", err)
+	}
+
+	return &wsConn{conn: conn, reader: buffered.Reader}, nil
+}
+
+// sameOrigin はブラウザから別のサイト経由で接続されていないかを確かめる。Originを送らないブラウザ以外のクライアントは許可する
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(parsed.Host, r.Host)
+}
+
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec // RFC 6455 のハンドシェイクで規定されている
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ReadMessage はテキスト・バイナリメッセージを1件読み込む。ping には自動で応答する
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	var message []byte
+	fragmented := false
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			_ = c.writeFrame(opClose, payload)
+			return nil, ErrSocketClosed
+		case opText, opBinary:
+			if fragmented {
+				return nil, fmt.Errorf("%w: 分割メッセージの途中です", ErrInvalidFrame)
+			}
+			message = payload
+		case opContinuation:
+			if !fragmented {
+				return nil, fmt.Errorf("%w: 継続フレームが不正です", ErrInvalidFrame)
+			}
+			if len(message)+len(payload) > maxWebSocketMessage {
+				return nil, ErrFrameTooLarge
+			}
+			message = append(message, payload...)
+		default:
+			return nil, fmt.Errorf("%w: opcode=%d", ErrInvalidFrame, opcode)
+		}
+
+		if fin {
+			return message, nil
+		}
+		fragmented = true
+	}
+}
+
+func (c *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, c.readError(err)
+	}
+
+	fin = header[0]&finBit != 0
+	opcode = header[0] & 0x0F
+	if header[1]&maskBit == 0 {
+		return false, 0, nil, fmt.Errorf("%w: クライアントフレームがマスクされていません", ErrInvalidFrame)
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, c.readError(err)
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, c.readError(err)
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if length > maxWebSocketMessage {
+		return false, 0, nil, ErrFrameTooLarge
+	}
+	// RFC 6455 5.5: 制御フレームは分割できず、ペイロードは125バイトまで
+	if opcode >= opClose && (!fin || length > maxControlPayload) {
+		return false, 0, nil, fmt.Errorf("%w: 制御フレームが不正です", ErrInvalidFrame)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, c.readError(err)
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, c.readError(err)
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (c *wsConn) readError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+		return ErrSocketClosed
+	}
+	return fmt.Errorf("WebSocket受信エラー: %w", err)
+}
+
+func (c *wsConn) WriteText(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, finBit|opcode)
+
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+	frame = append(frame, payload...)
+
+	if _, err := c.conn.Write(frame); err != nil {
+		if errors.Is(err, net.ErrClosed) {
+			return ErrSocketClosed
+		}
+		return fmt.Errorf("WebSocket送信エラー: %w", err)
+	}
+	return nil
+}
+
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(opClose, nil)
+	return c.conn.Close()
+}
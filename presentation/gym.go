@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"tetris/application/rl"
+	"tetris/infrastructure/gym"
+)
+
+// runGym は強化学習用の環境を標準入出力、またはUnixソケットで提供する。標準出力はプロトコル専用のためログは標準エラーに出す
+func runGym(args []string) error {
+	flags := flag.NewFlagSet("gym", flag.ContinueOnError)
+	socketPath := flags.String("unix", "", "環境を提供するUnixソケットのパス（省略時は標準入出力）")
+	maxSteps := flags.Int("max-steps", rl.DefaultMaxSteps, "1エピソードの最大ステップ数")
+	gravity := flags.Int("gravity", 0, "ピースが1段自然落下するまでのステップ数（0は自然落下なし）")
+	startLevel := flags.Int("level", 1, "開始レベル")
+	width := flags.Int("width", 0, "ボードの幅（4〜20。0で標準の10）")
+	height := flags.Int("height", 0, "ボードの高さ（4〜40。0で標準の20）")
+	hold := flags.Bool("hold", false, "ホールドの行動を有効にする")
+	preview := flags.Int("preview", 1, "観測に含めるネクストの数")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config := rl.Config{
+		MaxSteps:     *maxSteps,
+		GravitySteps: *gravity,
+		StartLevel:   *startLevel,
+		Width:        *width,
+		Height:       *height,
+		Hold:         *hold,
+		Preview:      *preview,
+	}
+
+	if *socketPath == "" {
+		return gym.Serve(rl.NewEnvironment(config), os.Stdin, os.Stdout)
+	}
+
+	listener, err := net.Listen("unix", *socketPath)
+	if err != nil {
+		return fmt.Errorf("環境ソケット作成エラー: %w", err)
+	}
+	server := gym.NewServer(config)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		_ = server.Close()
+	}()
+
+	log.Printf("強化学習環境を %s で提供します", *socketPath)
+	err = server.Serve(listener)
+	_ = os.Remove(*socketPath)
+	return err
+}
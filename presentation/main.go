@@ -2,40 +2,128 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"strings"
 	"tetris/application"
+	"tetris/application/ai"
+	"tetris/domain/model"
 	"tetris/infrastructure/console"
+	"tetris/infrastructure/fumen"
 	"tetris/infrastructure/input"
+	"tetris/infrastructure/storage"
 	"time"
 )
 
+var errQuit = errors.New("ゲーム終了")
+
 func main() {
-	if err := runGame(); err != nil {
+	if len(os.Args) > 1 {
+		if command, exists := subcommands[os.Args[1]]; exists {
+			if err := command(os.Args[2:]); err != nil && !errors.Is(err, errQuit) {
+				log.Fatalf("%sエラー: %v", os.Args[1], err)
+			}
+			return
+		}
+	}
+
+	modeName := flag.String("mode", application.ModeEndless,
+		"ゲームモード (endless, marathon, sprint, ultra, dig, survival, versus, puzzle)")
+	startLevel := flag.Int("level", 1, "開始レベル")
+	spectatePath := flag.String("spectate", "", "観戦者に配信するUnixソケットのパス")
+	autoplay := flag.Bool("autoplay", false, "AIに自動でプレイさせる")
+	searchDepth := flag.Int("ai-depth", 1, "自動プレイで先読みするピース数（1は現在のピースのみ）")
+	searchWidth := flag.Int("ai-width", ai.DefaultBeamWidth, "自動プレイの先読みで残す局面数")
+	searchBudget := flag.Duration("ai-budget", ai.DefaultTimeBudget, "自動プレイの1手あたりの持ち時間")
+	weightsPath := flag.String("ai-weights", "", "train サブコマンドで学習した重みのチェックポイント")
+	undoLimit := flag.Int("undo", 0, "練習用に取り消せるピースの数（0で無効。有効にすると記録を保存しない）")
+	puzzleDir := flag.String("puzzles", "puzzles", "パズルモードで読み込むパズルファイルのディレクトリ")
+	fumenData := flag.String("fumen", "", "fumen（v115）の盤面から練習を始める")
+	fumenPage := flag.Int("fumen-page", 1, "-fumen で使うページの番号")
+	pieceSet := flag.String("pieces", "",
+		"出現するピース（standard, pentomino, big を+でつなぐか、ピースセットのJSONファイル。記録は保存しない）")
+	width := flag.Int("width", 0, "ボードの幅（4〜20。0で標準の10）")
+	height := flag.Int("height", 0, "ボードの高さ（4〜40。0で標準の20）")
+	flag.Parse()
+
+	options := gameOptions{
+		modeName:     *modeName,
+		startLevel:   *startLevel,
+		spectatePath: *spectatePath,
+		autoplay:     *autoplay,
+		search: ai.SearchConfig{
+			Width:      *searchWidth,
+			Depth:      *searchDepth,
+			TimeBudget: *searchBudget,
+		},
+		weightsPath: *weightsPath,
+		undoLimit:   *undoLimit,
+		puzzleDir:   *puzzleDir,
+		fumen:       *fumenData,
+		fumenPage:   *fumenPage,
+		pieceSet:    *pieceSet,
+		width:       *width,
+		height:      *height,
+	}
+	if err := runGame(options); err != nil && !errors.Is(err, errQuit) {
 		log.Fatalf("ゲーム実行エラー: %v", err)
 	}
 }
 
-func runGame() error {
-	gameController, err := application.NewGameController()
-	if err != nil {
-		return fmt.Errorf("ゲームコントローラー初期化エラー: %w", err)
-	}
+type gameOptions struct {
+	modeName     string
+	startLevel   int
+	spectatePath string
+	autoplay     bool
+	search       ai.SearchConfig
+	weightsPath  string
+	undoLimit    int
+	puzzleDir    string
+	fumen        string
+	fumenPage    int
+	pieceSet     string
+	width        int
+	height       int
+}
 
+func runGame(options gameOptions) error {
 	display := console.NewDisplay()
 	keyboardInput := input.NewKeyboardInput()
 
+	if rawErr := keyboardInput.EnableRawMode(); rawErr != nil {
+		log.Printf("%v（Enterキーで入力を確定してください）", rawErr)
+	}
+
 	if startErr := keyboardInput.Start(); startErr != nil {
 		return fmt.Errorf("キーボード入力初期化エラー: %w", startErr)
 	}
 	defer keyboardInput.Stop()
 
-	fmt.Println("テトリスゲームを開始します！")
-	fmt.Println("何かキーを押してゲームを開始してください...")
+	if options.modeName == application.ModeVersus {
+		if options.spectatePath != "" {
+			return fmt.Errorf("対戦モードの観戦には serve サブコマンドを使用してください")
+		}
+		if options.pieceSet != "" || options.width != 0 || options.height != 0 {
+			return fmt.Errorf("対戦モードでは -pieces、-width、-height を使用できません")
+		}
+		return runVersus(display, keyboardInput, options.startLevel)
+	}
+
+	config, err := newGameConfig(options, display, keyboardInput)
+	if err != nil {
+		return err
+	}
 
-	_, err = keyboardInput.GetInput()
-	if err != nil && !errors.Is(err, input.ErrInputCancelled) {
-		return fmt.Errorf("入力待機エラー: %w", err)
+	gameController, err := application.NewGameControllerWithConfig(config)
+	if err != nil {
+		return fmt.Errorf("ゲームコントローラー初期化エラー: %w", err)
+	}
+
+	if err := waitForStart(os.Stdout, keyboardInput); err != nil {
+		return err
 	}
 
 	gameLoop := &GameLoop{
@@ -44,13 +132,173 @@ func runGame() error {
 		input:      keyboardInput,
 	}
 
+	if options.autoplay {
+		planner, err := newPlanner(options.search, options.weightsPath)
+		if err != nil {
+			return err
+		}
+		gameLoop.autoplay = ai.NewPlayer(gameController, planner)
+	}
+
+	if options.spectatePath != "" {
+		feedServer, err := startFeedServer(options.spectatePath)
+		if err != nil {
+			return err
+		}
+		defer feedServer.Close()
+		gameLoop.feed = feedServer.feed
+	}
+
 	return gameLoop.Run()
 }
 
+// newGameConfig はモードを選び、記録を保存するかどうかを含めたゲームの設定を作る
+func newGameConfig(options gameOptions, display *console.Display,
+	keyboardInput *input.KeyboardInput) (application.GameConfig, error) {
+	config := application.GameConfig{
+		StartLevel: options.startLevel,
+		UndoLimit:  options.undoLimit,
+		Width:      options.width,
+		Height:     options.height,
+	}
+	practice := options.fumen != ""
+	if practice {
+		if options.modeName != application.ModeEndless {
+			return application.GameConfig{}, fmt.Errorf("-fumen は -mode と同時に指定できません")
+		}
+		mode, err := newPracticeMode(options.fumen, options.fumenPage)
+		if err != nil {
+			return application.GameConfig{}, err
+		}
+		config.Mode = mode
+	} else if options.modeName == application.ModePuzzle {
+		if options.autoplay {
+			return application.GameConfig{}, fmt.Errorf("パズルモードでは自動プレイを使用できません")
+		}
+		mode, err := newPuzzleMode(display, keyboardInput, options.puzzleDir)
+		if err != nil {
+			return application.GameConfig{}, err
+		}
+		config.Mode = mode
+		// パズルは試行錯誤しやすいよう、指定がなければ全ピース分を取り消せるようにする
+		if config.UndoLimit == 0 {
+			config.UndoLimit = mode.PieceCount()
+		}
+	} else {
+		mode, err := application.NewGameMode(options.modeName)
+		if err != nil {
+			return application.GameConfig{}, fmt.Errorf("ゲームモード選択エラー: %w", err)
+		}
+		config.Mode = mode
+	}
+
+	pieces, piecesErr := loadPieceSet(options.pieceSet)
+	if piecesErr != nil {
+		return application.GameConfig{}, piecesErr
+	}
+	config.Pieces = pieces
+	// 先読みの深さに足りる分だけネクストを見せる
+	if options.autoplay && options.search.Depth > 1 {
+		config.Preview = options.search.Depth - 1
+	}
+
+	// 自動プレイ、取り消しを使える練習、共有された盤面からの練習、標準以外のピースの結果はプレイヤーの記録として保存しない
+	if !options.autoplay && config.UndoLimit == 0 && !practice && options.pieceSet == "" {
+		recordPath, err := storage.DefaultRecordPath()
+		if err != nil {
+			return application.GameConfig{}, fmt.Errorf("記録ファイルパス取得エラー: %w", err)
+		}
+		config.Records = storage.NewFileRecordRepository(recordPath)
+	}
+	return config, nil
+}
+
+// loadPieceSet は組み込みのピースセット名、または".json"で終わるピースセットファイルから出現するピースを決める。
+// 指定がなければnil（標準の7種類）を返す
+func loadPieceSet(name string) ([]model.TetrominoType, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if strings.HasSuffix(name, ".json") {
+		return storage.LoadPieceSet(name)
+	}
+	return model.ParsePieceSet(name)
+}
+
+// newPracticeMode はfumenの指定したページの盤面から始める練習モードを作る
+func newPracticeMode(data string, page int) (*application.PracticeMode, error) {
+	pages, err := fumen.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("fumen読み込みエラー: %w", err)
+	}
+	if page < 1 || page > len(pages) {
+		return nil, fmt.Errorf("fumenのページ%dがありません（全%dページ）", page, len(pages))
+	}
+	return application.NewPracticeMode(pages[page-1].Board), nil
+}
+
+// newPlanner は先読みしない場合は貪欲なボットを、先読みする場合はビームサーチを使う
+func newPlanner(config ai.SearchConfig, weightsPath string) (ai.Planner, error) {
+	weights := ai.DefaultWeights()
+	if weightsPath != "" {
+		loaded, err := storage.LoadWeights(weightsPath)
+		if err != nil {
+			return nil, err
+		}
+		weights = loaded
+	}
+
+	if config.Depth == 1 {
+		return ai.NewBot(weights), nil
+	}
+
+	searcher, err := ai.NewSearcher(weights, config)
+	if err != nil {
+		return nil, fmt.Errorf("探索設定エラー: %w", err)
+	}
+	return searcher, nil
+}
+
+func runVersus(display *console.Display, keyboardInput *input.KeyboardInput, startLevel int) error {
+	match, err := application.NewVersusMatch(application.GameConfig{
+		StartLevel: startLevel,
+		Seed:       uint64(time.Now().UnixNano()),
+	})
+	if err != nil {
+		return fmt.Errorf("対戦初期化エラー: %w", err)
+	}
+
+	if err := waitForStart(os.Stdout, keyboardInput); err != nil {
+		return err
+	}
+
+	versusLoop := &VersusLoop{
+		match:   match,
+		display: display,
+		input:   keyboardInput,
+	}
+
+	return versusLoop.Run()
+}
+
+func waitForStart(out io.Writer, keyboardInput *input.KeyboardInput) error {
+	fmt.Fprintln(out, "テトリスゲームを開始します！")
+	fmt.Fprintln(out, "何かキーを押してゲームを開始してください...")
+
+	_, err := keyboardInput.GetInput()
+	if err != nil && !errors.Is(err, input.ErrInputCancelled) {
+		return fmt.Errorf("入力待機エラー: %w", err)
+	}
+	return nil
+}
+
 type GameLoop struct {
 	controller *application.GameController
 	display    *console.Display
 	input      *input.KeyboardInput
+	feed       *application.StateFeed
+	autoplay   *ai.Player
+	message    string
 }
 
 func (gl *GameLoop) Run() error {
@@ -68,14 +316,19 @@ func (gl *GameLoop) Run() error {
 			if err := gl.display.Render(gameState); err != nil {
 				return fmt.Errorf("描画エラー: %w", err)
 			}
+			if gl.message != "" {
+				gl.display.PrintMessage(gl.message)
+			}
+			if gl.feed != nil {
+				gl.feed.Publish(gameState)
+			}
 
-		default:
-			inputStr, err := gl.input.GetInput()
-			if err != nil {
-				if errors.Is(err, input.ErrInputCancelled) {
-					return nil
-				}
-				continue
+		case <-gl.input.Done():
+			return nil
+
+		case inputStr, ok := <-gl.input.Inputs():
+			if !ok {
+				return nil
 			}
 
 			command, err := input.MapInputToCommand(inputStr)
@@ -91,16 +344,96 @@ func (gl *GameLoop) Run() error {
 }
 
 func (gl *GameLoop) update() error {
+	if gl.autoplay != nil {
+		if err := gl.autoplay.Step(); err != nil {
+			return err
+		}
+	}
 	return gl.controller.Update()
 }
 
 func (gl *GameLoop) handleCommand(command string) error {
 	switch command {
 	case "quit":
-		return fmt.Errorf("ゲーム終了")
+		return errQuit
 	case "restart":
+		if gl.autoplay != nil {
+			gl.autoplay.Reset()
+		}
 		return gl.controller.Reset()
+	case "pause":
+		return gl.controller.HandleInput(command)
+	case "export":
+		gl.exportFumen()
+		return nil
 	default:
+		// 自動プレイ中は移動・回転の入力を受け付けない
+		if gl.autoplay != nil {
+			return nil
+		}
 		return gl.controller.HandleInput(command)
 	}
 }
+
+// exportFumen は現在の盤面と操作中のピースをfumenに変換し、画面の下に表示し続ける
+func (gl *GameLoop) exportFumen() {
+	state := gl.controller.GetGameState()
+	data, err := fumen.Encode([]fumen.Page{{Board: state.Board, Piece: state.CurrentPiece, Lock: true}})
+	if err != nil {
+		gl.message = fmt.Sprintf("fumen出力エラー: %v", err)
+		return
+	}
+	gl.message = "fumen: " + data
+}
+
+type VersusLoop struct {
+	match   *application.VersusMatch
+	display *console.Display
+	input   *input.KeyboardInput
+}
+
+func (vl *VersusLoop) Run() error {
+	ticker := time.NewTicker(16 * time.Millisecond) // ~60 FPS
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := vl.match.Update(); err != nil {
+				return fmt.Errorf("対戦更新エラー: %w", err)
+			}
+
+			if err := vl.display.RenderVersus(vl.match.GetState()); err != nil {
+				return fmt.Errorf("描画エラー: %w", err)
+			}
+
+		case <-vl.input.Done():
+			return nil
+
+		case inputStr, ok := <-vl.input.Inputs():
+			if !ok {
+				return nil
+			}
+
+			command, err := input.MapVersusInputToCommand(inputStr)
+			if err != nil {
+				continue
+			}
+
+			if err := vl.handleCommand(command); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (vl *VersusLoop) handleCommand(command input.VersusCommand) error {
+	switch command.Command {
+	case "quit":
+		return errQuit
+	case "restart":
+		return vl.match.Reset()
+	default:
+		return vl.match.HandleInput(command.Player, command.Command)
+	}
+}
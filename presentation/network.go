@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"tetris/application"
+	"tetris/infrastructure/console"
+	"tetris/infrastructure/input"
+	"tetris/infrastructure/network"
+	"time"
+)
+
+var subcommands = map[string]func(args []string) error{
+	"serve": runServe,
+	"join":  runJoin,
+	"watch": runWatch,
+	"web":   runWeb,
+	"ssh":   runSSH,
+	"train": runTrain,
+	"gym":   runGym,
+	"sim":   runSim,
+}
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":7777", "待ち受けアドレス")
+	startLevel := flags.Int("level", 1, "開始レベル")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server := network.NewServer(application.GameConfig{StartLevel: *startLevel})
+	log.Printf("対戦サーバーを %s で起動します", *addr)
+
+	return server.ListenAndServe(*addr)
+}
+
+func runJoin(args []string) error {
+	flags := flag.NewFlagSet("join", flag.ContinueOnError)
+	name := flags.String("name", defaultPlayerName(), "プレイヤー名")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("使い方: join [-name 名前] host:port")
+	}
+
+	client, err := network.Dial(flags.Arg(0), *name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return runClient(client, "対戦相手を待っています...")
+}
+
+func runWatch(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	socketPath := flags.String("unix", "", "ローカルゲームの観戦用Unixソケット")
+	matchID := flags.Int("match", 0, "観戦する対戦番号（0は最新の対戦）")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	networkName, addr := "unix", *socketPath
+	if addr == "" {
+		if flags.NArg() != 1 {
+			return fmt.Errorf("使い方: watch [-match 番号] host:port | watch -unix ソケットパス")
+		}
+		networkName, addr = "tcp", flags.Arg(0)
+	}
+
+	client, err := network.Spectate(networkName, addr, *matchID)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return runClient(client, "観戦を開始します...")
+}
+
+func runClient(client *network.Client, banner string) error {
+	keyboardInput := input.NewKeyboardInput()
+	if rawErr := keyboardInput.EnableRawMode(); rawErr != nil {
+		log.Printf("%v（Enterキーで入力を確定してください）", rawErr)
+	}
+	if err := keyboardInput.Start(); err != nil {
+		return fmt.Errorf("キーボード入力初期化エラー: %w", err)
+	}
+	defer keyboardInput.Stop()
+
+	fmt.Println(banner)
+
+	messages := make(chan error, 1)
+	go func() {
+		for {
+			if _, err := client.Receive(); err != nil {
+				messages <- err
+				return
+			}
+		}
+	}()
+
+	clientLoop := &ClientLoop{
+		client:   client,
+		display:  console.NewDisplay(),
+		input:    keyboardInput,
+		received: messages,
+	}
+	return clientLoop.Run()
+}
+
+func defaultPlayerName() string {
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "player"
+}
+
+type ClientLoop struct {
+	client   *network.Client
+	display  *console.Display
+	input    *input.KeyboardInput
+	received <-chan error
+}
+
+func (cl *ClientLoop) Run() error {
+	ticker := time.NewTicker(16 * time.Millisecond) // ~60 FPS
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			view := cl.client.View()
+			if !view.Started {
+				continue
+			}
+			if err := cl.render(view); err != nil {
+				return fmt.Errorf("描画エラー: %w", err)
+			}
+
+		case err := <-cl.received:
+			if errors.Is(err, network.ErrConnectionClosed) && cl.client.View().Finished {
+				return cl.waitForQuit()
+			}
+			return err
+
+		case <-cl.input.Done():
+			return nil
+
+		case inputStr, ok := <-cl.input.Inputs():
+			if !ok {
+				return nil
+			}
+
+			command, err := input.MapInputToCommand(inputStr)
+			if err != nil {
+				continue
+			}
+			if command == "quit" {
+				return errQuit
+			}
+			// fumenの出力は手元のゲームだけが対象で、対戦サーバーには送らない
+			if command == "export" || cl.client.View().Spectator {
+				continue
+			}
+			if err := cl.client.SendInput(command); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// render は受信途中で状態が揃っていないフレームを描画せずに読み飛ばす
+func (cl *ClientLoop) render(view network.MatchView) error {
+	if view.PlayerCount == 1 {
+		state, err := view.GameState(0)
+		if err != nil {
+			return nil
+		}
+		return cl.display.Render(state)
+	}
+
+	state, err := view.VersusState()
+	if err != nil {
+		return nil
+	}
+	return cl.display.RenderVersus(state)
+}
+
+func (cl *ClientLoop) waitForQuit() error {
+	fmt.Println("ゲームが終了しました。Qで終了します。")
+	for {
+		select {
+		case <-cl.input.Done():
+			return nil
+		case inputStr, ok := <-cl.input.Inputs():
+			if !ok {
+				return nil
+			}
+			if command, err := input.MapInputToCommand(inputStr); err == nil && command == "quit" {
+				return errQuit
+			}
+		}
+	}
+}
+
+type spectateServer struct {
+	feed   *application.StateFeed
+	server *network.FeedServer
+	path   string
+}
+
+func startFeedServer(path string) (*spectateServer, error) {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("観戦ソケット作成エラー: %w", err)
+	}
+
+	feed := application.NewStateFeed()
+	server := network.NewFeedServer(feed)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Printf("観戦サーバーエラー: %v", err)
+		}
+	}()
+
+	return &spectateServer{feed: feed, server: server, path: path}, nil
+}
+
+func (s *spectateServer) Close() {
+	s.feed.Close()
+	_ = s.server.Close()
+	_ = os.Remove(s.path)
+}
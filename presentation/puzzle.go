@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"tetris/application"
+	"tetris/infrastructure/console"
+	"tetris/infrastructure/input"
+	"tetris/infrastructure/storage"
+)
+
+// newPuzzleMode はパズルの一覧を読み込み、選ばれたパズルのモードを作る
+func newPuzzleMode(display *console.Display, keyboardInput *input.KeyboardInput,
+	dir string) (*application.PuzzleMode, error) {
+	puzzles, err := storage.LoadPuzzles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzle, err := selectPuzzle(display, keyboardInput, puzzles)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewPuzzleMode(puzzle)
+}
+
+// selectPuzzle はW/Sで選んでSpaceで決定するか、番号キーで直接選ぶ
+func selectPuzzle(display *console.Display, keyboardInput *input.KeyboardInput,
+	puzzles []application.Puzzle) (application.Puzzle, error) {
+	selected := 0
+	for {
+		if err := display.RenderPuzzlePicker(puzzles, selected); err != nil {
+			return application.Puzzle{}, fmt.Errorf("描画エラー: %w", err)
+		}
+
+		inputStr, err := keyboardInput.GetInput()
+		if errors.Is(err, input.ErrInputCancelled) {
+			return application.Puzzle{}, errQuit
+		}
+		if err != nil {
+			return application.Puzzle{}, fmt.Errorf("入力待機エラー: %w", err)
+		}
+
+		if number, err := strconv.Atoi(inputStr); err == nil && number >= 1 && number <= len(puzzles) {
+			return puzzles[number-1], nil
+		}
+
+		command, err := input.MapInputToCommand(inputStr)
+		if err != nil {
+			continue
+		}
+		switch command {
+		case "quit":
+			return application.Puzzle{}, errQuit
+		case "rotate":
+			selected = (selected + len(puzzles) - 1) % len(puzzles)
+		case "down":
+			selected = (selected + 1) % len(puzzles)
+		case "drop":
+			return puzzles[selected], nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"tetris/application/ai"
+	"tetris/application/sim"
+	"tetris/infrastructure/storage"
+	"time"
+)
+
+func runSim(args []string) error {
+	flags := flag.NewFlagSet("sim", flag.ContinueOnError)
+	games := flags.Int("games", 1000, "実行するゲーム数")
+	seed := flags.Uint64("seed", 1, "最初のゲームのシード（以降は1ずつ増やす）")
+	pieces := flags.Int("pieces", 1000, "1ゲームの最大ピース数（0は制限なし）")
+	startLevel := flags.Int("level", 1, "開始レベル")
+	workers := flags.Int("workers", runtime.NumCPU(), "並列に実行するゲーム数")
+	scriptPath := flags.String("script", "", "AIの代わりに使う入力スクリプト（1行1コマンド）")
+	weightsPath := flags.String("ai-weights", "", "train サブコマンドで学習した重みのチェックポイント")
+	format := flags.String("format", "json", "出力形式 (json, csv)")
+	outputPath := flags.String("o", "", "出力ファイル（省略時は標準出力）")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("不明な出力形式です: %s", *format)
+	}
+
+	newDriver, err := newSimDriver(*scriptPath, *weightsPath)
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	report, err := sim.Run(sim.Config{
+		Games:      *games,
+		Seed:       *seed,
+		MaxPieces:  *pieces,
+		StartLevel: *startLevel,
+		Workers:    *workers,
+		NewDriver:  newDriver,
+	})
+	if err != nil {
+		return err
+	}
+
+	summary := report.Summary
+	log.Printf("%dゲーム (%v): ライン 平均%.1f 中央値%.1f 最大%d / スコア 平均%.0f 最大%d / トップアウト率 %.1f%%",
+		summary.Games, time.Since(started).Round(time.Millisecond), summary.MeanLines, summary.MedianLines,
+		summary.MaxLines, summary.MeanScore, summary.MaxScore, summary.TopOutRate*100)
+
+	var output io.Writer = os.Stdout
+	if *outputPath != "" {
+		file, err := os.Create(*outputPath)
+		if err != nil {
+			return fmt.Errorf("出力ファイル作成エラー: %w", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	if *format == "csv" {
+		return report.WriteCSV(output)
+	}
+	return report.WriteJSON(output)
+}
+
+func newSimDriver(scriptPath, weightsPath string) (func() sim.Driver, error) {
+	if scriptPath != "" {
+		file, err := os.Open(scriptPath)
+		if err != nil {
+			return nil, fmt.Errorf("入力スクリプトを開けません: %w", err)
+		}
+		defer file.Close()
+
+		commands, err := sim.ParseScript(file)
+		if err != nil {
+			return nil, err
+		}
+		return func() sim.Driver { return sim.NewScriptDriver(commands) }, nil
+	}
+
+	weights := ai.DefaultWeights()
+	if weightsPath != "" {
+		loaded, err := storage.LoadWeights(weightsPath)
+		if err != nil {
+			return nil, err
+		}
+		weights = loaded
+	}
+	return func() sim.Driver { return sim.NewPlannerDriver(ai.NewBot(weights)) }, nil
+}
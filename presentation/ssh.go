@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"tetris/application"
+	"tetris/infrastructure/console"
+	"tetris/infrastructure/input"
+	"tetris/infrastructure/sshserver"
+)
+
+func runSSH(args []string) error {
+	flags := flag.NewFlagSet("ssh", flag.ContinueOnError)
+	listen := flags.String("listen", ":2222", "待ち受けアドレス")
+	hostKeyPath := flags.String("host-key", "", "ホスト鍵のパス（省略時は設定ディレクトリに生成）")
+	modeName := flags.String("mode", application.ModeEndless, "ゲームモード")
+	startLevel := flags.Int("level", 1, "開始レベル")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if _, err := application.NewGameMode(*modeName); err != nil {
+		return fmt.Errorf("ゲームモード選択エラー: %w", err)
+	}
+
+	if *hostKeyPath == "" {
+		path, err := sshserver.DefaultHostKeyPath()
+		if err != nil {
+			return err
+		}
+		*hostKeyPath = path
+	}
+	hostKey, err := sshserver.LoadOrGenerateHostKey(*hostKeyPath)
+	if err != nil {
+		return err
+	}
+
+	server := sshserver.NewServer(hostKey, func(session *sshserver.Session) error {
+		log.Printf("SSHセッション開始: %s (%s %dx%d)", session.User, session.Term, session.Width, session.Height)
+		return playSSHSession(session, *modeName, *startLevel)
+	})
+	log.Printf("SSHサーバーを %s で起動します", *listen)
+
+	return server.ListenAndServe(*listen)
+}
+
+// playSSHSession はセッションごとに独立したコントローラーと入出力でゲームループを実行する
+func playSSHSession(session *sshserver.Session, modeName string, startLevel int) error {
+	mode, err := application.NewGameMode(modeName)
+	if err != nil {
+		return fmt.Errorf("ゲームモード選択エラー: %w", err)
+	}
+
+	gameController, err := application.NewGameControllerWithConfig(application.GameConfig{
+		Mode:       mode,
+		StartLevel: startLevel,
+	})
+	if err != nil {
+		return fmt.Errorf("ゲームコントローラー初期化エラー: %w", err)
+	}
+
+	keyboardInput := input.NewRemoteKeyboardInput(session.Stdin)
+	if err := keyboardInput.Start(); err != nil {
+		return fmt.Errorf("キーボード入力初期化エラー: %w", err)
+	}
+	defer keyboardInput.Stop()
+
+	if err := waitForStart(session.Stdout, keyboardInput); err != nil {
+		return err
+	}
+
+	gameLoop := &GameLoop{
+		controller: gameController,
+		display:    console.NewANSIDisplay(session.Stdout),
+		input:      keyboardInput,
+	}
+	if err := gameLoop.Run(); err != nil && !errors.Is(err, errQuit) {
+		return err
+	}
+	return nil
+}
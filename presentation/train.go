@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"tetris/application/ai"
+	"tetris/infrastructure/storage"
+)
+
+const defaultCheckpointPath = "weights.json"
+
+func runTrain(args []string) error {
+	config, checkpointPath, err := parseTrainFlags(args)
+	if err != nil {
+		return err
+	}
+
+	store := storage.NewFileCheckpointStore(checkpointPath)
+	checkpoint, err := store.Load()
+	if err != nil {
+		return err
+	}
+	if checkpoint.Generation > 0 {
+		log.Printf("%s の第%d世代から再開します", checkpointPath, checkpoint.Generation)
+	}
+
+	trainer, err := ai.NewTrainer(config, store)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	best, err := trainer.Run(ctx, func(report ai.GenerationReport) {
+		log.Printf("第%d世代: 最良 %.1f 平均 %.1f 通算最良 %.1f (%v)",
+			report.Generation, report.Best.Fitness, report.MeanFitness, report.BestEver.Fitness, report.Elapsed.Round(1e6))
+	})
+	if errors.Is(err, context.Canceled) {
+		log.Printf("中断しました。%s から再開できます", checkpointPath)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "最良の重み (%s=%.1f): %+v\n", config.Fitness, best.Fitness, best.Weights)
+	fmt.Fprintf(os.Stdout, "自動プレイで使うには: -autoplay -ai-weights %s\n", checkpointPath)
+	return nil
+}
+
+// parseTrainFlags は学習の設定とチェックポイントファイルのパスをフラグから読み取る
+func parseTrainFlags(args []string) (ai.TrainerConfig, string, error) {
+	config := ai.DefaultTrainerConfig()
+
+	flags := flag.NewFlagSet("train", flag.ContinueOnError)
+	flags.IntVar(&config.Population, "population", config.Population, "1世代の個体数")
+	flags.IntVar(&config.Generations, "generations", config.Generations, "学習する世代数（再開時は通算）")
+	flags.IntVar(&config.Games, "games", config.Games, "1個体あたりのゲーム数")
+	flags.IntVar(&config.MaxPieces, "pieces", config.MaxPieces, "1ゲームの最大ピース数")
+	flags.IntVar(&config.Workers, "workers", runtime.NumCPU(), "並列に実行するシミュレーション数")
+	flags.StringVar(&config.Fitness, "fitness", config.Fitness, "評価基準 (lines, score)")
+	flags.Uint64Var(&config.Seed, "seed", config.Seed, "乱数シード")
+	checkpointPath := flags.String("checkpoint", defaultCheckpointPath, "チェックポイントファイル（存在すれば再開する）")
+	if err := flags.Parse(args); err != nil {
+		return ai.TrainerConfig{}, "", err
+	}
+
+	if config.Elite >= config.Population {
+		config.Elite = config.Population - 1
+	}
+	return config, *checkpointPath, nil
+}
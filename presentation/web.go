@@ -0,0 +1,22 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"tetris/application"
+	"tetris/infrastructure/web"
+)
+
+func runWeb(args []string) error {
+	flags := flag.NewFlagSet("web", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "待ち受けアドレス")
+	startLevel := flags.Int("level", 1, "開始レベル")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server := web.NewServer(application.GameConfig{StartLevel: *startLevel})
+	log.Printf("Webサーバーを http://%s で起動します", *addr)
+
+	return server.ListenAndServe(*addr)
+}